@@ -1,47 +1,255 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
 	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/grpcapi"
 	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
 	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
 const ENV = ".env"
 
-func initConfig() {
-	viper.SetConfigFile(ENV)
-	viper.AutomaticEnv()
-	viper.SetConfigType("env")
+// readHeaderTimeout drops connections that never finish their request headers
+const readHeaderTimeout = 10 * time.Second
 
-	if err := viper.ReadInConfig(); err != nil {
-		logger.Error("No .env file found or error reading it: %v", err)
+// reloadOnHangup reloads the configuration and applies its LOG_LEVEL on every SIGHUP,
+// the other settings need a restart
+func reloadOnHangup() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	for range hangup {
+		cfg, err := config.Load(ENV)
+		if err != nil {
+			logger.Error("Reloading the configuration failed", "error", err)
+			continue
+		}
+		if err := logs.SetLevel(cfg.Logging.Level); err != nil {
+			logger.Error("Reloading the log level failed", "error", err)
+			continue
+		}
+		logger.Info("Configuration reloaded", "log_level", logs.Level())
 	}
 }
 
 var logger logs.Logger
 
 func main() {
-	logger := logs.Get()
-	initConfig()
+	// an invalid configuration stops the server before it serves anything,
+	// the error is logged with the default logging settings
+	cfg, err := config.Load(ENV)
+	if err != nil {
+		logs.Get().Fatal("❌ Configuration failed", "error", err)
+	}
+	logs.Init(cfg.Logging)
+	logger = logs.Get()
+
+	if args := os.Args[1:]; len(args) > 0 {
+		if args[0] != "migrate" {
+			fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", args[0], migrateUsage)
+			os.Exit(2)
+		}
+		if err := runMigrate(cfg.Database, logger, args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			_ = logger.Sync()
+			os.Exit(1)
+		}
+		_ = logger.Sync()
+		return
+	}
+	go reloadOnHangup()
+
+	// the first SIGINT or SIGTERM starts the shutdown, a second one kills the process
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Fatal("Tracing setup failed", "error", err)
+	}
+
+	// instances starting together wait on the advisory lock of the one that migrates
+	if cfg.Database.AutoMigrate {
+		if err := db.MigrateUp(cfg.Database, logger); err != nil {
+			logger.Fatal("❌ Database migration failed", "error", err)
+		}
+		logger.Info("✅ Database migrated", "version", db.SchemaVersion)
+	}
+
+	database, err := db.Open(cfg.Database)
+	if err != nil {
+		logger.Fatal("❌ Database setup failed", "error", err)
+	}
+	logger.Info("✅ Database connection established")
+	dbStats, err := db.StatsCollector(database, cfg.Database.Name)
+	if err != nil {
+		logger.Fatal("❌ Database setup failed", "error", err)
+	}
+
+	container := app.New(cfg, repository.NewRepositories(database), clock.Real(), logger)
+	container.Metrics.Register(dbStats)
+	services := container.Services
+
+	// serverErrors receives the error of a server that stopped on its own, it starts the shutdown as well
+	serverErrors := make(chan error, 3)
+	workers := newWorkerGroup()
 
-	port := viper.GetString("APP_PORT")
-	if port == "" {
-		logger.Fatal("No APP_PORT environment variable found")
-		panic("No APP_PORT environment variable found")
+	container.Metrics.RegisterRunningTimers(map[string]metrics.CountFunc{
+		"time_record": services.TimeRecord.CountRunning,
+		"pomodoro":    services.Pomodoro.CountRunning,
+	})
+	var metricsServer *http.Server
+	if metricsPort := cfg.Server.MetricsPort; metricsPort != "" {
+		metricsServer = &http.Server{Addr: ":" + metricsPort, Handler: container.Metrics.Handler(), ReadHeaderTimeout: readHeaderTimeout}
+		go func() {
+			logger.Info("🚀 Starting metrics server", "port", metricsPort)
+			if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- fmt.Errorf("metrics server: %w", err)
+			}
+		}()
 	}
 
-	db.Init()
+	workers.Go(func(ctx context.Context) {
+		services.Pomodoro.RunScheduler(ctx, cfg.Features.PomodoroTick())
+	})
+
+	workers.Go(container.OutboxDispatcher.Run)
+	workers.Go(container.WebhookDispatcher.Run)
+
+	grpcPort := cfg.Server.GRPCPort
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		logger.Fatal("gRPC listen failed", "error", err)
+	}
+	grpcServer := grpcapi.NewServer(container)
+	go func() {
+		logger.Info("🚀 Starting gRPC server", "port", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			serverErrors <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+
+	// requests are logged and panics recovered by the router's middleware instead of the gin defaults
+	engine := gin.New()
+	router.SetupRoutes(engine, container)
+	port := cfg.Server.Port
+	server := &http.Server{Addr: ":" + port, Handler: engine, ReadHeaderTimeout: readHeaderTimeout}
+	go func() {
+		logger.Info("🚀 Starting server", "port", port)
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- fmt.Errorf("server: %w", err)
+		}
+	}()
 
-	engine := gin.Default()
-	router.SetupRoutes(engine)
-	logger.Info("🚀 Starting server on port %s...", port)
-	if err := engine.Run(":" + port); err != nil {
-		logger.Fatal("Server failed: %v", err)
-		if viper.GetString("DEBUG") == "true" {
+	exitCode := 0
+	select {
+	case <-signals.Done():
+		logger.Info("Shutting down", "timeout", cfg.Server.ShutdownTimeout)
+	case err := <-serverErrors:
+		logger.Error("Server failed, shutting down", "error", err)
+		if cfg.Debug {
 			fmt.Printf("Server failed: %v\n", err)
 		}
+		exitCode = 1
+	}
+	stopSignals()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+
+	// open event streams never end on their own, closing the bus ends the SSE, WebSocket and gRPC streams
+	container.Bus.Close()
+
+	// new connections are refused while the requests in flight are drained
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		grpcServer.GracefulStop()
+	}()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("Server shutdown failed", "error", err)
+		exitCode = 1
+	}
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		logger.Error("gRPC server shutdown timed out, closing the open calls")
+		grpcServer.Stop()
+		exitCode = 1
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.Error("Metrics server shutdown failed", "error", err)
+		}
+	}
+
+	if err := workers.Stop(ctx); err != nil {
+		logger.Error("Background workers did not stop in time", "error", err)
+		exitCode = 1
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Tracing shutdown failed", "error", err)
+	}
+	if err := db.Close(database); err != nil {
+		logger.Error("Closing the database failed", "error", err)
+		exitCode = 1
+	}
+
+	cancel()
+	logger.Info("👋 Server stopped")
+	// syncing a terminal fails on some systems, the file is what needs flushing
+	_ = logger.Sync()
+	os.Exit(exitCode)
+}
+
+// workerGroup runs the background workers until Stop cancels their context
+type workerGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wait   sync.WaitGroup
+}
+
+func newWorkerGroup() *workerGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workerGroup{ctx: ctx, cancel: cancel}
+}
+
+func (group *workerGroup) Go(run func(ctx context.Context)) {
+	group.wait.Add(1)
+	go func() {
+		defer group.wait.Done()
+		run(group.ctx)
+	}()
+}
+
+// Stop cancels the workers and waits for them to finish their runs in progress, at most until ctx is done
+func (group *workerGroup) Stop(ctx context.Context) error {
+	group.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		group.wait.Wait()
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+)
+
+const migrateUsage = `usage: timekeeper migrate [-legacy-time-zone ZONE] <command>
+
+  up             apply every pending migration
+  down [N]       revert the last N migrations, 1 by default
+  status         print the applied and the latest version
+  force VERSION  record VERSION as applied without running it, after fixing a failed migration by hand
+
+  -legacy-time-zone ZONE  IANA zone the server ran in before migration 11, overrides DB_LEGACY_TIME_ZONE`
+
+// runMigrate runs the migrate subcommand with the embedded migrations against the configured database
+func runMigrate(cfg config.Database, logger logs.Logger, args []string) (err error) {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&cfg.LegacyTimeZone, "legacy-time-zone", cfg.LegacyTimeZone, "")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, migrateUsage)
+	}
+	args = flags.Args()
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+
+	var steps, version int
+	switch command {
+	case "up", "status":
+		if len(args) > 0 {
+			return errors.New(migrateUsage)
+		}
+	case "down":
+		steps = 1
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 1 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("down takes a positive number of migrations, got %q", args[0])
+			}
+		}
+	case "force":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+		if version, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("force takes a version number, got %q", args[0])
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", command, migrateUsage)
+	}
+
+	migrator, err := db.NewMigrator(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, migrator.Close())
+	}()
+
+	switch command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(steps)
+	case "force":
+		err = migrator.Force(version)
+	}
+	if err != nil {
+		return err
+	}
+
+	current, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version %d of %d", current, db.SchemaVersion)
+	if dirty {
+		fmt.Print(", dirty: fix the failed migration by hand, then run force")
+	}
+	fmt.Println()
+	return nil
+}
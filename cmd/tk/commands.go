@@ -0,0 +1,439 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+	"golang.org/x/term"
+)
+
+func signin(app *app, args []string) error {
+	flags := newFlagSet("signin")
+	server := flags.String("server", app.config.Server, "server URL")
+	email := flags.String("email", app.config.Email, "account email")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		*email = prompt("Email: ")
+	}
+	// the password is not taken as a flag, it would end up in the shell history and the process list
+	password := os.Getenv("TK_PASSWORD")
+	if password == "" {
+		var err error
+		if password, err = promptPassword("Password: "); err != nil {
+			return err
+		}
+	}
+
+	app.client = client.New(*server)
+	session, err := app.client.Signin(app.ctx, client.UserInput{Email: *email, Password: password})
+	if err != nil {
+		return err
+	}
+
+	app.config.Server = *server
+	app.config.Email = session.Email
+	app.config.Token = session.Token
+	if err := saveConfig(app.configPath, app.config); err != nil {
+		return err
+	}
+	return app.printer.message("Signed in as %s", session.Email)
+}
+
+func signout(app *app, args []string) error {
+	if err := parseFlags(newFlagSet("signout"), args, 0); err != nil {
+		return err
+	}
+	app.config.Token = ""
+	if err := saveConfig(app.configPath, app.config); err != nil {
+		return err
+	}
+	return app.printer.message("Signed out")
+}
+
+func whoami(app *app, args []string) error {
+	if err := parseFlags(newFlagSet("whoami"), args, 0); err != nil {
+		return err
+	}
+	profile, err := app.client.Profile(app.ctx)
+	if err != nil {
+		return err
+	}
+	return app.printer.print(profile, []string{"ID", "EMAIL", "TIME ZONE", "SERVER"}, [][]string{
+		{profile.ID.String(), profile.Email, profile.TimeZone, app.config.Server},
+	})
+}
+
+func projects(app *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing projects subcommand", errUsage)
+	}
+	subcommand, args := args[0], args[1:]
+
+	switch subcommand {
+	case "list":
+		if err := parseFlags(newFlagSet("projects list"), args, 0); err != nil {
+			return err
+		}
+		projects, err := app.client.ListProjects(app.ctx)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(projects))
+		for _, project := range projects {
+			rows = append(rows, []string{formatID(project.ID), project.Name, formatTime(project.CreatedAt, time.Local)})
+		}
+		return app.printer.print(projects, []string{"ID", "NAME", "CREATED"}, rows)
+
+	case "create":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: projects create takes a name", errUsage)
+		}
+		project, err := app.client.CreateProject(app.ctx, client.ProjectInput{Name: args[0]})
+		if err != nil {
+			return err
+		}
+		return app.printer.print(project, []string{"ID", "NAME"}, [][]string{{formatID(project.ID), project.Name}})
+
+	case "rename":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: projects rename takes an id and a name", errUsage)
+		}
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := app.client.RenameProject(app.ctx, id, client.ProjectInput{Name: args[1]}); err != nil {
+			return err
+		}
+		return app.printer.message("Project %d renamed to %s", id, args[1])
+
+	case "delete":
+		id, err := singleID(args)
+		if err != nil {
+			return err
+		}
+		if err := app.client.DeleteProject(app.ctx, id); err != nil {
+			return err
+		}
+		return app.printer.message("Project %d deleted", id)
+
+	default:
+		return fmt.Errorf("%w: unknown projects subcommand %q", errUsage, subcommand)
+	}
+}
+
+func tasks(app *app, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing tasks subcommand", errUsage)
+	}
+	subcommand, args := args[0], args[1:]
+
+	switch subcommand {
+	case "list":
+		flags := newFlagSet("tasks list")
+		all := flags.Bool("all", false, "include closed tasks")
+		if err := parseFlags(flags, args, 0); err != nil {
+			return err
+		}
+		list := app.client.ListActiveTasks
+		if *all {
+			list = app.client.ListTasks
+		}
+		tasks, err := list(app.ctx)
+		if err != nil {
+			return err
+		}
+		return app.printTasks(tasks)
+
+	case "create":
+		flags := newFlagSet("tasks create")
+		projectID := flags.Uint64("project", 0, "project id")
+		tags := flags.String("tags", "", "comma separated tags")
+		if err := parseFlags(flags, args, 1); err != nil {
+			return err
+		}
+		task, err := app.client.CreateTask(app.ctx, client.CreateTaskInput{
+			Name:      flags.Arg(0),
+			ProjectID: *projectID,
+			Tags:      splitTags(*tags),
+		})
+		if err != nil {
+			return err
+		}
+		return app.printTasks([]client.Task{*task})
+
+	case "show":
+		id, err := singleID(args)
+		if err != nil {
+			return err
+		}
+		task, err := app.client.GetTask(app.ctx, id)
+		if err != nil {
+			return err
+		}
+		return app.printTasks([]client.Task{*task})
+
+	case "update":
+		flags := newFlagSet("tasks update")
+		name := flags.String("name", "", "new name")
+		projectID := flags.Uint64("project", 0, "new project id")
+		tags := flags.String("tags", "", "new comma separated tags")
+		status := flags.String("status", "", "new status")
+		if err := parseFlags(flags, args, 1); err != nil {
+			return err
+		}
+		id, err := parseID(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		var input client.UpdateTaskInput
+		flags.Visit(func(changed *flag.Flag) {
+			switch changed.Name {
+			case "name":
+				input.Name = name
+			case "project":
+				input.ProjectID = projectID
+			case "tags":
+				splitted := splitTags(*tags)
+				input.Tags = &splitted
+			case "status":
+				input.Status = status
+			}
+		})
+		task, err := app.client.UpdateTask(app.ctx, id, input)
+		if err != nil {
+			return err
+		}
+		return app.printTasks([]client.Task{*task})
+
+	case "delete":
+		id, err := singleID(args)
+		if err != nil {
+			return err
+		}
+		if err := app.client.DeleteTask(app.ctx, id); err != nil {
+			return err
+		}
+		return app.printer.message("Task %d deleted", id)
+
+	default:
+		return fmt.Errorf("%w: unknown tasks subcommand %q", errUsage, subcommand)
+	}
+}
+
+func start(app *app, args []string) error {
+	id, err := singleID(args)
+	if err != nil {
+		return err
+	}
+	if err := app.client.StartTask(app.ctx, id); err != nil {
+		return err
+	}
+	return app.printer.message("Started task %d", id)
+}
+
+func stop(app *app, args []string) error {
+	if len(args) > 0 {
+		id, err := singleID(args)
+		if err != nil {
+			return err
+		}
+		if err := app.client.StopTask(app.ctx, id); err != nil {
+			return err
+		}
+		return app.printer.message("Stopped task %d", id)
+	}
+
+	running, err := app.runningTasks()
+	if err != nil {
+		return err
+	}
+	switch len(running) {
+	case 0:
+		return fmt.Errorf("no task is running")
+	case 1:
+		if err := app.client.StopTask(app.ctx, running[0].ID); err != nil {
+			return err
+		}
+		return app.printer.message("Stopped task %d %s", running[0].ID, running[0].Name)
+	default:
+		return fmt.Errorf("%d tasks are running, pass an id or use tk stop-all", len(running))
+	}
+}
+
+func stopAll(app *app, args []string) error {
+	if err := parseFlags(newFlagSet("stop-all"), args, 0); err != nil {
+		return err
+	}
+	if err := app.client.StopAllTasks(app.ctx); err != nil {
+		return err
+	}
+	return app.printer.message("Stopped all tasks")
+}
+
+func closeTask(app *app, args []string) error {
+	id, err := singleID(args)
+	if err != nil {
+		return err
+	}
+	if err := app.client.CloseTask(app.ctx, id); err != nil {
+		return err
+	}
+	return app.printer.message("Closed task %d", id)
+}
+
+type runningTask struct {
+	client.Task
+	StartedAt *time.Time `json:"started_at,omitempty"`
+}
+
+func status(app *app, args []string) error {
+	if err := parseFlags(newFlagSet("status"), args, 0); err != nil {
+		return err
+	}
+	running, err := app.runningTasks()
+	if err != nil {
+		return err
+	}
+	if len(running) == 0 && app.printer.format == outputTable {
+		return app.printer.message("No task is running")
+	}
+
+	rows := make([][]string, 0, len(running))
+	for _, task := range running {
+		started, elapsed := "", ""
+		if task.StartedAt != nil {
+			started = formatTime(*task.StartedAt, time.Local)
+			elapsed = formatDuration(time.Since(*task.StartedAt))
+		}
+		rows = append(rows, []string{formatID(task.ID), task.Name, started, elapsed})
+	}
+	return app.printer.print(running, []string{"ID", "TASK", "STARTED", "ELAPSED"}, rows)
+}
+
+// runningTasks returns the tasks being worked on with the start of their open time record
+func (app *app) runningTasks() ([]runningTask, error) {
+	active, err := app.client.ListActiveTasks(app.ctx)
+	if err != nil {
+		return nil, err
+	}
+	running := make([]runningTask, 0)
+	for _, task := range active {
+		if task.Status != client.StatusWorkingOn {
+			continue
+		}
+		taskID := task.ID
+		timeRecords, err := app.client.ListTimeRecords(app.ctx, client.TimeRecordFilter{TaskID: &taskID})
+		if err != nil {
+			return nil, err
+		}
+		entry := runningTask{Task: task}
+		for _, timeRecord := range timeRecords {
+			if !timeRecord.IsClosed {
+				startedAt := timeRecord.StartTime
+				entry.StartedAt = &startedAt
+				break
+			}
+		}
+		running = append(running, entry)
+	}
+	return running, nil
+}
+
+func (app *app) printTasks(tasks []client.Task) error {
+	rows := make([][]string, 0, len(tasks))
+	for _, task := range tasks {
+		project := ""
+		if task.ProjectID != 0 {
+			project = formatID(task.ProjectID)
+		}
+		rows = append(rows, []string{
+			formatID(task.ID),
+			task.Name,
+			string(task.Status),
+			project,
+			strings.Join(task.Tags, ","),
+		})
+	}
+	return app.printer.print(tasks, []string{"ID", "NAME", "STATUS", "PROJECT", "TAGS"}, rows)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parseFlags parses the flags and requires exactly positional arguments after them
+func parseFlags(flags *flag.FlagSet, args []string, positional int) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != positional {
+		return fmt.Errorf("%w: %s expects %d argument(s)", errUsage, flags.Name(), positional)
+	}
+	return nil
+}
+
+func singleID(args []string) (uint64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: expected one id", errUsage)
+	}
+	return parseID(args[0])
+}
+
+func parseID(value string) (uint64, error) {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid id %q", errUsage, value)
+	}
+	return id, nil
+}
+
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func splitTags(value string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// stdin is shared by the prompts, a reader of its own would buffer the answers to the later ones
+var stdin = bufio.NewReader(os.Stdin)
+
+func prompt(label string) string {
+	fmt.Fprint(os.Stderr, label)
+	line, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// promptPassword reads a password without echoing it, input that is not a terminal is read like prompt does
+func promptPassword(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return prompt(label), nil
+	}
+	fmt.Fprint(os.Stderr, label)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return string(password), nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	newFlags := func() (*bool, func(args []string, positional int) error) {
+		flags := newFlagSet("tasks list")
+		all := flags.Bool("all", false, "")
+		return all, func(args []string, positional int) error {
+			return parseFlags(flags, args, positional)
+		}
+	}
+
+	all, parse := newFlags()
+	if err := parse([]string{"--all"}, 0); err != nil || !*all {
+		t.Fatalf("parseFlags(--all) returned %v with all %t, want no error and all set", err, *all)
+	}
+	_, parse = newFlags()
+	if err := parse([]string{"--all", "42"}, 1); err != nil {
+		t.Fatalf("parseFlags with the expected argument returned %v", err)
+	}
+
+	cases := map[string]struct {
+		args       []string
+		positional int
+	}{
+		"unknown flag":        {args: []string{"--everything"}, positional: 0},
+		"missing argument":    {args: []string{"--all"}, positional: 1},
+		"unexpected argument": {args: []string{"42"}, positional: 0},
+		"argument too many":   {args: []string{"42", "43"}, positional: 1},
+		"invalid flag value":  {args: []string{"--all=maybe"}, positional: 0},
+	}
+	for name, test := range cases {
+		_, parse := newFlags()
+		if err := parse(test.args, test.positional); !errors.Is(err, errUsage) {
+			t.Errorf("%s: parseFlags(%q) returned %v, want a usage error", name, test.args, err)
+		}
+	}
+}
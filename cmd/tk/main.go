@@ -0,0 +1,142 @@
+// Command tk is a terminal client for GoTimekeeper.
+//
+//	tk signin --email me@example.com
+//	tk start 42
+//	tk status
+//	tk log --week
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+)
+
+const usage = `Usage: tk [-o table|json] [--config path] <command> [arguments]
+
+Session:
+  signin [--server URL] [--email EMAIL]
+  signout
+  whoami
+
+Projects:
+  projects list
+  projects create NAME
+  projects rename ID NAME
+  projects delete ID
+
+Tasks:
+  tasks list [--all]
+  tasks create [--project ID] [--tags a,b] NAME
+  tasks show ID
+  tasks update [--name NAME] [--project ID] [--tags a,b] [--status STATUS] ID
+  tasks delete ID
+
+Timer:
+  start ID          start working on a task
+  stop [ID]         stop the task, or the only running one
+  stop-all          stop every running task
+  close ID          stop and close a task
+  status            show running tasks
+
+Time records:
+  log [--today|--week] [--from TIME] [--to TIME] [--task ID]
+  add --from TIME --to TIME [--description TEXT] TASK_ID
+
+TIME is RFC 3339, "2006-01-02 15:04" or "15:04" for today, in the time zone of your profile.
+signin asks for the password unless TK_PASSWORD is set, the server is also read from TK_SERVER.
+TK_API_KEY authenticates with an API key instead of the signed in session.
+`
+
+type app struct {
+	ctx        context.Context
+	client     *client.Client
+	config     *config
+	configPath string
+	printer    *printer
+}
+
+type command func(app *app, args []string) error
+
+var commands = map[string]command{
+	"signin":   signin,
+	"signout":  signout,
+	"whoami":   whoami,
+	"projects": projects,
+	"tasks":    tasks,
+	"start":    start,
+	"stop":     stop,
+	"stop-all": stopAll,
+	"close":    closeTask,
+	"status":   status,
+	"log":      timeLog,
+	"add":      addTimeRecord,
+}
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	flags := flag.NewFlagSet("tk", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	output := flags.String("o", outputTable, "output format: table or json")
+	configPath := flags.String("config", defaultConfigPath(), "config file")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(os.Stderr, "tk: unknown output format %q\n", *output)
+		os.Exit(2)
+	}
+
+	args := flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "tk: unknown command %q\n\n", args[0])
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tk: read config %s: %v\n", *configPath, err)
+		os.Exit(1)
+	}
+
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stopSignals()
+
+	auth := client.WithToken(cfg.Token)
+	if apiKey := os.Getenv("TK_API_KEY"); apiKey != "" {
+		auth = client.WithAPIKey(apiKey)
+	}
+	application := &app{
+		ctx:        ctx,
+		client:     client.New(cfg.Server, auth),
+		config:     cfg,
+		configPath: *configPath,
+		printer:    &printer{format: *output, out: os.Stdout},
+	}
+	if err := run(application, args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "tk %s: %v\n\n", args[0], err)
+			flags.Usage()
+			os.Exit(2)
+		}
+		var apiError *client.APIError
+		if errors.As(err, &apiError) && apiError.StatusCode == 401 {
+			fmt.Fprintln(os.Stderr, "tk: not signed in or session expired, run tk signin")
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "tk: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+// print writes value as JSON or the rows as an aligned table
+func (printer *printer) print(value any, header []string, rows [][]string) error {
+	if printer.format == outputJSON {
+		encoder := json.NewEncoder(printer.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(printer.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// message prints a confirmation, in JSON mode as {"message": ...}
+func (printer *printer) message(format string, args ...any) error {
+	text := fmt.Sprintf(format, args...)
+	if printer.format == outputJSON {
+		return printer.print(map[string]string{"message": text}, nil, nil)
+	}
+	_, err := fmt.Fprintln(printer.out, text)
+	return err
+}
+
+func formatDuration(duration time.Duration) string {
+	duration = duration.Round(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(duration.Hours()), int(duration.Minutes())%60)
+}
+
+func formatTime(value time.Time, location *time.Location) string {
+	return value.In(location).Format("2006-01-02 15:04")
+}
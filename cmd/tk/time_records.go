@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+)
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+func timeLog(app *app, args []string) error {
+	flags := newFlagSet("log")
+	today := flags.Bool("today", false, "records started today")
+	week := flags.Bool("week", false, "records started this week")
+	from := flags.String("from", "", "start of the range")
+	to := flags.String("to", "", "end of the range")
+	taskID := flags.Uint64("task", 0, "records of one task")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	if *today && (*week || *from != "" || *to != "") {
+		return fmt.Errorf("%w: --today cannot be combined with another range", errUsage)
+	}
+
+	location, err := userLocation(app)
+	if err != nil {
+		return err
+	}
+	var filter client.TimeRecordFilter
+	now := time.Now().In(location)
+	switch {
+	case *week:
+		start := startOfWeek(now)
+		end := start.AddDate(0, 0, 7)
+		filter.From, filter.To = &start, &end
+	case *from != "" || *to != "":
+		if *from != "" {
+			parsed, err := parseTime(*from, now)
+			if err != nil {
+				return err
+			}
+			filter.From = &parsed
+		}
+		if *to != "" {
+			parsed, err := parseTime(*to, now)
+			if err != nil {
+				return err
+			}
+			filter.To = &parsed
+		}
+	default:
+		start := startOfDay(now)
+		end := start.AddDate(0, 0, 1)
+		filter.From, filter.To = &start, &end
+	}
+	if *taskID != 0 {
+		filter.TaskID = taskID
+	}
+
+	timeRecords, err := app.client.ListTimeRecords(app.ctx, filter)
+	if err != nil {
+		return err
+	}
+	tasks, err := app.client.ListTasks(app.ctx)
+	if err != nil {
+		return err
+	}
+	taskNames := make(map[uint64]string, len(tasks))
+	for _, task := range tasks {
+		taskNames[task.ID] = task.Name
+	}
+
+	var total time.Duration
+	rows := make([][]string, 0, len(timeRecords)+1)
+	for _, timeRecord := range timeRecords {
+		end, duration := "running", now.Sub(timeRecord.StartTime)
+		if timeRecord.EndTime != nil {
+			end = formatTime(*timeRecord.EndTime, location)
+			duration = timeRecord.EndTime.Sub(timeRecord.StartTime)
+		}
+		total += duration
+		rows = append(rows, []string{
+			formatID(timeRecord.ID),
+			taskNames[timeRecord.TaskID],
+			formatTime(timeRecord.StartTime, location),
+			end,
+			formatDuration(duration),
+			timeRecord.Description,
+		})
+	}
+	rows = append(rows, []string{"", "TOTAL", "", "", formatDuration(total), ""})
+	return app.printer.print(timeRecords, []string{"ID", "TASK", "START", "END", "DURATION", "DESCRIPTION"}, rows)
+}
+
+func addTimeRecord(app *app, args []string) error {
+	flags := newFlagSet("add")
+	from := flags.String("from", "", "start of the entry")
+	to := flags.String("to", "", "end of the entry")
+	description := flags.String("description", "", "what was done")
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" {
+		return fmt.Errorf("%w: --from and --to are required", errUsage)
+	}
+	taskID, err := parseID(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	location, err := userLocation(app)
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(location)
+	startTime, err := parseTime(*from, now)
+	if err != nil {
+		return err
+	}
+	endTime, err := parseTime(*to, now)
+	if err != nil {
+		return err
+	}
+
+	timeRecord, err := app.client.CreateTimeRecord(app.ctx, client.CreateTimeRecordInput{
+		TaskID:      taskID,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Description: *description,
+	})
+	if err != nil {
+		return err
+	}
+	return app.printer.print(timeRecord, []string{"ID", "TASK", "START", "END", "DURATION"}, [][]string{{
+		formatID(timeRecord.ID),
+		formatID(timeRecord.TaskID),
+		formatTime(timeRecord.StartTime, location),
+		formatTime(endTime, location),
+		formatDuration(endTime.Sub(startTime)),
+	}})
+}
+
+// parseTime accepts the layouts in timeLayouts or a clock time of the current day, all in the location of now
+func parseTime(value string, now time.Time) (time.Time, error) {
+	location := now.Location()
+	for _, layout := range timeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, nil
+		}
+	}
+	if clock, err := time.ParseInLocation("15:04", value, location); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, location), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid time %q", errUsage, value)
+}
+
+// userLocation is the time zone of the signed in user, days and times of the time log are shown in it.
+// Profiles without a zone the client knows fall back to the local time of the machine.
+func userLocation(app *app) (*time.Location, error) {
+	profile, err := app.client.Profile(app.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if profile.TimeZone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(profile.TimeZone)
+	if err != nil {
+		return time.Local, nil
+	}
+	return location, nil
+}
+
+func startOfDay(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
+}
+
+// startOfWeek returns the last Monday midnight
+func startOfWeek(value time.Time) time.Time {
+	offset := (int(value.Weekday()) + 6) % 7
+	return startOfDay(value).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location failed: %v", err)
+	}
+	now := time.Date(2025, time.March, 12, 16, 45, 0, 0, berlin)
+
+	cases := map[string]time.Time{
+		"2025-03-10T08:30:00Z":      time.Date(2025, time.March, 10, 8, 30, 0, 0, time.UTC),
+		"2025-03-10T08:30:00+05:00": time.Date(2025, time.March, 10, 3, 30, 0, 0, time.UTC),
+		"2025-03-10 08:30":          time.Date(2025, time.March, 10, 8, 30, 0, 0, berlin),
+		"2025-03-10T08:30":          time.Date(2025, time.March, 10, 8, 30, 0, 0, berlin),
+		"2025-03-10":                time.Date(2025, time.March, 10, 0, 0, 0, 0, berlin),
+		"09:15":                     time.Date(2025, time.March, 12, 9, 15, 0, 0, berlin),
+	}
+	for value, want := range cases {
+		got, err := parseTime(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseTime(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "25:00", "2025-13-01", "10.03.2025"} {
+		if _, err := parseTime(value, now); !errors.Is(err, errUsage) {
+			t.Errorf("parseTime(%q) returned %v, want a usage error", value, err)
+		}
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location failed: %v", err)
+	}
+	monday := time.Date(2025, time.March, 24, 0, 0, 0, 0, berlin)
+
+	cases := map[string]time.Time{
+		"monday midnight":            monday,
+		"monday evening":             time.Date(2025, time.March, 24, 23, 59, 0, 0, berlin),
+		"wednesday":                  time.Date(2025, time.March, 26, 12, 0, 0, 0, berlin),
+		"sunday evening":             time.Date(2025, time.March, 30, 23, 30, 0, 0, berlin),
+		"sunday after the DST shift": time.Date(2025, time.March, 30, 3, 30, 0, 0, berlin),
+	}
+	for name, value := range cases {
+		if got := startOfWeek(value); !got.Equal(monday) || got.Location() != berlin {
+			t.Errorf("startOfWeek of %s (%v) = %v, want %v", name, value, got, monday)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package app wires the components of the server. main builds one Container and hands it to the
+// HTTP and gRPC APIs, tests build their own against the database or fakes they need.
+package app
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Services are shared by the REST, GraphQL and gRPC APIs and the background workers,
+// the pomodoro scheduler and API calls must use the same PomodoroService
+type Services struct {
+	User       *service.UserService
+	Project    *service.ProjectService
+	Task       *service.TaskService
+	TimeRecord *service.TimeRecordService
+	Pomodoro   *service.PomodoroService
+	APIKey     *service.APIKeyService
+	Audit      *service.AuditService
+	Webhook    *service.WebhookService
+	Health     *service.HealthService
+}
+
+type Container struct {
+	Config *config.Config
+	Logger logs.Logger
+	Clock  clock.Clock
+	Bus    *event.Bus
+	Tokens *auth.JWT
+	// Metrics records on a registry of the container, GET /metrics serves it
+	Metrics      *metrics.Metrics
+	Repositories *repository.Repositories
+	Services     *Services
+
+	// OutboxDispatcher relays stored events to the Bus and the WebhookDispatcher, main runs both
+	OutboxDispatcher  *service.OutboxDispatcher
+	WebhookDispatcher *service.WebhookDispatcher
+}
+
+// New builds the services on top of repositories, the clock is the source of every stored timestamp
+func New(
+	cfg *config.Config,
+	repositories *repository.Repositories,
+	clock clock.Clock,
+	logger logs.Logger,
+) *Container {
+	recorder := metrics.New(prometheus.NewRegistry(), logger)
+	outbox := service.NewOutbox(repositories, clock)
+	timeRecordService := service.NewTimeRecordService(repositories, outbox, clock)
+	taskService := service.NewTaskService(repositories, outbox, clock, timeRecordService)
+
+	container := &Container{
+		Config:       cfg,
+		Logger:       logger,
+		Clock:        clock,
+		Bus:          event.NewBus(clock),
+		Tokens:       auth.NewJWT(cfg.Auth, clock),
+		Metrics:      recorder,
+		Repositories: repositories,
+		Services: &Services{
+			User:       service.NewUserService(repositories, clock, recorder),
+			Project:    service.NewProjectService(repositories, outbox, clock),
+			Task:       taskService,
+			TimeRecord: timeRecordService,
+			Pomodoro:   service.NewPomodoroService(repositories, clock, taskService, timeRecordService, logger, recorder),
+			APIKey:     service.NewAPIKeyService(repositories, clock),
+			Audit:      service.NewAuditService(repositories),
+			Webhook:    service.NewWebhookService(repositories, cfg.Webhooks, clock),
+			Health:     service.NewHealthService(repositories),
+		},
+		OutboxDispatcher:  service.NewOutboxDispatcher(outbox, logger, recorder),
+		WebhookDispatcher: service.NewWebhookDispatcher(repositories, cfg.Webhooks, clock, logger, recorder),
+	}
+	container.OutboxDispatcher.Subscribe("event-bus", service.PublishToBus(container.Bus))
+	container.OutboxDispatcher.Subscribe("webhooks", container.WebhookDispatcher.Enqueue)
+	return container
+}
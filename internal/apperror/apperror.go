@@ -0,0 +1,167 @@
+// Package apperror defines the errors API clients get to see. An Error carries a stable machine
+// readable code, the HTTP status and the public message, the cause it wraps is only logged.
+//
+// Services declare their errors as *Error sentinels and wrap them with fmt.Errorf("...: %w"),
+// the REST, gRPC and GraphQL layers find them again with From.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Code is a stable machine readable error code, clients may switch on it
+type Code string
+
+// Codes of the error categories, services add more specific ones next to their sentinels
+const (
+	CodeInvalidInput     Code = "invalid_input"
+	CodeValidationFailed Code = "validation_failed"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeNotFound         Code = "not_found"
+	CodeConflict         Code = "conflict"
+	CodeInternal         Code = "internal"
+)
+
+// sqlStateUniqueViolation is the Postgres error code of a unique constraint violation
+const sqlStateUniqueViolation = "23505"
+
+var (
+	ErrInternal = Internal(CodeInternal, "internal server error")
+	ErrConflict = Conflict(CodeConflict, "resource already exists")
+)
+
+// FieldError is a validation failure of one request field, Field is its JSON name
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Response is the JSON body of an error response, Error is kept for clients reading only the message
+type Response struct {
+	Error  string       `json:"error"`
+	Code   Code         `json:"code"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// Error is an error with a public representation
+type Error struct {
+	Code    Code
+	Status  int
+	Message string
+	Fields  []FieldError
+	Err     error
+
+	// sentinel is the error Wrap copied, errors.Is keeps matching it
+	sentinel *Error
+}
+
+func New(code Code, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func BadRequest(code Code, message string) *Error {
+	return New(code, http.StatusBadRequest, message)
+}
+
+func Unauthorized(code Code, message string) *Error {
+	return New(code, http.StatusUnauthorized, message)
+}
+
+func NotFound(code Code, message string) *Error {
+	return New(code, http.StatusNotFound, message)
+}
+
+func Conflict(code Code, message string) *Error {
+	return New(code, http.StatusConflict, message)
+}
+
+func Unprocessable(code Code, message string) *Error {
+	return New(code, http.StatusUnprocessableEntity, message)
+}
+
+func Internal(code Code, message string) *Error {
+	return New(code, http.StatusInternalServerError, message)
+}
+
+// Validation reports invalid request fields, all of them at once
+func Validation(fields ...FieldError) *Error {
+	return &Error{
+		Code:    CodeValidationFailed,
+		Status:  http.StatusUnprocessableEntity,
+		Message: "validation failed",
+		Fields:  fields,
+	}
+}
+
+func (err *Error) Error() string {
+	if err.Err != nil {
+		return err.Message + ": " + err.Err.Error()
+	}
+	return err.Message
+}
+
+// Response is the public representation of err, the cause is left out
+func (err *Error) Response() Response {
+	return Response{Error: err.Message, Code: err.Code, Fields: err.Fields}
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is matches the sentinel a wrapped error was created from
+func (err *Error) Is(target error) bool {
+	return err.sentinel != nil && target == error(err.sentinel)
+}
+
+// Wrap returns a copy of err caused by cause, it still matches err with errors.Is
+func (err *Error) Wrap(cause error) *Error {
+	wrapped := *err
+	wrapped.Err = cause
+	wrapped.sentinel = err.root()
+	return &wrapped
+}
+
+// WithMessage returns a copy of err with another public message, it still matches err with errors.Is
+func (err *Error) WithMessage(message string) *Error {
+	wrapped := *err
+	wrapped.Message = message
+	wrapped.sentinel = err.root()
+	return &wrapped
+}
+
+func (err *Error) root() *Error {
+	if err.sentinel != nil {
+		return err.sentinel
+	}
+	return err
+}
+
+// From returns the public error of err: the first *Error of its chain, not found for a missing record
+// and conflict for a unique violation. Anything else is reported as fallback, or ErrInternal without one.
+func From(err error, fallback *Error) *Error {
+	if fallback == nil {
+		fallback = ErrInternal
+	}
+
+	var appErr *Error
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound(CodeNotFound, fallback.Message)
+	case errors.Is(err, gorm.ErrDuplicatedKey) || IsUniqueViolation(err):
+		return ErrConflict
+	default:
+		return fallback
+	}
+}
+
+// IsUniqueViolation reports whether err is a Postgres unique constraint violation
+func IsUniqueViolation(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	return errors.As(err, &sqlErr) && sqlErr.SQLState() == sqlStateUniqueViolation
+}
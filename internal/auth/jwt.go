@@ -1,40 +1,74 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
 	"github.com/advanced-coder-com/go-timekeeper/internal/service"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/spf13/viper"
+	"github.com/google/uuid"
 	"time"
 )
 
-func GenerateJWT(userID string) (string, error) {
-	secret := viper.GetString("JWT_SECRET")
-	if secret == "" {
+// tokenLifetime is how long a token is accepted after it was issued
+const tokenLifetime = 24 * time.Hour
+
+// JWT signs and verifies the tokens of signed in users, issue and expiry times are taken from its clock
+type JWT struct {
+	secret string
+	clock  clock.Clock
+}
+
+func NewJWT(cfg config.Auth, clock clock.Clock) *JWT {
+	return &JWT{secret: cfg.JWTSecret, clock: clock}
+}
+
+func (tokens *JWT) Generate(userID string) (string, error) {
+	if tokens.secret == "" {
 		return "", service.ErrUserMissingJWTSecret
 	}
 
+	now := tokens.clock.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+		"iat":     jwt.NewNumericDate(now),
+		"exp":     jwt.NewNumericDate(now.Add(tokenLifetime)),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return token.SignedString([]byte(tokens.secret))
 }
 
-func VerifyJWT(tokenStr string) (*jwt.Token, jwt.MapClaims, error) {
-	secret := viper.GetString("JWT_SECRET")
-	if secret == "" {
+func (tokens *JWT) Verify(tokenStr string) (*jwt.Token, jwt.MapClaims, error) {
+	if tokens.secret == "" {
 		return nil, nil, service.ErrUserMissingJWTSecret
 	}
 
 	claims := jwt.MapClaims{}
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+		return []byte(tokens.secret), nil
+	}, jwt.WithTimeFunc(tokens.clock.Now))
 	if err != nil || !token.Valid {
 		return nil, nil, err
 	}
 
 	return token, claims, nil
 }
+
+// UserID verifies the token and returns the user it was issued to,
+// a token without a user_id claim holding a UUID is rejected although its signature is valid
+func (tokens *JWT) UserID(tokenStr string) (string, error) {
+	_, claims, err := tokens.Verify(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return "", errors.New("token has no user_id claim")
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", fmt.Errorf("token user_id claim is not a UUID: %w", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,78 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const secret = "test-secret"
+
+func sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token failed: %v", err)
+	}
+	return token
+}
+
+func TestUserIDOfGeneratedToken(t *testing.T) {
+	tokens := auth.NewJWT(config.Auth{JWTSecret: secret}, clock.Real())
+	userID := uuid.NewString()
+	token, err := tokens.Generate(userID)
+	if err != nil {
+		t.Fatalf("generate token failed: %v", err)
+	}
+	if verified, err := tokens.UserID(token); err != nil || verified != userID {
+		t.Fatalf("token was issued to %q, %v, want %s", verified, err, userID)
+	}
+}
+
+func TestUserIDRejectsTokensWithoutAValidUser(t *testing.T) {
+	tokens := auth.NewJWT(config.Auth{JWTSecret: secret}, clock.Real())
+	cases := map[string]jwt.MapClaims{
+		"missing claim":  {},
+		"number claim":   {"user_id": 42},
+		"empty claim":    {"user_id": ""},
+		"non UUID claim": {"user_id": "admin"},
+	}
+	for name, claims := range cases {
+		if userID, err := tokens.UserID(sign(t, claims)); err == nil {
+			t.Errorf("%s: token was accepted for user %q", name, userID)
+		}
+	}
+	if _, err := tokens.UserID(sign(t, jwt.MapClaims{"user_id": uuid.NewString()}) + "x"); err == nil {
+		t.Error("token with a broken signature was accepted")
+	}
+}
+
+func TestTokensExpireByTheClock(t *testing.T) {
+	issued := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFake(issued)
+	tokens := auth.NewJWT(config.Auth{JWTSecret: secret}, fakeClock)
+	token, err := tokens.Generate(uuid.NewString())
+	if err != nil {
+		t.Fatalf("generate token failed: %v", err)
+	}
+
+	_, claims, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("verify token failed: %v", err)
+	}
+	issuedAt, _ := claims.GetIssuedAt()
+	expiresAt, _ := claims.GetExpirationTime()
+	if !issuedAt.Equal(issued) || !expiresAt.Equal(issued.Add(24*time.Hour)) {
+		t.Fatalf("token issued at %v and expiring at %v, want %v and a day later", issuedAt, expiresAt, issued)
+	}
+
+	fakeClock.Set(issued.Add(24*time.Hour + time.Second))
+	if _, err := tokens.UserID(token); err == nil {
+		t.Fatal("expired token was accepted")
+	}
+}
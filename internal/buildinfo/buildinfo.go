@@ -0,0 +1,58 @@
+// Package buildinfo describes the running binary. Version, Commit and BuildTime are set at build time with
+//
+//	go build -ldflags "-X github.com/advanced-coder-com/go-timekeeper/internal/buildinfo.Commit=$(git rev-parse HEAD) ..."
+//
+// and fall back to the version control information the Go toolchain embeds, see make binary.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info is the build of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+}
+
+var (
+	info     Info
+	infoOnce sync.Once
+)
+
+func Get() Info {
+	infoOnce.Do(func() {
+		info = Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+
+		build, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	})
+	return info
+}
@@ -0,0 +1,20 @@
+// Package clock abstracts the current time so services can be run against a controlled time in tests.
+package clock
+
+import "time"
+
+// Clock tells the current time, services take every timestamp they store from it
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+// Real is the clock of the system
+func Real() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
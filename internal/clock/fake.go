@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a clock for tests that only moves when it is told to
+type Fake struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (fake *Fake) Now() time.Time {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return fake.now
+}
+
+// Set moves the clock to now, which may be in the past
+func (fake *Fake) Set(now time.Time) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.now = now
+}
+
+// Advance moves the clock forward by duration and returns the new time
+func (fake *Fake) Advance(duration time.Duration) time.Time {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.now = fake.now.Add(duration)
+	return fake.now
+}
@@ -0,0 +1,128 @@
+// Package config loads the configuration of the server into a typed Config and validates it at startup.
+//
+// Every setting has an environment variable, the env tag of its field. Values are taken from the environment,
+// then the .env file, then the optional YAML file named by CONFIG_FILE (keys are the mapstructure tags, e.g.
+// server.port) and finally the default tag. Components get their section of the Config passed explicitly.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ModeDevelopment = "development"
+	ModeProduction  = "production"
+)
+
+type Config struct {
+	Mode string `mapstructure:"mode" env:"MODE" default:"development" validate:"oneof=development production"`
+	// Debug prints server failures to stdout as well, for the debugger console
+	Debug bool `mapstructure:"debug" env:"DEBUG"`
+
+	Server   Server   `mapstructure:"server"`
+	Database Database `mapstructure:"database"`
+	Auth     Auth     `mapstructure:"auth"`
+	Logging  Logging  `mapstructure:"logging"`
+	Tracing  Tracing  `mapstructure:"tracing"`
+	Features Features `mapstructure:"features"`
+	Webhooks Webhooks `mapstructure:"webhooks"`
+}
+
+type Server struct {
+	Port     string `mapstructure:"port" env:"APP_PORT" validate:"required,port"`
+	GRPCPort string `mapstructure:"grpc_port" env:"GRPC_PORT" default:"9090" validate:"required,port"`
+	// MetricsPort serves /metrics on a separate listener, on the API port when empty
+	MetricsPort     string        `mapstructure:"metrics_port" env:"METRICS_PORT" validate:"omitempty,port"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" validate:"gt=0"`
+	// WSAllowedOrigins are the browser origins allowed to open the event WebSocket, "*" allows any
+	WSAllowedOrigins []string `mapstructure:"ws_allowed_origins" env:"WS_ALLOWED_ORIGINS"`
+}
+
+type Database struct {
+	Host     string `mapstructure:"host" env:"DB_HOST" validate:"required"`
+	Port     string `mapstructure:"port" env:"DB_PORT" default:"5432" validate:"required,port"`
+	User     string `mapstructure:"user" env:"DB_USER" validate:"required"`
+	Password string `mapstructure:"password" env:"DB_PASSWORD"`
+	Name     string `mapstructure:"name" env:"DB_NAME" validate:"required"`
+	// AutoMigrate applies the pending migrations at startup, instances starting together take turns
+	AutoMigrate bool `mapstructure:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+	// LegacyTimeZone is the zone of the server that wrote the timestamps before migration 11 stored them with
+	// their offset, the migration reads them as wall clock times of it
+	LegacyTimeZone string `mapstructure:"legacy_time_zone" env:"DB_LEGACY_TIME_ZONE" default:"UTC" validate:"timezone"`
+}
+
+// DSN is the connection string of the Postgres driver, sessions run in UTC so NOW() and date functions agree with the application
+func (database Database) DSN() string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
+		database.Host, database.User, database.Password, database.Name, database.Port,
+	)
+}
+
+type Auth struct {
+	JWTSecret string `mapstructure:"jwt_secret" env:"JWT_SECRET" validate:"required"`
+	// AdminToken authenticates the admin routes, they are disabled when it is empty
+	AdminToken string `mapstructure:"admin_token" env:"ADMIN_TOKEN" validate:"omitempty,min=16"`
+}
+
+type Logging struct {
+	// Level and Format default by Mode: debug and pretty in development, info and json in production
+	Level  string `mapstructure:"level" env:"LOG_LEVEL" validate:"omitempty,oneof=debug info warn error"`
+	Format string `mapstructure:"format" env:"LOG_FORMAT" validate:"omitempty,oneof=pretty json console"`
+	// StderrOnly writes to stderr only, without stdout and the file, for containers
+	StderrOnly bool   `mapstructure:"stderr_only" env:"LOG_STDERR_ONLY"`
+	File       string `mapstructure:"file" env:"LOG_FILE" default:"logs/app.log" validate:"required"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb" env:"LOG_MAX_SIZE_MB" default:"100" validate:"min=1"`
+	MaxBackups int    `mapstructure:"max_backups" env:"LOG_MAX_BACKUPS" default:"10" validate:"min=0"`
+	MaxAgeDays int    `mapstructure:"max_age_days" env:"LOG_MAX_AGE_DAYS" default:"30" validate:"min=0"`
+	// RotateInterval rotates the file at its multiples in UTC as well, 0 rotates by size only
+	RotateInterval time.Duration `mapstructure:"rotate_interval" env:"LOG_ROTATE_INTERVAL" default:"24h" validate:"min=0"`
+	Compress       bool          `mapstructure:"compress" env:"LOG_COMPRESS" default:"true"`
+	// StacktraceLevel is the lowest level file entries carry a stack trace from, set by Mode
+	StacktraceLevel string `mapstructure:"-"`
+}
+
+type Tracing struct {
+	Exporter    string  `mapstructure:"exporter" env:"TRACING_EXPORTER" default:"none" validate:"oneof=none stdout otlp"`
+	Endpoint    string  `mapstructure:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" validate:"omitempty,url"`
+	ServiceName string  `mapstructure:"service_name" env:"OTEL_SERVICE_NAME" default:"timekeeper" validate:"required"`
+	SampleRatio float64 `mapstructure:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1" validate:"gte=0,lte=1"`
+}
+
+type Features struct {
+	PomodoroTickSeconds int `mapstructure:"pomodoro_tick_seconds" env:"POMODORO_TICK_SECONDS" default:"5" validate:"min=1"`
+	// GraphQLMaxDepth, GraphQLMaxComplexity and GraphQLMaxRequestBytes override the limits of the GraphQL API when set
+	GraphQLMaxDepth        int `mapstructure:"graphql_max_depth" env:"GRAPHQL_MAX_DEPTH" validate:"min=0"`
+	GraphQLMaxComplexity   int `mapstructure:"graphql_max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" validate:"min=0"`
+	GraphQLMaxRequestBytes int `mapstructure:"graphql_max_request_bytes" env:"GRAPHQL_MAX_REQUEST_BYTES" validate:"min=0"`
+}
+
+// PomodoroTick is the interval of the pomodoro scheduler
+func (features Features) PomodoroTick() time.Duration {
+	return time.Duration(features.PomodoroTickSeconds) * time.Second
+}
+
+type Webhooks struct {
+	// AllowPrivateNetworks lets webhooks reach loopback, private and link-local addresses, for development and tests
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+}
+
+// applyModeDefaults fills the settings whose default depends on the mode
+func (config *Config) applyModeDefaults() {
+	production := config.Mode == ModeProduction
+	if config.Logging.Level == "" {
+		config.Logging.Level = pick(production, "info", "debug")
+	}
+	if config.Logging.Format == "" {
+		config.Logging.Format = pick(production, "json", "pretty")
+	}
+	config.Logging.StacktraceLevel = pick(production, "error", "debug")
+}
+
+func pick(condition bool, whenTrue string, whenFalse string) string {
+	if condition {
+		return whenTrue
+	}
+	return whenFalse
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// FileEnv names the optional YAML file, in the environment or the .env file
+const FileEnv = "CONFIG_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load reads the configuration, envFile is the .env file and may be missing. The returned error lists every
+// invalid setting at once by its environment variable.
+func Load(envFile string) (*Config, error) {
+	dotenv, err := readEnvFile(envFile)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := viper.New()
+	configFile := os.Getenv(FileEnv)
+	if configFile == "" {
+		configFile = dotenv[strings.ToLower(FileEnv)]
+	}
+	if configFile != "" {
+		settings.SetConfigFile(configFile)
+		settings.SetConfigType("yaml")
+		if err := settings.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read %s %s: %w", FileEnv, configFile, err)
+		}
+	}
+
+	if err := bind(settings, dotenv, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := settings.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("decode configuration: %w", err)
+	}
+	config.applyModeDefaults()
+
+	if err := validate(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Defaults is the configuration of the default tags, for tools and tests running without a configuration
+func Defaults() *Config {
+	settings := viper.New()
+	if err := bind(settings, nil, reflect.TypeOf(Config{}), ""); err != nil {
+		panic("config: " + err.Error())
+	}
+	var config Config
+	if err := settings.Unmarshal(&config); err != nil {
+		panic("config: " + err.Error())
+	}
+	config.applyModeDefaults()
+	return &config
+}
+
+// readEnvFile returns the variables of the .env file by lower case name, none when it does not exist
+func readEnvFile(envFile string) (map[string]string, error) {
+	dotenv := viper.New()
+	dotenv.SetConfigFile(envFile)
+	dotenv.SetConfigType("env")
+	if err := dotenv.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", envFile, err)
+	}
+
+	values := map[string]string{}
+	for _, key := range dotenv.AllKeys() {
+		values[key] = dotenv.GetString(key)
+	}
+	return values, nil
+}
+
+// bind registers the fields of configType under prefix: the environment variable, the .env value
+// that takes precedence over the YAML file and the default
+func bind(settings *viper.Viper, dotenv map[string]string, configType reflect.Type, prefix string) error {
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if err := bind(settings, dotenv, field.Type, key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		env := field.Tag.Get("env")
+		if env == "" {
+			return fmt.Errorf("config: field %s has no env tag", key)
+		}
+		if err := settings.BindEnv(key, env); err != nil {
+			return fmt.Errorf("config: bind %s: %w", env, err)
+		}
+		settings.SetDefault(key, field.Tag.Get("default"))
+		if _, inEnvironment := os.LookupEnv(env); !inEnvironment {
+			if value, ok := dotenv[strings.ToLower(env)]; ok {
+				settings.Set(key, value)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	playground "github.com/go-playground/validator/v10"
+)
+
+var validatorEngine = newValidator()
+
+func newValidator() *playground.Validate {
+	engine := playground.New()
+	// violations are reported by the environment variable of the field
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if env := field.Tag.Get("env"); env != "" {
+			return env
+		}
+		return field.Name
+	})
+	if err := engine.RegisterValidation("port", func(field playground.FieldLevel) bool {
+		port, err := strconv.ParseUint(field.Field().String(), 10, 16)
+		return err == nil && port > 0
+	}); err != nil {
+		panic(err)
+	}
+	return engine
+}
+
+func validate(config *Config) error {
+	err := validatorEngine.Struct(config)
+	var violations playground.ValidationErrors
+	if !errors.As(err, &violations) {
+		return err
+	}
+
+	problems := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		problems = append(problems, violation.Field()+" "+message(violation))
+	}
+	return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+}
+
+func message(violation playground.FieldError) string {
+	param := violation.Param()
+	switch violation.Tag() {
+	case "required":
+		return "is required"
+	case "port":
+		return "must be a port number"
+	case "url":
+		return "must be a URL"
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "min":
+		if violation.Kind() == reflect.String {
+			return "must be at least " + param + " characters long"
+		}
+		return "must be at least " + param
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lte":
+		return "must be at most " + param
+	default:
+		return "is invalid"
+	}
+}
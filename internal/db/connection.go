@@ -2,46 +2,44 @@ package db
 
 import (
 	"fmt"
-	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
-	"log"
-	"sync"
 
-	"github.com/spf13/viper"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var (
-	instance *gorm.DB
-	once     sync.Once
-	logger   logs.Logger
-)
-
-func Init() {
-	logger = logs.Get()
-	once.Do(func() {
-		dsn := fmt.Sprintf(
-			"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-			viper.GetString("DB_HOST"),
-			viper.GetString("DB_USER"),
-			viper.GetString("DB_PASSWORD"),
-			viper.GetString("DB_NAME"),
-			viper.GetString("DB_PORT"),
-		)
-
-		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-		if err != nil {
-			logger.Fatal("❌ Failed to connect to database: %v", err)
-		}
+// Open connects to the database, statements are traced and logged through the logger of their request
+// and times are written and read in UTC
+func Open(cfg config.Database) (*gorm.DB, error) {
+	database, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{Logger: newGormLogger(), NowFunc: nowUTC})
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if err := database.Use(tracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("register database tracing: %w", err)
+	}
+	if err := database.Use(utcPlugin{}); err != nil {
+		return nil, fmt.Errorf("register UTC times: %w", err)
+	}
+	return database, nil
+}
 
-		instance = db
-		logger.Info("✅ Database connection established")
-	})
+// StatsCollector reports the connection pool statistics of database under the database name
+func StatsCollector(database *gorm.DB, name string) (prometheus.Collector, error) {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get database connection pool: %w", err)
+	}
+	return collectors.NewDBStatsCollector(sqlDB, name), nil
 }
 
-func Get() *gorm.DB {
-	if instance == nil {
-		log.Fatal("❌ DB not initialized — call db.Init() first")
+// Close closes the connection pool, it is called on shutdown once nothing queries anymore
+func Close(database *gorm.DB) error {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
 	}
-	return instance
+	return sqlDB.Close()
 }
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	gormLogComponent = "gorm"
+
+	slowStatementThreshold = 200 * time.Millisecond
+)
+
+// gormLogger writes the GORM logs through the logger of the request the statement runs for,
+// so failed and slow statements carry its request ID. Statements are logged with placeholders,
+// bound values such as password hashes are left out.
+type gormLogger struct {
+	level gormlogger.LogLevel
+}
+
+func newGormLogger() gormlogger.Interface {
+	return &gormLogger{level: gormlogger.Warn}
+}
+
+func (logger *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (logger *gormLogger) Info(ctx context.Context, message string, data ...interface{}) {
+	if logger.level >= gormlogger.Info {
+		loggerOf(ctx).Info(fmt.Sprintf(message, data...))
+	}
+}
+
+func (logger *gormLogger) Warn(ctx context.Context, message string, data ...interface{}) {
+	if logger.level >= gormlogger.Warn {
+		loggerOf(ctx).Warn(fmt.Sprintf(message, data...))
+	}
+}
+
+func (logger *gormLogger) Error(ctx context.Context, message string, data ...interface{}) {
+	if logger.level >= gormlogger.Error {
+		loggerOf(ctx).Error(fmt.Sprintf(message, data...))
+	}
+}
+
+func (logger *gormLogger) Trace(
+	ctx context.Context,
+	begin time.Time,
+	fc func() (sql string, rowsAffected int64),
+	err error,
+) {
+	if logger.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && logger.level >= gormlogger.Error:
+		sql, rows := fc()
+		// expected failures such as unique violations are answered to the client, hence no error level
+		loggerOf(ctx).Warn("SQL statement failed", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case elapsed > slowStatementThreshold && logger.level >= gormlogger.Warn:
+		sql, rows := fc()
+		loggerOf(ctx).Warn("slow SQL statement", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case logger.level >= gormlogger.Info:
+		sql, rows := fc()
+		loggerOf(ctx).Debug("SQL statement", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
+
+// ParamsFilter keeps the bound values out of the logged statements
+func (logger *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func loggerOf(ctx context.Context) logs.Logger {
+	return logs.FromContext(ctx).With("component", gormLogComponent)
+}
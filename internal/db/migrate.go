@@ -0,0 +1,154 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// migrateLockTimeout is how long a migration waits for the advisory lock, instances starting together
+// wait for the one that migrates instead of failing
+const migrateLockTimeout = 5 * time.Minute
+
+// LegacyTimeZoneSetting is the session setting the migrations read config.Database.LegacyTimeZone from
+const LegacyTimeZoneSetting = "timekeeper.legacy_time_zone"
+
+// Migrator applies the migrations embedded in package migrations. golang-migrate holds a Postgres advisory lock
+// on the database while it migrates, the migrate CLI takes the same one, so concurrent runs apply each migration once.
+type Migrator struct {
+	migrate *migrate.Migrate
+}
+
+// NewMigrator connects to the database with a connection of its own, Close releases it.
+// The connection carries the legacy time zone of cfg as LegacyTimeZoneSetting.
+func NewMigrator(cfg config.Database, logger logs.Logger) (*Migrator, error) {
+	if _, err := time.LoadLocation(cfg.LegacyTimeZone); err != nil || cfg.LegacyTimeZone == "" {
+		return nil, fmt.Errorf("legacy time zone %q is not an IANA time zone", cfg.LegacyTimeZone)
+	}
+	source, err := iofs.New(migrations.Files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+	sqlDB, err := sql.Open("pgx/v5", fmt.Sprintf("%s %s=%s", cfg.DSN(), LegacyTimeZoneSetting, cfg.LegacyTimeZone))
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	driver, err := migratepgx.WithInstance(sqlDB, &migratepgx.Config{MigrationsTable: MigrationsTable})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	instance, err := migrate.NewWithInstance("iofs", source, cfg.Name, driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("set up migrations: %w", err)
+	}
+	instance.Log = migrateLogger{logger: logger.With("component", "Migrator")}
+	instance.LockTimeout = migrateLockTimeout
+	return &Migrator{migrate: instance}, nil
+}
+
+// MigrateUp applies the pending migrations, main runs it at startup when DB_AUTO_MIGRATE is set
+func MigrateUp(cfg config.Database, logger logs.Logger) (err error) {
+	migrator, err := NewMigrator(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, migrator.Close())
+	}()
+	return migrator.Up()
+}
+
+// Up applies every pending migration, it does nothing when the database is up to date
+func (migrator *Migrator) Up() error {
+	if err := migrator.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down reverts the last steps migrations
+func (migrator *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("revert migrations: steps must be at least 1, got %d", steps)
+	}
+	if err := migrator.migrate.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("revert migrations: %w", err)
+	}
+	return nil
+}
+
+// Version returns the applied version, 0 when no migration has been applied. A dirty version failed
+// half way and has to be fixed by hand and then set with Force.
+func (migrator *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = migrator.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Force records version as applied and clean without running any migration
+func (migrator *Migrator) Force(version int) error {
+	if err := migrator.migrate.Force(version); err != nil {
+		return fmt.Errorf("force migration version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Close closes the connection of the Migrator
+func (migrator *Migrator) Close() error {
+	sourceErr, databaseErr := migrator.migrate.Close()
+	return errors.Join(sourceErr, databaseErr)
+}
+
+// latestMigration is the version of the newest embedded migration
+func latestMigration(files fs.FS) (uint, error) {
+	source, err := iofs.New(files, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// migrateLogger writes the applied migrations to the application log
+type migrateLogger struct {
+	logger logs.Logger
+}
+
+func (migrateLogger migrateLogger) Printf(format string, v ...interface{}) {
+	migrateLogger.logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger migrateLogger) Verbose() bool {
+	return false
+}
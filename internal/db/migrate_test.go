@@ -0,0 +1,35 @@
+package db_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/migrations"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	ups, err := fs.Glob(migrations.Files, "*.up.sql")
+	if err != nil {
+		t.Fatalf("list migrations failed: %v", err)
+	}
+	if uint(len(ups)) != db.SchemaVersion {
+		t.Fatalf("%d up migrations are embedded, SchemaVersion is %d, versions have to be numbered without gaps", len(ups), db.SchemaVersion)
+	}
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		if _, err := fs.Stat(migrations.Files, down); err != nil {
+			t.Errorf("%s has no down migration: %v", up, err)
+		}
+	}
+}
+
+func TestNewMigratorRejectsUnknownLegacyTimeZone(t *testing.T) {
+	cfg := config.Database{Host: "localhost", Port: "5432", User: "user", Name: "timekeeper", LegacyTimeZone: "Mars/Olympus"}
+	if _, err := db.NewMigrator(cfg, logs.Get()); err == nil || !strings.Contains(err.Error(), "Mars/Olympus") {
+		t.Fatalf("migrator with an unknown legacy time zone returned %v, want the zone rejected", err)
+	}
+}
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/migrations"
+)
+
+// SchemaVersion is the version of the newest migration embedded from migrations/. The server is ready once
+// the database is migrated to it or beyond, a newer schema belongs to a newer instance of a rolling deploy.
+var SchemaVersion = mustLatestMigration()
+
+// MigrationsTable is the table the migrate tool records the applied version in
+const MigrationsTable = "schema_migrations"
+
+// mustLatestMigration panics on malformed migration file names, they are embedded at build time
+func mustLatestMigration() uint {
+	version, err := latestMigration(migrations.Files)
+	if err != nil {
+		panic(fmt.Sprintf("read embedded migrations: %v", err))
+	}
+	return version
+}
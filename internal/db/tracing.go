@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracingPluginName = "tracing"
+
+	// parentContextKey keeps the statement context the span replaced, it is restored when the span ends
+	parentContextKey = "tracing:parent_context"
+)
+
+// tracingPlugin creates a client span for every SQL statement run with a context that carries a span.
+// Statements of untraced contexts, such as the polls of the background jobs, are not traced.
+// The SQL is recorded with placeholders, the bound values are left out.
+type tracingPlugin struct{}
+
+func (plugin tracingPlugin) Name() string {
+	return tracingPluginName
+}
+
+func (plugin tracingPlugin) Initialize(database *gorm.DB) error {
+	callbacks := database.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startSpan("INSERT")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endSpan),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startSpan("SELECT")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endSpan),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startSpan("UPDATE")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endSpan),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("DELETE")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startSpan("SELECT")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endSpan),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("RAW")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(database *gorm.DB) {
+		ctx := database.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		name := operation
+		if database.Statement.Table != "" {
+			name += " " + database.Statement.Table
+		}
+		database.InstanceSet(parentContextKey, ctx)
+		database.Statement.Context, _ = tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(database.Statement.Table),
+			),
+		)
+	}
+}
+
+func endSpan(database *gorm.DB) {
+	parent, ok := database.InstanceGet(parentContextKey)
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(database.Statement.Context)
+	database.Statement.Context = parent.(context.Context)
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(database.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", database.RowsAffected),
+	)
+	if !errors.Is(database.Error, gorm.ErrRecordNotFound) {
+		tracing.RecordError(span, database.Error)
+	}
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// Transactor opens transactions on one database
+type Transactor struct {
+	database *gorm.DB
+}
+
+func NewTransactor(database *gorm.DB) *Transactor {
+	return &Transactor{database: database}
+}
+
+// WithTransaction runs fn in a database transaction. Repositories called with the context passed to fn
+// join the transaction, nested calls reuse the outer one.
+func (transactor *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return transactor.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+}
+
+// Session returns the transaction bound to ctx, or database when ctx carries none
+func Session(ctx context.Context, database *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return database.WithContext(ctx)
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const utcPluginName = "utc"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// utcPlugin stores and reads every time in UTC. The columns are TIMESTAMPTZ, so the instant is kept either way,
+// but the driver returns times in the zone of the server process and callers may pass any zone.
+type utcPlugin struct{}
+
+func (plugin utcPlugin) Name() string {
+	return utcPluginName
+}
+
+func (plugin utcPlugin) Initialize(database *gorm.DB) error {
+	callbacks := database.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("utc:before_create", normalizeDest),
+		callbacks.Update().Before("gorm:update").Register("utc:before_update", normalizeDest),
+		callbacks.Query().After("gorm:query").Register("utc:after_query", normalizeDest),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func normalizeDest(database *gorm.DB) {
+	if database.Error == nil {
+		NormalizeTimes(database.Statement.Dest)
+	}
+}
+
+// nowUTC is the time GORM sets autoCreateTime and autoUpdateTime fields to
+func nowUTC() time.Time {
+	return time.Now().UTC()
+}
+
+// NormalizeTimes converts the time.Time and *time.Time values of a model, a pointer or slice of models
+// or a map of column updates to UTC in place. Shared *time.Time values are replaced rather than changed.
+func NormalizeTimes(value any) {
+	normalizeTimes(reflect.ValueOf(value))
+}
+
+func normalizeTimes(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		elem := value.Elem()
+		if value.Kind() == reflect.Pointer && elem.Type() == timeType {
+			utc := elem.Interface().(time.Time).UTC()
+			if value.CanSet() {
+				value.Set(reflect.ValueOf(&utc))
+			} else {
+				elem.Set(reflect.ValueOf(utc))
+			}
+			return
+		}
+		normalizeTimes(elem)
+	case reflect.Struct:
+		if value.Type() == timeType {
+			if value.CanSet() {
+				value.Set(reflect.ValueOf(value.Interface().(time.Time).UTC()))
+			}
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				normalizeTimes(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch value.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Pointer, reflect.Interface:
+			for i := 0; i < value.Len(); i++ {
+				normalizeTimes(value.Index(i))
+			}
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			item := value.MapIndex(key)
+			if item.Kind() == reflect.Interface && !item.IsNil() {
+				item = item.Elem()
+			}
+			switch {
+			case item.Type() == timeType:
+				value.SetMapIndex(key, reflect.ValueOf(item.Interface().(time.Time).UTC()))
+			case item.Kind() == reflect.Pointer && !item.IsNil() && item.Elem().Type() == timeType:
+				utc := item.Elem().Interface().(time.Time).UTC()
+				value.SetMapIndex(key, reflect.ValueOf(&utc))
+			}
+		}
+	}
+}
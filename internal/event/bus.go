@@ -0,0 +1,168 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	ProjectCreated    Type = "project.created"
+	ProjectUpdated    Type = "project.updated"
+	ProjectDeleted    Type = "project.deleted"
+	TaskCreated       Type = "task.created"
+	TaskUpdated       Type = "task.updated"
+	TaskDeleted       Type = "task.deleted"
+	TaskStarted       Type = "task.started"
+	TaskStopped       Type = "task.stopped"
+	TaskClosed        Type = "task.closed"
+	TimeRecordCreated Type = "time_record.created"
+	TimeRecordUpdated Type = "time_record.updated"
+	TimeRecordDeleted Type = "time_record.deleted"
+)
+
+// Types lists every event type that services publish
+var Types = []Type{
+	ProjectCreated,
+	ProjectUpdated,
+	ProjectDeleted,
+	TaskCreated,
+	TaskUpdated,
+	TaskDeleted,
+	TaskStarted,
+	TaskStopped,
+	TaskClosed,
+	TimeRecordCreated,
+	TimeRecordUpdated,
+	TimeRecordDeleted,
+}
+
+func IsValidType(inputType string) bool {
+	for _, eventType := range Types {
+		if string(eventType) == inputType {
+			return true
+		}
+	}
+	return false
+}
+
+type Event struct {
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	UserID     string    `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// NewAt returns an event that occurred at the given time, services take it from their clock
+func NewAt(eventType Type, userID string, data any, occurredAt time.Time) Event {
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: occurredAt,
+		Data:       data,
+	}
+}
+
+// Bus is an in-process publish/subscribe hub for domain events.
+// Publishing never blocks: events are dropped for subscribers whose buffer is full.
+type Bus struct {
+	clock       clock.Clock
+	mutex       sync.RWMutex
+	subscribers map[uint64]*Subscription
+	nextID      uint64
+	closed      bool
+}
+
+type Subscription struct {
+	id     uint64
+	userID string
+	events chan Event
+	bus    *Bus
+	once   sync.Once
+}
+
+const DefaultBufferSize = 64
+
+func NewBus(clock clock.Clock) *Bus {
+	return &Bus{clock: clock, subscribers: make(map[uint64]*Subscription)}
+}
+
+// New returns an event that occurs now by the clock of the bus
+func (bus *Bus) New(eventType Type, userID string, data any) Event {
+	return NewAt(eventType, userID, data, bus.clock.Now())
+}
+
+// Subscribe registers a subscriber for events of the given user, an empty userID receives events of all users.
+func (bus *Bus) Subscribe(userID string, bufferSize int) *Subscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.nextID++
+	subscription := &Subscription{
+		id:     bus.nextID,
+		userID: userID,
+		events: make(chan Event, bufferSize),
+		bus:    bus,
+	}
+	if bus.closed {
+		subscription.once.Do(func() { close(subscription.events) })
+		return subscription
+	}
+	bus.subscribers[subscription.id] = subscription
+	return subscription
+}
+
+// Close ends every subscription so the streams reading them finish, subscriptions made afterwards start closed.
+// It is called on shutdown, the HTTP and gRPC servers wait for open streams otherwise.
+func (bus *Bus) Close() {
+	bus.mutex.Lock()
+	bus.closed = true
+	subscriptions := make([]*Subscription, 0, len(bus.subscribers))
+	for _, subscription := range bus.subscribers {
+		subscriptions = append(subscriptions, subscription)
+	}
+	bus.mutex.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.Close()
+	}
+}
+
+func (bus *Bus) Publish(event Event) {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	for _, subscription := range bus.subscribers {
+		if subscription.userID != "" && subscription.userID != event.UserID {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+		}
+	}
+}
+
+func (subscription *Subscription) Events() <-chan Event {
+	return subscription.events
+}
+
+// Close unregisters the subscription and closes its channel
+func (subscription *Subscription) Close() {
+	subscription.once.Do(func() {
+		subscription.bus.mutex.Lock()
+		delete(subscription.bus.subscribers, subscription.id)
+		subscription.bus.mutex.Unlock()
+		close(subscription.events)
+	})
+}
@@ -0,0 +1,96 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+)
+
+const (
+	userA = "6f1c1a5e-0000-4000-8000-00000000000a"
+	userB = "6f1c1a5e-0000-4000-8000-00000000000b"
+)
+
+func TestSubscriptionReceivesOnlyEventsOfItsUser(t *testing.T) {
+	bus := event.NewBus(clock.Real())
+	subscriptionA := bus.Subscribe(userA, 0)
+	defer subscriptionA.Close()
+	subscriptionB := bus.Subscribe(userB, 0)
+	defer subscriptionB.Close()
+	all := bus.Subscribe("", 0)
+	defer all.Close()
+
+	published := bus.New(event.TaskStarted, userA, map[string]uint64{"id": 1})
+	bus.Publish(published)
+
+	if received := receive(t, subscriptionA); received.ID != published.ID {
+		t.Fatalf("user A received event %s, want %s", received.ID, published.ID)
+	}
+	if received := receive(t, all); received.ID != published.ID {
+		t.Fatalf("the subscription to all users received event %s, want %s", received.ID, published.ID)
+	}
+	select {
+	case received := <-subscriptionB.Events():
+		t.Fatalf("user B received event %s of user A", received.ID)
+	default:
+	}
+}
+
+func TestPublishDropsEventsForFullSubscriptions(t *testing.T) {
+	bus := event.NewBus(clock.Real())
+	subscription := bus.Subscribe(userA, 1)
+	defer subscription.Close()
+
+	first := bus.New(event.TaskStarted, userA, nil)
+	bus.Publish(first)
+	bus.Publish(bus.New(event.TaskStopped, userA, nil))
+
+	if received := receive(t, subscription); received.ID != first.ID {
+		t.Fatalf("received event %s, want the first event %s", received.ID, first.ID)
+	}
+	select {
+	case received := <-subscription.Events():
+		t.Fatalf("received event %s that did not fit into the buffer", received.ID)
+	default:
+	}
+}
+
+func TestCloseEndsSubscriptions(t *testing.T) {
+	bus := event.NewBus(clock.Real())
+	subscription := bus.Subscribe(userA, 0)
+	bus.Close()
+
+	if _, ok := <-subscription.Events(); ok {
+		t.Fatal("subscription is open after the bus was closed")
+	}
+	if _, ok := <-bus.Subscribe(userA, 0).Events(); ok {
+		t.Fatal("subscription made after the bus was closed is open")
+	}
+	// closing twice and publishing to a closed bus must not panic
+	subscription.Close()
+	bus.Publish(bus.New(event.TaskStarted, userA, nil))
+}
+
+func TestNewEventsOccurByTheClockOfTheBus(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+	bus := event.NewBus(clock.NewFake(now))
+	if occurred := bus.New(event.TaskStarted, userA, nil).OccurredAt; !occurred.Equal(now) {
+		t.Fatalf("event occurred at %v, want %v", occurred, now)
+	}
+}
+
+func receive(t *testing.T, subscription *event.Subscription) event.Event {
+	t.Helper()
+	select {
+	case received, ok := <-subscription.Events():
+		if !ok {
+			t.Fatal("subscription is closed")
+		}
+		return received
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+	return event.Event{}
+}
@@ -0,0 +1,77 @@
+package graphqlapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+)
+
+// Error codes in the extensions of GraphQL errors
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeNotFound        = "NOT_FOUND"
+	codeConflict        = "CONFLICT"
+	codeInternal        = "INTERNAL"
+	codeQueryLimit      = "QUERY_LIMIT_EXCEEDED"
+)
+
+// Error is a resolver error, graphql-go adds its code to the error extensions.
+// Reason is the code of the REST API error, Fields its invalid fields.
+type Error struct {
+	Code    string
+	Reason  apperror.Code
+	Message string
+	Fields  []apperror.FieldError
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func (err *Error) Extensions() map[string]any {
+	extensions := map[string]any{"code": err.Code}
+	if err.Reason != "" {
+		extensions["reason"] = err.Reason
+	}
+	if len(err.Fields) > 0 {
+		extensions["fields"] = err.Fields
+	}
+	return extensions
+}
+
+func badUserInput(commonError *apperror.Error) error {
+	return errorOf(commonError)
+}
+
+// publicError logs err and returns the error the REST API would show for it
+func publicError(ctx context.Context, err error, commonError *apperror.Error) error {
+	logs.FromContext(ctx).Error("GraphQL resolver failed", "error", err)
+	return errorOf(apperror.From(err, commonError))
+}
+
+func errorOf(appErr *apperror.Error) *Error {
+	return &Error{
+		Code:    codeOf(appErr.Status),
+		Reason:  appErr.Code,
+		Message: appErr.Message,
+		Fields:  appErr.Fields,
+	}
+}
+
+func codeOf(httpStatus int) string {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codeBadUserInput
+	case http.StatusUnauthorized:
+		return codeUnauthenticated
+	case http.StatusNotFound:
+		return codeNotFound
+	case http.StatusConflict:
+		return codeConflict
+	default:
+		return codeInternal
+	}
+}
@@ -0,0 +1,121 @@
+// Package graphqlapi serves a GraphQL schema over users, projects, tasks and time records.
+// Nested lists are loaded with one query per level of the request instead of one per parent.
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Request is the body of a GraphQL request
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response
+type Response struct {
+	Data   any                        `json:"data,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// Executor runs requests against the schema with the depth and complexity limits
+type Executor struct {
+	schema        graphql.Schema
+	services      *Services
+	clock         clock.Clock
+	MaxDepth      int
+	MaxComplexity int
+}
+
+func NewExecutor(services *Services, clock clock.Clock) (*Executor, error) {
+	schema, err := newSchema(services)
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{
+		schema:        schema,
+		services:      services,
+		clock:         clock,
+		MaxDepth:      DefaultMaxDepth,
+		MaxComplexity: DefaultMaxComplexity,
+	}, nil
+}
+
+// request holds the state of one request, running time records count up to its now in every field
+type request struct {
+	userID  string
+	now     time.Time
+	loaders *loaders
+}
+
+type requestKey struct{}
+
+func requestOf(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// Execute runs the request for the user. The second result is false when the request was rejected
+// before execution because it does not parse, validate or fit the limits.
+func (executor *Executor) Execute(ctx context.Context, userID string, input Request) (*Response, bool) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(input.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &Response{Errors: gqlerrors.FormatErrors(err)}, false
+	}
+	// the limits are checked first, validating a document with many fragment spreads is costly too
+	err = checkLimits(&executor.schema, document, input.OperationName, executor.MaxDepth, executor.MaxComplexity)
+	if err != nil {
+		return &Response{Errors: []gqlerrors.FormattedError{withExtensions(gqlerrors.FormatError(err))}}, false
+	}
+	validation := graphql.ValidateDocument(&executor.schema, document, nil)
+	if !validation.IsValid {
+		return &Response{Errors: validation.Errors}, false
+	}
+
+	now := executor.clock.Now()
+	ctx = context.WithValue(ctx, requestKey{}, &request{
+		userID:  userID,
+		now:     now,
+		loaders: newLoaders(ctx, userID, now, executor.services),
+	})
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        executor.schema,
+		AST:           document,
+		OperationName: input.OperationName,
+		Args:          input.Variables,
+		Context:       ctx,
+	})
+	for index := range result.Errors {
+		result.Errors[index] = withExtensions(result.Errors[index])
+	}
+	return &Response{Data: result.Data, Errors: result.Errors}, true
+}
+
+// withExtensions adds the code of an Error, graphql-go only does so for errors of resolvers
+// that do not return a thunk
+func withExtensions(formatted gqlerrors.FormattedError) gqlerrors.FormattedError {
+	original := formatted.OriginalError()
+	for original != nil {
+		switch err := original.(type) {
+		case *Error:
+			formatted.Extensions = err.Extensions()
+			return formatted
+		case *gqlerrors.Error:
+			original = err.OriginalError
+		case gqlerrors.FormattedError:
+			original = err.OriginalError()
+		default:
+			return formatted
+		}
+	}
+	return formatted
+}
@@ -0,0 +1,60 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/memory"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestRunningDurationsUseClock(t *testing.T) {
+	fakeClock := clock.NewFake(time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC))
+	repositories := memory.NewRepositories()
+	outbox := service.NewOutbox(repositories, fakeClock)
+	timeRecords := service.NewTimeRecordService(repositories, outbox, fakeClock)
+	services := &Services{
+		Project:    service.NewProjectService(repositories, outbox, fakeClock),
+		Task:       service.NewTaskService(repositories, outbox, fakeClock, timeRecords),
+		TimeRecord: timeRecords,
+	}
+	userID := uuid.NewString()
+	ctx := requestctx.WithActor(context.Background(), userID)
+
+	project, err := services.Project.Create(ctx, userID, service.ProjectInput{Name: "Timekeeper"})
+	if err != nil {
+		t.Fatalf("create project failed: %v", err)
+	}
+	task, err := services.Task.Create(ctx, userID, service.CreateTaskInput{Name: "Running", ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	if err := services.Task.Start(ctx, task.ID, userID); err != nil {
+		t.Fatalf("start task failed: %v", err)
+	}
+	fakeClock.Set(fakeClock.Now().Add(90 * time.Minute))
+
+	executor, err := NewExecutor(services, fakeClock)
+	if err != nil {
+		t.Fatalf("build schema failed: %v", err)
+	}
+	response, executed := executor.Execute(ctx, userID, Request{
+		Query: "query { projects { totalSeconds tasks { totalSeconds timeRecords { durationSeconds } } } }",
+	})
+	if !executed || len(response.Errors) > 0 {
+		t.Fatalf("query failed: %v", response.Errors)
+	}
+	data, err := json.Marshal(response.Data)
+	if err != nil {
+		t.Fatalf("marshal data failed: %v", err)
+	}
+	want := `{"projects":[{"tasks":[{"timeRecords":[{"durationSeconds":5400}],"totalSeconds":5400}],"totalSeconds":5400}]}`
+	if string(data) != want {
+		t.Fatalf("query answered %s, want %s", data, want)
+	}
+}
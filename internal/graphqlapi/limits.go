@@ -0,0 +1,163 @@
+package graphqlapi
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	DefaultMaxDepth      = 8
+	DefaultMaxComplexity = 1000
+	// DefaultMaxRequestBytes caps the size of a request body, the query is read from it
+	DefaultMaxRequestBytes = 64 << 10
+
+	// listMultiplier is the assumed size of a list when estimating the complexity
+	listMultiplier = 5
+)
+
+// limitChecker measures the depth and estimated complexity of an operation before it runs.
+// Every field costs 1 plus the cost of its selections, multiplied by listMultiplier for lists.
+// Fragments are measured once however often they are spread, and the walk stops as soon as a
+// limit is exceeded, so documents whose expansion grows exponentially are rejected in linear time.
+type limitChecker struct {
+	schema        *graphql.Schema
+	maxDepth      int
+	maxComplexity int
+	fragments     map[string]*ast.FragmentDefinition
+	measured      map[string]measure
+	visiting      map[string]bool
+}
+
+type measure struct {
+	depth      int
+	complexity int
+}
+
+func checkLimits(schema *graphql.Schema, document *ast.Document, operationName string, maxDepth int, maxComplexity int) error {
+	checker := &limitChecker{
+		schema:        schema,
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		measured:      make(map[string]measure),
+		visiting:      make(map[string]bool),
+	}
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			checker.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		root := schema.QueryType()
+		if operation.Operation == ast.OperationTypeMutation {
+			root = schema.MutationType()
+		}
+		depth, complexity := checker.selectionSet(operation.SelectionSet, root)
+		if depth > maxDepth {
+			return &Error{Code: codeQueryLimit, Message: fmt.Sprintf("query depth exceeds the limit of %d", maxDepth)}
+		}
+		if complexity > maxComplexity {
+			return &Error{
+				Code:    codeQueryLimit,
+				Message: fmt.Sprintf("query complexity exceeds the limit of %d", maxComplexity),
+			}
+		}
+	}
+	return nil
+}
+
+// selectionSet returns the depth and complexity of the selections on parent, parent is nil for
+// introspection types where only the field count matters
+func (checker *limitChecker) selectionSet(selectionSet *ast.SelectionSet, parent *graphql.Object) (int, int) {
+	if selectionSet == nil {
+		return 0, 0
+	}
+	depth, complexity := 0, 0
+	for _, selection := range selectionSet.Selections {
+		var selectionDepth, selectionComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selectionDepth, selectionComplexity = checker.field(selection, parent)
+		case *ast.InlineFragment:
+			selectionDepth, selectionComplexity = checker.selectionSet(selection.SelectionSet, checker.typeCondition(selection.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			selectionDepth, selectionComplexity = checker.fragmentSpread(selection, parent)
+		}
+		depth = max(depth, selectionDepth)
+		complexity += selectionComplexity
+		if checker.exceeded(depth, complexity) {
+			break
+		}
+	}
+	return depth, complexity
+}
+
+// fragmentSpread measures a fragment on its first spread and reuses the result for the others,
+// spreads of a fragment inside itself count nothing, validation rejects such cycles
+func (checker *limitChecker) fragmentSpread(spread *ast.FragmentSpread, parent *graphql.Object) (int, int) {
+	name := spread.Name.Value
+	if result, ok := checker.measured[name]; ok {
+		return result.depth, result.complexity
+	}
+	fragment := checker.fragments[name]
+	if fragment == nil || checker.visiting[name] {
+		return 0, 0
+	}
+	checker.visiting[name] = true
+	depth, complexity := checker.selectionSet(fragment.SelectionSet, checker.typeCondition(fragment.TypeCondition, parent))
+	delete(checker.visiting, name)
+	checker.measured[name] = measure{depth: depth, complexity: complexity}
+	return depth, complexity
+}
+
+func (checker *limitChecker) exceeded(depth int, complexity int) bool {
+	return depth > checker.maxDepth || complexity > checker.maxComplexity
+}
+
+func (checker *limitChecker) field(field *ast.Field, parent *graphql.Object) (int, int) {
+	var fieldType graphql.Type
+	if parent != nil {
+		if definition, ok := parent.Fields()[field.Name.Value]; ok {
+			fieldType = definition.Type
+		}
+	}
+
+	isList := false
+	for {
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+			continue
+		}
+		if list, ok := fieldType.(*graphql.List); ok {
+			isList = true
+			fieldType = list.OfType
+			continue
+		}
+		break
+	}
+	object, _ := fieldType.(*graphql.Object)
+
+	depth, complexity := checker.selectionSet(field.SelectionSet, object)
+	if isList {
+		complexity *= listMultiplier
+	}
+	// an exceeded limit is reported as just above it, nested lists cannot overflow the count
+	return min(depth+1, checker.maxDepth+1), min(complexity+1, checker.maxComplexity+1)
+}
+
+func (checker *limitChecker) typeCondition(condition *ast.Named, parent *graphql.Object) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	object, _ := checker.schema.Type(condition.Name.Value).(*graphql.Object)
+	return object
+}
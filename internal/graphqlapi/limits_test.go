@@ -0,0 +1,82 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func newTestExecutor(t *testing.T) *Executor {
+	t.Helper()
+	// the limits are checked before any resolver runs, so no service is needed
+	executor, err := NewExecutor(&Services{}, clock.Real())
+	if err != nil {
+		t.Fatalf("build schema failed: %v", err)
+	}
+	return executor
+}
+
+func mustParse(t *testing.T, query string) *ast.Document {
+	t.Helper()
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("parse query failed: %v", err)
+	}
+	return document
+}
+
+func executeRejected(t *testing.T, executor *Executor, query string) string {
+	t.Helper()
+	response, executed := executor.Execute(context.Background(), "", Request{Query: query})
+	if executed || len(response.Errors) != 1 {
+		t.Fatalf("query was executed with errors %v, want it rejected", response.Errors)
+	}
+	return response.Errors[0].Message
+}
+
+func TestLimitsCountEverySpreadOfAFragment(t *testing.T) {
+	executor := newTestExecutor(t)
+	// each projects list costs 1 plus 5 times its two fields
+	query := `
+		fragment names on Project { id name }
+		query { first: projects { ...names } second: projects { ...names } }`
+
+	executor.MaxComplexity = 22
+	if err := checkLimits(&executor.schema, mustParse(t, query), "", executor.MaxDepth, executor.MaxComplexity); err != nil {
+		t.Fatalf("query at the complexity limit was rejected: %v", err)
+	}
+	executor.MaxComplexity = 21
+	if message := executeRejected(t, executor, query); !strings.Contains(message, "complexity") {
+		t.Fatalf("query above the complexity limit was rejected with %q, want the complexity limit", message)
+	}
+}
+
+func TestLimitsRejectExponentialFragmentsQuickly(t *testing.T) {
+	executor := newTestExecutor(t)
+	// every fragment spreads the previous one twice, the expanded query has 2^60 fields
+	var query strings.Builder
+	query.WriteString("fragment f0 on Project { id }\n")
+	for i := 1; i <= 60; i++ {
+		fmt.Fprintf(&query, "fragment f%d on Project { ...f%d ... on Project { ...f%d } }\n", i, i-1, i-1)
+	}
+	query.WriteString("query { projects { ...f60 } }")
+
+	if message := executeRejected(t, executor, query.String()); !strings.Contains(message, "complexity") {
+		t.Fatalf("exponential query was rejected with %q, want the complexity limit", message)
+	}
+}
+
+func TestLimitsRejectDeepQueries(t *testing.T) {
+	executor := newTestExecutor(t)
+	executor.MaxDepth = 3
+	query := "query { timeRecords { task { project { tasks { name } } } } }"
+
+	if message := executeRejected(t, executor, query); !strings.Contains(message, "depth") {
+		t.Fatalf("deep query was rejected with %q, want the depth limit", message)
+	}
+}
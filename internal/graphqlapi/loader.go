@@ -0,0 +1,61 @@
+package graphqlapi
+
+import "sync"
+
+// thunk is a deferred resolver result. The executor calls the thunks of one level of the query
+// after resolving the whole level, which lets a loader collect the keys of every sibling first.
+type thunk = func() (any, error)
+
+// loader batches the keys requested while a level of the query resolves into one fetch and caches
+// the values for the rest of the request
+type loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	mutex   sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errors  map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errors: make(map[K]error),
+	}
+}
+
+// load queues the key and returns a thunk that resolves it, a missing key resolves to the zero value
+func (loader *loader[K, V]) load(key K) func() (V, error) {
+	loader.mutex.Lock()
+	if _, done := loader.values[key]; !done && loader.errors[key] == nil && !loader.queued[key] {
+		loader.queued[key] = true
+		loader.pending = append(loader.pending, key)
+	}
+	loader.mutex.Unlock()
+
+	return func() (V, error) {
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+		if loader.queued[key] {
+			loader.dispatch()
+		}
+		return loader.values[key], loader.errors[key]
+	}
+}
+
+// dispatch fetches every pending key at once, the caller holds the mutex
+func (loader *loader[K, V]) dispatch() {
+	keys := loader.pending
+	loader.pending = nil
+	values, err := loader.fetch(keys)
+	for _, key := range keys {
+		delete(loader.queued, key)
+		if err != nil {
+			loader.errors[key] = err
+			continue
+		}
+		loader.values[key] = values[key]
+	}
+}
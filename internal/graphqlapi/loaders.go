@@ -0,0 +1,175 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+)
+
+// timeRange is the from and to arguments of a field, time records are batched per range
+type timeRange struct {
+	from, to         time.Time
+	fromDate, toDate string
+}
+
+func (value timeRange) filter() service.TimeRecordFilter {
+	var filter service.TimeRecordFilter
+	if !value.from.IsZero() {
+		filter.From = &value.from
+	}
+	if !value.to.IsZero() {
+		filter.To = &value.to
+	}
+	filter.FromDate, filter.ToDate = value.fromDate, value.toDate
+	return filter
+}
+
+// loaders hold the batching loaders of one request, they only see the records of the request's user
+type loaders struct {
+	ctx      context.Context
+	userID   string
+	now      time.Time
+	services *Services
+
+	projects        *loader[uint64, *model.Project]
+	tasks           *loader[uint64, *model.Task]
+	tasksByProject  *loader[uint64, []model.Task]
+	mutex           sync.Mutex
+	timeRecords     map[timeRange]*loader[uint64, []model.TimeRecord]
+	projectDuration map[timeRange]*loader[uint64, time.Duration]
+}
+
+func newLoaders(ctx context.Context, userID string, now time.Time, services *Services) *loaders {
+	loaders := &loaders{
+		ctx:             ctx,
+		userID:          userID,
+		now:             now,
+		services:        services,
+		timeRecords:     make(map[timeRange]*loader[uint64, []model.TimeRecord]),
+		projectDuration: make(map[timeRange]*loader[uint64, time.Duration]),
+	}
+	loaders.projects = newLoader(loaders.fetchProjects)
+	loaders.tasks = newLoader(loaders.fetchTasks)
+	loaders.tasksByProject = newLoader(loaders.fetchTasksByProject)
+	return loaders
+}
+
+func (loaders *loaders) fetchProjects(ids []uint64) (map[uint64]*model.Project, error) {
+	projects, err := loaders.services.Project.GetAllByIDs(loaders.ctx, loaders.userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*model.Project, len(projects))
+	for index := range projects {
+		byID[projects[index].ID] = &projects[index]
+	}
+	return byID, nil
+}
+
+func (loaders *loaders) fetchTasks(ids []uint64) (map[uint64]*model.Task, error) {
+	tasks, err := loaders.services.Task.GetAllByIDs(loaders.ctx, loaders.userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*model.Task, len(tasks))
+	for index := range tasks {
+		byID[tasks[index].ID] = &tasks[index]
+	}
+	return byID, nil
+}
+
+func (loaders *loaders) fetchTasksByProject(projectIDs []uint64) (map[uint64][]model.Task, error) {
+	tasks, err := loaders.services.Task.GetAllByProjects(loaders.ctx, loaders.userID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	byProject := make(map[uint64][]model.Task, len(projectIDs))
+	for _, projectID := range projectIDs {
+		byProject[projectID] = []model.Task{}
+	}
+	for _, task := range tasks {
+		byProject[task.ProjectID] = append(byProject[task.ProjectID], task)
+	}
+	return byProject, nil
+}
+
+// timeRecordsByTask returns the loader of the time records of tasks started within the range
+func (loaders *loaders) timeRecordsByTask(within timeRange) *loader[uint64, []model.TimeRecord] {
+	loaders.mutex.Lock()
+	defer loaders.mutex.Unlock()
+	if existing, ok := loaders.timeRecords[within]; ok {
+		return existing
+	}
+	created := newLoader(func(taskIDs []uint64) (map[uint64][]model.TimeRecord, error) {
+		return loaders.fetchTimeRecords(taskIDs, within)
+	})
+	loaders.timeRecords[within] = created
+	return created
+}
+
+func (loaders *loaders) fetchTimeRecords(taskIDs []uint64, within timeRange) (map[uint64][]model.TimeRecord, error) {
+	timeRecords, err := loaders.services.TimeRecord.GetAllByTasks(loaders.ctx, loaders.userID, taskIDs, within.filter())
+	if err != nil {
+		return nil, err
+	}
+	byTask := make(map[uint64][]model.TimeRecord, len(taskIDs))
+	for _, taskID := range taskIDs {
+		byTask[taskID] = []model.TimeRecord{}
+	}
+	for _, timeRecord := range *timeRecords {
+		byTask[timeRecord.TaskID] = append(byTask[timeRecord.TaskID], timeRecord)
+	}
+	return byTask, nil
+}
+
+// projectDurations returns the loader of the tracked time of projects, it needs two queries per batch
+// because the time records are reached through the tasks
+func (loaders *loaders) projectDurations(within timeRange) *loader[uint64, time.Duration] {
+	loaders.mutex.Lock()
+	defer loaders.mutex.Unlock()
+	if existing, ok := loaders.projectDuration[within]; ok {
+		return existing
+	}
+	created := newLoader(func(projectIDs []uint64) (map[uint64]time.Duration, error) {
+		return loaders.fetchProjectDurations(projectIDs, within)
+	})
+	loaders.projectDuration[within] = created
+	return created
+}
+
+func (loaders *loaders) fetchProjectDurations(projectIDs []uint64, within timeRange) (map[uint64]time.Duration, error) {
+	tasks, err := loaders.services.Task.GetAllByProjects(loaders.ctx, loaders.userID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[uint64]time.Duration, len(projectIDs))
+	if len(tasks) == 0 {
+		return durations, nil
+	}
+	projectOfTask := make(map[uint64]uint64, len(tasks))
+	taskIDs := make([]uint64, 0, len(tasks))
+	for _, task := range tasks {
+		projectOfTask[task.ID] = task.ProjectID
+		taskIDs = append(taskIDs, task.ID)
+	}
+	timeRecords, err := loaders.services.TimeRecord.GetAllByTasks(loaders.ctx, loaders.userID, taskIDs, within.filter())
+	if err != nil {
+		return nil, err
+	}
+	for index := range *timeRecords {
+		timeRecord := &(*timeRecords)[index]
+		durations[projectOfTask[timeRecord.TaskID]] += duration(timeRecord, loaders.now)
+	}
+	return durations, nil
+}
+
+// duration counts a running time record up to now
+func duration(timeRecord *model.TimeRecord, now time.Time) time.Duration {
+	if timeRecord.EndTime == nil {
+		return now.Sub(timeRecord.StartTime)
+	}
+	return timeRecord.EndTime.Sub(timeRecord.StartTime)
+}
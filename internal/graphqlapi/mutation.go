@@ -0,0 +1,381 @@
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	createTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateTaskInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"projectId": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"tags":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"status":    &graphql.InputObjectFieldConfig{Type: taskStatus},
+		},
+	})
+	updateTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateTaskInput",
+		Description: "Only the given fields change, tags replace all tags",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"projectId": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"tags":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"status":    &graphql.InputObjectFieldConfig{Type: taskStatus},
+		},
+	})
+	createTimeRecordInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateTimeRecordInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"taskId":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"startTime":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+			"endTime":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	updateTimeRecordInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateTimeRecordInput",
+		Description: "Only the given fields change",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"taskId":      &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"startTime":   &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"endTime":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"isClosed":    &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+)
+
+// mutation maps every field to the service method behind the matching REST route
+func (builder *schemaBuilder) mutation() *graphql.Object {
+	services := builder.services
+	nameArgs := graphql.FieldConfigArgument{
+		"id":   idArgs["id"],
+		"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+	inputArgs := func(input *graphql.InputObject) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}}
+	}
+	idInputArgs := func(input *graphql.InputObject) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{
+			"id":    idArgs["id"],
+			"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+		}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createProject": &graphql.Field{
+				Type: graphql.NewNonNull(builder.project),
+				Args: graphql.FieldConfigArgument{"name": nameArgs["name"]},
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					name, _ := params.Args["name"].(string)
+					project, err := services.Project.Create(params.Context, userIDOf(params), service.ProjectInput{Name: name})
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrProjectCreateFailed)
+					}
+					return project, nil
+				},
+			},
+			"renameProject": &graphql.Field{
+				Type: graphql.NewNonNull(builder.project),
+				Args: nameArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					if _, err := idArg(params.Args, "id", service.ErrProjectInvalidInput); err != nil {
+						return nil, err
+					}
+					id, _ := params.Args["id"].(string)
+					name, _ := params.Args["name"].(string)
+					err := services.Project.Rename(params.Context, id, userIDOf(params), service.ProjectInput{Name: name})
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrProjectUpdateFailed)
+					}
+					project, err := services.Project.GetByID(params.Context, id, userIDOf(params))
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrProjectGetFailed)
+					}
+					return project, nil
+				},
+			},
+			"deleteProject": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					if _, err := idArg(params.Args, "id", service.ErrProjectInvalidInput); err != nil {
+						return nil, err
+					}
+					id, _ := params.Args["id"].(string)
+					if err := services.Project.Delete(params.Context, id, userIDOf(params)); err != nil {
+						return nil, publicError(params.Context, err, service.ErrProjectDeleteFailed)
+					}
+					return true, nil
+				},
+			},
+
+			"createTask": &graphql.Field{
+				Type: graphql.NewNonNull(builder.task),
+				Args: inputArgs(createTaskInput),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					input := inputOf(params)
+					create := service.CreateTaskInput{Tags: []string{}}
+					create.Name, _ = input["name"].(string)
+					if status, ok := input["status"].(model.TaskStatus); ok {
+						create.Status = string(status)
+					}
+					if tags, ok := input["tags"]; ok {
+						create.Tags = stringList(tags)
+					}
+					if _, ok := input["projectId"]; ok {
+						projectID, err := idArg(input, "projectId", service.ErrTaskInvalidInput)
+						if err != nil {
+							return nil, err
+						}
+						create.ProjectID = projectID
+					}
+					task, err := services.Task.Create(params.Context, userIDOf(params), create)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTaskCreateFailed)
+					}
+					return task, nil
+				},
+			},
+			"updateTask": &graphql.Field{
+				Type: graphql.NewNonNull(builder.task),
+				Args: idInputArgs(updateTaskInput),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrTaskInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					input := inputOf(params)
+					var update service.UpdateTaskInput
+					if name, ok := input["name"].(string); ok {
+						update.Name = &name
+					}
+					if status, ok := input["status"].(model.TaskStatus); ok {
+						value := string(status)
+						update.Status = &value
+					}
+					if tags, ok := input["tags"]; ok {
+						list := stringList(tags)
+						update.Tags = &list
+					}
+					if _, ok := input["projectId"]; ok {
+						projectID, err := idArg(input, "projectId", service.ErrTaskInvalidInput)
+						if err != nil {
+							return nil, err
+						}
+						update.ProjectID = &projectID
+					}
+					task, err := services.Task.Update(params.Context, id, userIDOf(params), update)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTaskUpdateFailed)
+					}
+					return task, nil
+				},
+			},
+			"deleteTask": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return builder.taskAction(params, services.Task.Delete, service.ErrTaskDeleteFailed, false)
+				},
+			},
+			"startTask": &graphql.Field{
+				Type: graphql.NewNonNull(builder.task),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return builder.taskAction(params, services.Task.Start, service.ErrTaskStartFailed, true)
+				},
+			},
+			"stopTask": &graphql.Field{
+				Type: graphql.NewNonNull(builder.task),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return builder.taskAction(params, services.Task.Stop, service.ErrTaskStopFailed, true)
+				},
+			},
+			"closeTask": &graphql.Field{
+				Type: graphql.NewNonNull(builder.task),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return builder.taskAction(params, services.Task.Close, service.ErrTaskUpdateFailed, true)
+				},
+			},
+			"stopAllTasks": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					if err := services.Task.StopAll(params.Context, userIDOf(params)); err != nil {
+						return nil, publicError(params.Context, err, service.ErrTaskStopFailed)
+					}
+					return true, nil
+				},
+			},
+
+			"createTimeRecord": &graphql.Field{
+				Type: graphql.NewNonNull(builder.timeRecord),
+				Args: inputArgs(createTimeRecordInput),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					input := inputOf(params)
+					taskID, err := idArg(input, "taskId", service.ErrTimeRecordInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					startTime, startOK := input["startTime"].(time.Time)
+					endTime, endOK := input["endTime"].(time.Time)
+					if !startOK || !endOK {
+						return nil, badUserInput(service.ErrTimeRecordInvalidInput)
+					}
+					description, _ := input["description"].(string)
+					timeRecord, err := services.TimeRecord.CreateManual(params.Context, userIDOf(params), service.CreateTimeRecordInput{
+						TaskID:      taskID,
+						StartTime:   startTime,
+						EndTime:     endTime,
+						Description: description,
+					})
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordCreateFailed)
+					}
+					return timeRecord, nil
+				},
+			},
+			"updateTimeRecord": &graphql.Field{
+				Type: graphql.NewNonNull(builder.timeRecord),
+				Args: idInputArgs(updateTimeRecordInput),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrTimeRecordInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					input := inputOf(params)
+					var update service.UpdateTimeRecordInput
+					if _, ok := input["taskId"]; ok {
+						taskID, err := idArg(input, "taskId", service.ErrTimeRecordInvalidInput)
+						if err != nil {
+							return nil, err
+						}
+						update.TaskID = &taskID
+					}
+					if startTime, ok := input["startTime"].(time.Time); ok {
+						update.StartTime = &startTime
+					}
+					if endTime, ok := input["endTime"].(time.Time); ok {
+						update.EndTime = &endTime
+					}
+					if isClosed, ok := input["isClosed"].(bool); ok {
+						update.IsClosed = &isClosed
+					}
+					if description, ok := input["description"].(string); ok {
+						update.Description = &description
+					}
+					// edits go through the task service so task statuses follow the record
+					timeRecord, err := services.Task.EditTimeRecord(params.Context, id, userIDOf(params), update)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordUpdateFailed)
+					}
+					return timeRecord, nil
+				},
+			},
+			"deleteTimeRecord": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrTimeRecordInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					if err := services.Task.DeleteTimeRecord(params.Context, id, userIDOf(params)); err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordDeleteFailed)
+					}
+					return true, nil
+				},
+			},
+			"restoreTimeRecord": &graphql.Field{
+				Type: graphql.NewNonNull(builder.timeRecord),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgs["id"],
+					"version": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrTimeRecordInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					version, _ := params.Args["version"].(int)
+					timeRecord, err := services.Task.RestoreTimeRecord(
+						params.Context,
+						id,
+						userIDOf(params),
+						service.RestoreTimeRecordInput{Version: version},
+					)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordRestoreFailed)
+					}
+					return timeRecord, nil
+				},
+			},
+			"undoTimeRecord": &graphql.Field{
+				Type:        graphql.NewNonNull(builder.timeRecord),
+				Description: "Reverts the last stop, edit or delete of a time record",
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					timeRecord, err := services.Task.UndoTimeRecordAction(params.Context, userIDOf(params))
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordRestoreFailed)
+					}
+					return timeRecord, nil
+				},
+			},
+		},
+	})
+}
+
+// taskAction runs a task service method taking the task id, returning the task afterwards or true
+func (builder *schemaBuilder) taskAction(
+	params graphql.ResolveParams,
+	action func(ctx context.Context, taskID uint64, userID string) error,
+	commonError *apperror.Error,
+	returnTask bool,
+) (any, error) {
+	id, err := idArg(params.Args, "id", service.ErrTaskInvalidInput)
+	if err != nil {
+		return nil, err
+	}
+	if err := action(params.Context, id, userIDOf(params)); err != nil {
+		return nil, publicError(params.Context, err, commonError)
+	}
+	if !returnTask {
+		return true, nil
+	}
+	task, err := builder.services.Task.GetByID(params.Context, id, userIDOf(params))
+	if err != nil {
+		return nil, publicError(params.Context, err, service.ErrTaskGetFailed)
+	}
+	return task, nil
+}
+
+func inputOf(params graphql.ResolveParams) map[string]any {
+	input, _ := params.Args["input"].(map[string]any)
+	return input
+}
+
+func userIDOf(params graphql.ResolveParams) string {
+	return requestOf(params.Context).userID
+}
+
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := item.(string); ok {
+			list = append(list, text)
+		}
+	}
+	return list
+}
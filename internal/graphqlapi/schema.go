@@ -0,0 +1,380 @@
+package graphqlapi
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/graphql-go/graphql"
+)
+
+// services are the services the resolvers delegate to
+// Services are the services the resolvers call
+type Services struct {
+	User       *service.UserService
+	Project    *service.ProjectService
+	Task       *service.TaskService
+	TimeRecord *service.TimeRecordService
+}
+
+// schemaBuilder builds the schema, the object fields default to the model struct fields of the same name
+type schemaBuilder struct {
+	services *Services
+
+	user       *graphql.Object
+	project    *graphql.Object
+	task       *graphql.Object
+	timeRecord *graphql.Object
+}
+
+func newSchema(services *Services) (graphql.Schema, error) {
+	builder := &schemaBuilder{services: services}
+	builder.buildTypes()
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    builder.query(),
+		Mutation: builder.mutation(),
+	})
+}
+
+var rangeArgs = graphql.FieldConfigArgument{
+	"from": &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Earliest start time"},
+	"to":   &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Start time upper bound, exclusive"},
+	"fromDate": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Earliest start day (YYYY-MM-DD) in the user's time zone, replaces from",
+	},
+	"toDate": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Latest start day (YYYY-MM-DD) in the user's time zone, included, replaces to",
+	},
+}
+
+func (builder *schemaBuilder) buildTypes() {
+	builder.timeRecord = graphql.NewObject(graphql.ObjectConfig{
+		Name: "TimeRecord",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"taskId":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"startTime":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"endTime":     &graphql.Field{Type: graphql.DateTime, Description: "Unset while the record is running"},
+				"isClosed":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"durationSeconds": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "Running records count up to now",
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						return seconds(duration(params.Source.(*model.TimeRecord), requestOf(params.Context).now)), nil
+					},
+				},
+				"task": &graphql.Field{
+					Type: builder.task,
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						load := requestOf(params.Context).loaders.tasks.load(params.Source.(*model.TimeRecord).TaskID)
+						return resolveOne(params.Context, load, service.ErrTaskGetFailed), nil
+					},
+				},
+			}
+		}),
+	})
+
+	builder.task = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"projectId": &graphql.Field{Type: graphql.ID, Resolve: resolveProjectID},
+				"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tags": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						if tags := params.Source.(*model.Task).Tags; tags != nil {
+							return []string(tags), nil
+						}
+						return []string{}, nil
+					},
+				},
+				"status":    &graphql.Field{Type: graphql.NewNonNull(taskStatus)},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"project": &graphql.Field{
+					Type: builder.project,
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						projectID := params.Source.(*model.Task).ProjectID
+						if projectID == 0 {
+							return nil, nil
+						}
+						load := requestOf(params.Context).loaders.projects.load(projectID)
+						return resolveOne(params.Context, load, service.ErrProjectGetFailed), nil
+					},
+				},
+				"timeRecords": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.timeRecord))),
+					Description: "Newest first",
+					Args:        rangeArgs,
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						load := requestOf(params.Context).loaders.
+							timeRecordsByTask(rangeOf(params.Args)).
+							load(params.Source.(*model.Task).ID)
+						return resolveMany(params.Context, load, service.ErrTimeRecordGetFailed), nil
+					},
+				},
+				"totalSeconds": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "Tracked time of the records started within the range",
+					Args:        rangeArgs,
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						load := requestOf(params.Context).loaders.
+							timeRecordsByTask(rangeOf(params.Args)).
+							load(params.Source.(*model.Task).ID)
+						return func() (any, error) {
+							timeRecords, err := load()
+							if err != nil {
+								return nil, publicError(params.Context, err, service.ErrTimeRecordGetFailed)
+							}
+							var total time.Duration
+							now := requestOf(params.Context).now
+							for index := range timeRecords {
+								total += duration(&timeRecords[index], now)
+							}
+							return seconds(total), nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	builder.project = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Project",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"tasks": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.task))),
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						load := requestOf(params.Context).loaders.tasksByProject.load(params.Source.(*model.Project).ID)
+						return resolveMany(params.Context, load, service.ErrTaskGetFailed), nil
+					},
+				},
+				"totalSeconds": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "Tracked time of the task records started within the range",
+					Args:        rangeArgs,
+					Resolve: func(params graphql.ResolveParams) (any, error) {
+						load := requestOf(params.Context).loaders.
+							projectDurations(rangeOf(params.Args)).
+							load(params.Source.(*model.Project).ID)
+						return func() (any, error) {
+							total, err := load()
+							if err != nil {
+								return nil, publicError(params.Context, err, service.ErrTimeRecordGetFailed)
+							}
+							return seconds(total), nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	builder.user = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"email":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"timeZone": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"projects": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.project))),
+				Resolve: builder.resolveProjects,
+			},
+		},
+	})
+}
+
+var taskStatus = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TaskStatus",
+	Values: graphql.EnumValueConfigMap{
+		"OPENED":     &graphql.EnumValueConfig{Value: model.StatusOpened},
+		"WORKING_ON": &graphql.EnumValueConfig{Value: model.StatusWorkingOn},
+		"CLOSED":     &graphql.EnumValueConfig{Value: model.StatusClosed},
+	},
+})
+
+func (builder *schemaBuilder) query() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: graphql.NewNonNull(builder.user),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					user, err := builder.services.User.GetUser(params.Context, requestOf(params.Context).userID)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrGetUserFailed)
+					}
+					return user, nil
+				},
+			},
+			"projects": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.project))),
+				Resolve: builder.resolveProjects,
+			},
+			"project": &graphql.Field{
+				Type: builder.project,
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrProjectInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					return resolveOne(params.Context, requestOf(params.Context).loaders.projects.load(id), service.ErrProjectGetFailed), nil
+				},
+			},
+			"tasks": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.task))),
+				Args: graphql.FieldConfigArgument{
+					"activeOnly": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					list := builder.services.Task.GetAllByUser
+					if params.Args["activeOnly"] == true {
+						list = builder.services.Task.GetAllActiveByUser
+					}
+					tasks, err := list(params.Context, requestOf(params.Context).userID)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTaskGetFailed)
+					}
+					return pointers(tasks), nil
+				},
+			},
+			"task": &graphql.Field{
+				Type: builder.task,
+				Args: idArgs,
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					id, err := idArg(params.Args, "id", service.ErrTaskInvalidInput)
+					if err != nil {
+						return nil, err
+					}
+					return resolveOne(params.Context, requestOf(params.Context).loaders.tasks.load(id), service.ErrTaskGetFailed), nil
+				},
+			},
+			"timeRecords": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(builder.timeRecord))),
+				Description: "Newest first",
+				Args: graphql.FieldConfigArgument{
+					"taskId":   &graphql.ArgumentConfig{Type: graphql.ID},
+					"from":     rangeArgs["from"],
+					"to":       rangeArgs["to"],
+					"fromDate": rangeArgs["fromDate"],
+					"toDate":   rangeArgs["toDate"],
+				},
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					filter := rangeOf(params.Args).filter()
+					if _, ok := params.Args["taskId"]; ok {
+						taskID, err := idArg(params.Args, "taskId", service.ErrTimeRecordInvalidInput)
+						if err != nil {
+							return nil, err
+						}
+						filter.TaskID = &taskID
+					}
+					timeRecords, err := builder.services.TimeRecord.GetAllByUser(
+						params.Context,
+						requestOf(params.Context).userID,
+						filter,
+					)
+					if err != nil {
+						return nil, publicError(params.Context, err, service.ErrTimeRecordGetFailed)
+					}
+					return pointers(*timeRecords), nil
+				},
+			},
+		},
+	})
+}
+
+func (builder *schemaBuilder) resolveProjects(params graphql.ResolveParams) (any, error) {
+	projects, err := builder.services.Project.GetAllByUser(params.Context, requestOf(params.Context).userID)
+	if err != nil {
+		return nil, publicError(params.Context, err, service.ErrProjectGetFailed)
+	}
+	return pointers(projects), nil
+}
+
+func resolveProjectID(params graphql.ResolveParams) (any, error) {
+	if projectID := params.Source.(*model.Task).ProjectID; projectID != 0 {
+		return projectID, nil
+	}
+	return nil, nil
+}
+
+// resolveOne turns a loader result into a thunk, a missing record resolves to null
+func resolveOne[V any](ctx context.Context, load func() (*V, error), commonError *apperror.Error) thunk {
+	return func() (any, error) {
+		value, err := load()
+		if err != nil {
+			return nil, publicError(ctx, err, commonError)
+		}
+		if value == nil {
+			return nil, nil
+		}
+		return value, nil
+	}
+}
+
+// resolveMany turns a loader result into a thunk of a list of pointers
+func resolveMany[V any](ctx context.Context, load func() ([]V, error), commonError *apperror.Error) thunk {
+	return func() (any, error) {
+		values, err := load()
+		if err != nil {
+			return nil, publicError(ctx, err, commonError)
+		}
+		return pointers(values), nil
+	}
+}
+
+// pointers lets every object resolver take a pointer as its source
+func pointers[V any](values []V) []*V {
+	result := make([]*V, 0, len(values))
+	for index := range values {
+		result = append(result, &values[index])
+	}
+	return result
+}
+
+var idArgs = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+}
+
+func idArg(args map[string]any, name string, invalidInput *apperror.Error) (uint64, error) {
+	value, _ := args[name].(string)
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, badUserInput(invalidInput)
+	}
+	return id, nil
+}
+
+func rangeOf(args map[string]any) timeRange {
+	var within timeRange
+	if from, ok := args["from"].(time.Time); ok {
+		within.from = from
+	}
+	if to, ok := args["to"].(time.Time); ok {
+		within.to = to
+	}
+	within.fromDate, _ = args["fromDate"].(string)
+	within.toDate, _ = args["toDate"].(string)
+	return within
+}
+
+func seconds(value time.Duration) int {
+	return int(value / time.Second)
+}
@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const maxRequestIDLength = 128
+
+// publicMethods are callable without credentials
+var publicMethods = map[string]bool{
+	pb.UserService_Signup_FullMethodName: true,
+	pb.UserService_Signin_FullMethodName: true,
+}
+
+type userIDKey struct{}
+
+// userID returns the authenticated user, the interceptors reject calls without one
+func userID(ctx context.Context) string {
+	id, _ := ctx.Value(userIDKey{}).(string)
+	return id
+}
+
+// authenticator mirrors the RequestContext and AuthRequired middlewares: it stores the request
+// metadata for the service layer and accepts a JWT in "authorization: Bearer <token>" or an
+// API key in "x-api-key".
+type authenticator struct {
+	tokens        *auth.JWT
+	apiKeyService *service.APIKeyService
+	logger        logs.Logger
+}
+
+func newAuthenticator(tokens *auth.JWT, apiKeyService *service.APIKeyService, logger logs.Logger) *authenticator {
+	return &authenticator{tokens: tokens, apiKeyService: apiKeyService, logger: logger}
+}
+
+func (authenticator *authenticator) unary(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := authenticator.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+func (authenticator *authenticator) stream(
+	server any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := authenticator.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(server, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+func (authenticator *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	requestMetadata := requestctx.Metadata{
+		RequestID: requestID(incoming),
+		ClientIP:  clientIP(ctx),
+	}
+	ctx = requestctx.WithMetadata(ctx, requestMetadata)
+
+	fields := []interface{}{"request_id", requestMetadata.RequestID, "rpc_method", method}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields = append(fields, "trace_id", spanContext.TraceID().String())
+	}
+	ctx = logs.WithContext(ctx, authenticator.logger.With(fields...))
+	if publicMethods[method] {
+		return ctx, nil
+	}
+
+	var id string
+	if apiKey := first(incoming, strings.ToLower(service.APIKeyHeader)); apiKey != "" {
+		authenticated, err := authenticator.apiKeyService.Authenticate(ctx, apiKey)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, service.ErrAPIKeyInvalid.Error())
+		}
+		id = authenticated
+	} else {
+		authorization := first(incoming, "authorization")
+		if authorization == "" {
+			return nil, status.Error(codes.Unauthenticated, service.ErrUserMissingAuthHeader.Error())
+		}
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, service.ErrUserInvalidAuthHeader.Error())
+		}
+		verified, err := authenticator.tokens.UserID(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, service.ErrUserTokenInvalid.Error())
+		}
+		id = verified
+	}
+
+	ctx = context.WithValue(ctx, userIDKey{}, id)
+	ctx = logs.WithContext(ctx, logs.FromContext(ctx).With("user_id", id))
+	return requestctx.WithActor(ctx, id), nil
+}
+
+func requestID(incoming metadata.MD) string {
+	id := first(incoming, strings.ToLower(requestctx.RequestIDHeader))
+	if id == "" || len(id) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+	return id
+}
+
+func clientIP(ctx context.Context) string {
+	remote, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(remote.Addr.String())
+	if err != nil {
+		return remote.Addr.String()
+	}
+	return host
+}
+
+func first(incoming metadata.MD, key string) string {
+	if values := incoming.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// contextStream replaces the context of a server stream with the authenticated one
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextStream) Context() context.Context {
+	return stream.ctx
+}
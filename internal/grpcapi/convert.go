@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProject(project *model.Project) *pb.Project {
+	return &pb.Project{
+		Id:        project.ID,
+		UserId:    project.UserID.String(),
+		Name:      project.Name,
+		CreatedAt: timestamppb.New(project.CreatedAt),
+		UpdatedAt: timestamppb.New(project.UpdatedAt),
+	}
+}
+
+func toTask(task *model.Task) *pb.Task {
+	return &pb.Task{
+		Id:        task.ID,
+		UserId:    task.UserID.String(),
+		ProjectId: task.ProjectID,
+		Name:      task.Name,
+		Tags:      task.Tags,
+		Status:    string(task.Status),
+		CreatedAt: timestamppb.New(task.CreatedAt),
+		UpdatedAt: timestamppb.New(task.UpdatedAt),
+	}
+}
+
+func toTimeRecord(timeRecord *model.TimeRecord) *pb.TimeRecord {
+	return &pb.TimeRecord{
+		Id:          timeRecord.ID,
+		UserId:      timeRecord.UserID.String(),
+		TaskId:      timeRecord.TaskID,
+		StartTime:   timestamppb.New(timeRecord.StartTime),
+		EndTime:     optionalTimestamp(timeRecord.EndTime),
+		IsClosed:    timeRecord.IsClosed,
+		Description: timeRecord.Description,
+		CreatedAt:   timestamppb.New(timeRecord.CreatedAt),
+		UpdatedAt:   timestamppb.New(timeRecord.UpdatedAt),
+	}
+}
+
+func toTimeRecordVersion(version *model.TimeRecordVersion) *pb.TimeRecordVersion {
+	actorID := ""
+	if version.ActorID != nil {
+		actorID = version.ActorID.String()
+	}
+	return &pb.TimeRecordVersion{
+		Id:           version.ID,
+		TimeRecordId: version.TimeRecordID,
+		Version:      int32(version.Version),
+		ActorId:      actorID,
+		Operation:    string(version.Operation),
+		TaskId:       version.TaskID,
+		StartTime:    timestamppb.New(version.StartTime),
+		EndTime:      optionalTimestamp(version.EndTime),
+		IsClosed:     version.IsClosed,
+		Description:  version.Description,
+		Deleted:      version.Deleted,
+		CreatedAt:    timestamppb.New(version.CreatedAt),
+	}
+}
+
+func optionalTimestamp(value *time.Time) *timestamppb.Timestamp {
+	if value == nil {
+		return nil
+	}
+	return timestamppb.New(*value)
+}
+
+// optionalTime converts an unset timestamp to nil
+func optionalTime(value *timestamppb.Timestamp) *time.Time {
+	if value == nil {
+		return nil
+	}
+	converted := value.AsTime()
+	return &converted
+}
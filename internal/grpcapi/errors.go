@@ -0,0 +1,58 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus logs err and maps it to a gRPC status with the public message the REST API returns,
+// invalid fields are attached as a BadRequest detail
+func toStatus(ctx context.Context, err error, commonError *apperror.Error) error {
+	logs.FromContext(ctx).Error("gRPC call failed", "error", err)
+	return statusOf(apperror.From(err, commonError))
+}
+
+func invalidArgument(commonError *apperror.Error) error {
+	return status.Error(codes.InvalidArgument, commonError.Message)
+}
+
+func statusOf(appErr *apperror.Error) error {
+	converted := status.New(codeOf(appErr.Status), appErr.Message)
+	if len(appErr.Fields) == 0 {
+		return converted.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+	detailed, err := converted.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return converted.Err()
+	}
+	return detailed.Err()
+}
+
+func codeOf(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
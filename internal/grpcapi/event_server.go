@@ -0,0 +1,100 @@
+package grpcapi
+
+import (
+	"encoding/json"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timerEventTypes are the events streamed by WatchTimers
+var timerEventTypes = map[event.Type]bool{
+	event.TaskStarted:       true,
+	event.TaskStopped:       true,
+	event.TaskClosed:        true,
+	event.TimeRecordCreated: true,
+	event.TimeRecordUpdated: true,
+	event.TimeRecordDeleted: true,
+}
+
+type eventServer struct {
+	pb.UnimplementedEventServiceServer
+	bus *event.Bus
+}
+
+func newEventServer(bus *event.Bus) *eventServer {
+	return &eventServer{bus: bus}
+}
+
+// WatchTimers streams the timer events of the user until the client cancels the call
+func (eventServer *eventServer) WatchTimers(
+	request *pb.WatchTimersRequest,
+	stream grpc.ServerStreamingServer[pb.TimerEvent],
+) error {
+	ctx := stream.Context()
+	subscription := eventServer.bus.Subscribe(userID(ctx), event.DefaultBufferSize)
+	defer subscription.Close()
+	// send the headers once subscribed, a client that waited for them misses no event
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+			if !timerEventTypes[message.Type] {
+				continue
+			}
+			timerEvent, taskID, err := toTimerEvent(message)
+			if err != nil {
+				logs.FromContext(ctx).Error("convert timer event failed", "event_type", message.Type, "error", err)
+				continue
+			}
+			if request.GetTaskId() != 0 && request.GetTaskId() != taskID {
+				continue
+			}
+			if err := stream.Send(timerEvent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toTimerEvent decodes the event payload, which is the JSON of the task or time record, and returns its task id
+func toTimerEvent(message event.Event) (*pb.TimerEvent, uint64, error) {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return nil, 0, err
+	}
+	timerEvent := &pb.TimerEvent{
+		Id:         message.ID,
+		Type:       string(message.Type),
+		OccurredAt: timestamppb.New(message.OccurredAt),
+	}
+
+	switch message.Type {
+	case event.TaskStarted, event.TaskStopped, event.TaskClosed:
+		var task model.Task
+		if err := json.Unmarshal(data, &task); err != nil {
+			return nil, 0, err
+		}
+		timerEvent.Subject = &pb.TimerEvent_Task{Task: toTask(&task)}
+		return timerEvent, task.ID, nil
+	default:
+		var timeRecord model.TimeRecord
+		if err := json.Unmarshal(data, &timeRecord); err != nil {
+			return nil, 0, err
+		}
+		timerEvent.Subject = &pb.TimerEvent_TimeRecord{TimeRecord: toTimeRecord(&timeRecord)}
+		return timerEvent, timeRecord.TaskID, nil
+	}
+}
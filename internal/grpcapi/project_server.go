@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type projectServer struct {
+	pb.UnimplementedProjectServiceServer
+	projectService *service.ProjectService
+}
+
+func newProjectServer(projectService *service.ProjectService) *projectServer {
+	return &projectServer{projectService: projectService}
+}
+
+func (projectServer *projectServer) CreateProject(
+	ctx context.Context,
+	request *pb.CreateProjectRequest,
+) (*pb.Project, error) {
+	project, err := projectServer.projectService.Create(ctx, userID(ctx), service.ProjectInput{Name: request.GetName()})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrProjectCreateFailed)
+	}
+	return toProject(project), nil
+}
+
+func (projectServer *projectServer) ListProjects(ctx context.Context, _ *emptypb.Empty) (*pb.ListProjectsResponse, error) {
+	projects, err := projectServer.projectService.GetAllByUser(ctx, userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrProjectGetFailed)
+	}
+	response := &pb.ListProjectsResponse{Projects: make([]*pb.Project, 0, len(projects))}
+	for index := range projects {
+		response.Projects = append(response.Projects, toProject(&projects[index]))
+	}
+	return response, nil
+}
+
+func (projectServer *projectServer) GetProject(ctx context.Context, request *pb.GetProjectRequest) (*pb.Project, error) {
+	project, err := projectServer.projectService.GetByID(ctx, formatID(request.GetId()), userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrProjectGetFailed)
+	}
+	return toProject(project), nil
+}
+
+func (projectServer *projectServer) RenameProject(
+	ctx context.Context,
+	request *pb.RenameProjectRequest,
+) (*emptypb.Empty, error) {
+	err := projectServer.projectService.Rename(
+		ctx,
+		formatID(request.GetId()),
+		userID(ctx),
+		service.ProjectInput{Name: request.GetName()},
+	)
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrProjectUpdateFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (projectServer *projectServer) DeleteProject(
+	ctx context.Context,
+	request *pb.DeleteProjectRequest,
+) (*emptypb.Empty, error) {
+	if err := projectServer.projectService.Delete(ctx, formatID(request.GetId()), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrProjectDeleteFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// formatID converts ids for the project service, which takes them as path strings
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
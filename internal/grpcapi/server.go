@@ -0,0 +1,25 @@
+// Package grpcapi exposes the services over gRPC next to the REST API of the router package.
+package grpcapi
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/grpc"
+)
+
+// NewServer registers every service behind the tracing and authentication interceptors
+func NewServer(container *app.Container) *grpc.Server {
+	services := container.Services
+	authenticator := newAuthenticator(container.Tokens, services.APIKey, container.Logger)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(tracingUnary, authenticator.unary),
+		grpc.ChainStreamInterceptor(tracingStream, authenticator.stream),
+	)
+
+	pb.RegisterUserServiceServer(server, newUserServer(services.User, container.Tokens))
+	pb.RegisterProjectServiceServer(server, newProjectServer(services.Project))
+	pb.RegisterTaskServiceServer(server, newTaskServer(services.Task))
+	pb.RegisterTimeRecordServiceServer(server, newTimeRecordServer(services.TimeRecord, services.Task))
+	pb.RegisterEventServiceServer(server, newEventServer(container.Bus))
+	return server
+}
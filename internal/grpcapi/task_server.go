@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type taskServer struct {
+	pb.UnimplementedTaskServiceServer
+	taskService *service.TaskService
+}
+
+func newTaskServer(taskService *service.TaskService) *taskServer {
+	return &taskServer{taskService: taskService}
+}
+
+func (taskServer *taskServer) CreateTask(ctx context.Context, request *pb.CreateTaskRequest) (*pb.Task, error) {
+	task, err := taskServer.taskService.Create(ctx, userID(ctx), service.CreateTaskInput{
+		Name:      request.GetName(),
+		ProjectID: request.GetProjectId(),
+		Tags:      request.GetTags(),
+		Status:    request.GetStatus(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskCreateFailed)
+	}
+	return toTask(task), nil
+}
+
+func (taskServer *taskServer) ListTasks(ctx context.Context, request *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	list := taskServer.taskService.GetAllByUser
+	if request.GetActiveOnly() {
+		list = taskServer.taskService.GetAllActiveByUser
+	}
+	tasks, err := list(ctx, userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskGetFailed)
+	}
+	response := &pb.ListTasksResponse{Tasks: make([]*pb.Task, 0, len(tasks))}
+	for index := range tasks {
+		response.Tasks = append(response.Tasks, toTask(&tasks[index]))
+	}
+	return response, nil
+}
+
+func (taskServer *taskServer) GetTask(ctx context.Context, request *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := taskServer.taskService.GetByID(ctx, request.GetId(), userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskGetFailed)
+	}
+	return toTask(task), nil
+}
+
+func (taskServer *taskServer) UpdateTask(ctx context.Context, request *pb.UpdateTaskRequest) (*pb.Task, error) {
+	input := service.UpdateTaskInput{
+		Name:      request.Name,
+		ProjectID: request.ProjectId,
+		Status:    request.Status,
+	}
+	if request.Tags != nil {
+		tags := request.Tags.GetValues()
+		if tags == nil {
+			tags = []string{}
+		}
+		input.Tags = &tags
+	}
+
+	task, err := taskServer.taskService.Update(ctx, request.GetId(), userID(ctx), input)
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskUpdateFailed)
+	}
+	return toTask(task), nil
+}
+
+func (taskServer *taskServer) DeleteTask(ctx context.Context, request *pb.TaskIDRequest) (*emptypb.Empty, error) {
+	if err := taskServer.taskService.Delete(ctx, request.GetId(), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskDeleteFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (taskServer *taskServer) StartTask(ctx context.Context, request *pb.TaskIDRequest) (*emptypb.Empty, error) {
+	if err := taskServer.taskService.Start(ctx, request.GetId(), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskStartFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (taskServer *taskServer) StopTask(ctx context.Context, request *pb.TaskIDRequest) (*emptypb.Empty, error) {
+	if err := taskServer.taskService.Stop(ctx, request.GetId(), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskStopFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (taskServer *taskServer) StopAllTasks(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := taskServer.taskService.StopAll(ctx, userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskStopFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (taskServer *taskServer) CloseTask(ctx context.Context, request *pb.TaskIDRequest) (*emptypb.Empty, error) {
+	if err := taskServer.taskService.Close(ctx, request.GetId(), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTaskUpdateFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
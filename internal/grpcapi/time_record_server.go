@@ -0,0 +1,143 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// timeRecordServer edits through the task service like the REST handler, so task statuses follow the records
+type timeRecordServer struct {
+	pb.UnimplementedTimeRecordServiceServer
+	timeRecordService *service.TimeRecordService
+	taskService       *service.TaskService
+}
+
+func newTimeRecordServer(
+	timeRecordService *service.TimeRecordService,
+	taskService *service.TaskService,
+) *timeRecordServer {
+	return &timeRecordServer{
+		timeRecordService: timeRecordService,
+		taskService:       taskService,
+	}
+}
+
+func (timeRecordServer *timeRecordServer) CreateTimeRecord(
+	ctx context.Context,
+	request *pb.CreateTimeRecordRequest,
+) (*pb.TimeRecord, error) {
+	if request.GetTaskId() == 0 || request.StartTime == nil || request.EndTime == nil {
+		return nil, invalidArgument(service.ErrTimeRecordInvalidInput)
+	}
+	timeRecord, err := timeRecordServer.timeRecordService.CreateManual(ctx, userID(ctx), service.CreateTimeRecordInput{
+		TaskID:      request.GetTaskId(),
+		StartTime:   request.StartTime.AsTime(),
+		EndTime:     request.EndTime.AsTime(),
+		Description: request.GetDescription(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordCreateFailed)
+	}
+	return toTimeRecord(timeRecord), nil
+}
+
+func (timeRecordServer *timeRecordServer) ListTimeRecords(
+	ctx context.Context,
+	request *pb.ListTimeRecordsRequest,
+) (*pb.ListTimeRecordsResponse, error) {
+	timeRecords, err := timeRecordServer.timeRecordService.GetAllByUser(ctx, userID(ctx), service.TimeRecordFilter{
+		TaskID:   request.TaskId,
+		From:     optionalTime(request.From),
+		To:       optionalTime(request.To),
+		FromDate: request.GetFromDate(),
+		ToDate:   request.GetToDate(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordGetFailed)
+	}
+	response := &pb.ListTimeRecordsResponse{TimeRecords: make([]*pb.TimeRecord, 0, len(*timeRecords))}
+	for index := range *timeRecords {
+		response.TimeRecords = append(response.TimeRecords, toTimeRecord(&(*timeRecords)[index]))
+	}
+	return response, nil
+}
+
+func (timeRecordServer *timeRecordServer) GetTimeRecord(
+	ctx context.Context,
+	request *pb.TimeRecordIDRequest,
+) (*pb.TimeRecord, error) {
+	timeRecord, err := timeRecordServer.timeRecordService.GetByIDForUser(ctx, request.GetId(), userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordGetFailed)
+	}
+	return toTimeRecord(timeRecord), nil
+}
+
+func (timeRecordServer *timeRecordServer) UpdateTimeRecord(
+	ctx context.Context,
+	request *pb.UpdateTimeRecordRequest,
+) (*pb.TimeRecord, error) {
+	timeRecord, err := timeRecordServer.taskService.EditTimeRecord(ctx, request.GetId(), userID(ctx), service.UpdateTimeRecordInput{
+		TaskID:      request.TaskId,
+		StartTime:   optionalTime(request.StartTime),
+		EndTime:     optionalTime(request.EndTime),
+		IsClosed:    request.IsClosed,
+		Description: request.Description,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordUpdateFailed)
+	}
+	return toTimeRecord(timeRecord), nil
+}
+
+func (timeRecordServer *timeRecordServer) DeleteTimeRecord(
+	ctx context.Context,
+	request *pb.TimeRecordIDRequest,
+) (*emptypb.Empty, error) {
+	if err := timeRecordServer.taskService.DeleteTimeRecord(ctx, request.GetId(), userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordDeleteFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (timeRecordServer *timeRecordServer) GetTimeRecordHistory(
+	ctx context.Context,
+	request *pb.TimeRecordIDRequest,
+) (*pb.TimeRecordHistory, error) {
+	versions, err := timeRecordServer.timeRecordService.History(ctx, request.GetId(), userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordGetFailed)
+	}
+	history := &pb.TimeRecordHistory{Versions: make([]*pb.TimeRecordVersion, 0, len(versions))}
+	for index := range versions {
+		history.Versions = append(history.Versions, toTimeRecordVersion(&versions[index]))
+	}
+	return history, nil
+}
+
+func (timeRecordServer *timeRecordServer) RestoreTimeRecord(
+	ctx context.Context,
+	request *pb.RestoreTimeRecordRequest,
+) (*pb.TimeRecord, error) {
+	timeRecord, err := timeRecordServer.taskService.RestoreTimeRecord(
+		ctx,
+		request.GetId(),
+		userID(ctx),
+		service.RestoreTimeRecordInput{Version: int(request.GetVersion())},
+	)
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordRestoreFailed)
+	}
+	return toTimeRecord(timeRecord), nil
+}
+
+func (timeRecordServer *timeRecordServer) UndoTimeRecord(ctx context.Context, _ *emptypb.Empty) (*pb.TimeRecord, error) {
+	timeRecord, err := timeRecordServer.taskService.UndoTimeRecordAction(ctx, userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrTimeRecordRestoreFailed)
+	}
+	return toTimeRecord(timeRecord), nil
+}
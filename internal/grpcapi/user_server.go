@@ -0,0 +1,85 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	userService *service.UserService
+	tokens      *auth.JWT
+}
+
+func newUserServer(userService *service.UserService, tokens *auth.JWT) *userServer {
+	return &userServer{userService: userService, tokens: tokens}
+}
+
+func (userServer *userServer) Signup(ctx context.Context, request *pb.Credentials) (*pb.Session, error) {
+	user, err := userServer.userService.Signup(ctx, service.UserInput{
+		Email:    request.GetEmail(),
+		Password: request.GetPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrUserSignUpFailed)
+	}
+	return userServer.session(ctx, user, service.ErrUserSignUpFailed)
+}
+
+func (userServer *userServer) Signin(ctx context.Context, request *pb.Credentials) (*pb.Session, error) {
+	user, err := userServer.userService.Signin(ctx, service.UserInput{
+		Email:    request.GetEmail(),
+		Password: request.GetPassword(),
+	})
+	if err != nil {
+		logs.FromContext(ctx).Warn("gRPC signin failed", "error", err)
+		return nil, status.Error(codes.Unauthenticated, service.ErrUserSignInFailed.Error())
+	}
+	return userServer.session(ctx, user, service.ErrUserSignInFailed)
+}
+
+func (userServer *userServer) GetProfile(ctx context.Context, _ *emptypb.Empty) (*pb.Profile, error) {
+	user, err := userServer.userService.GetUser(ctx, userID(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrGetUserFailed)
+	}
+	return &pb.Profile{Id: user.ID.String(), Email: user.Email}, nil
+}
+
+func (userServer *userServer) ChangePassword(
+	ctx context.Context,
+	request *pb.ChangePasswordRequest,
+) (*emptypb.Empty, error) {
+	err := userServer.userService.ChangePassword(ctx, userID(ctx), service.ChangePasswordInput{
+		OldPassword: request.GetOldPassword(),
+		NewPassword: request.GetNewPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err, service.ErrUserChangePasswordFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (userServer *userServer) DeleteCurrentUser(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := userServer.userService.Delete(ctx, userID(ctx)); err != nil {
+		return nil, toStatus(ctx, err, service.ErrUserDeleteFailed)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (userServer *userServer) session(ctx context.Context, user *model.User, commonError *apperror.Error) (*pb.Session, error) {
+	token, err := userServer.tokens.Generate(user.ID.String())
+	if err != nil {
+		return nil, toStatus(ctx, err, commonError)
+	}
+	return &pb.Session{Id: user.ID.String(), Email: user.Email, Token: token}, nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	service *service.APIKeyService
+}
+
+func NewAPIKeyHandler(aPIKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: aPIKeyService,
+	}
+}
+
+func (apiKeyHandler *APIKeyHandler) Create(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var input service.APIKeyInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrAPIKeyInvalidInput)
+		return
+	}
+
+	apiKey, err := apiKeyHandler.service.Create(ctx.Request.Context(), userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrAPIKeyCreateFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiKey)
+}
+
+func (apiKeyHandler *APIKeyHandler) List(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	apiKeys, err := apiKeyHandler.service.GetAllByUser(ctx.Request.Context(), userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrAPIKeyGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiKeys)
+}
+
+func (apiKeyHandler *APIKeyHandler) Delete(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	apiKeyID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrAPIKeyInvalidInput)
+		return
+	}
+
+	if err := apiKeyHandler.service.Delete(ctx.Request.Context(), apiKeyID, userID); err != nil {
+		abortWithError(ctx, err, service.ErrAPIKeyDeleteFailed)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: auditService,
+	}
+}
+
+// List accepts the optional query parameters entity_type, entity_id, from, to (RFC 3339) and limit
+func (auditHandler *AuditHandler) List(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	filter := service.AuditFilter{
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   ctx.Query("entity_id"),
+	}
+	var err error
+	if filter.From, err = parseOptionalTime(ctx.Query("from")); err != nil {
+		auditHandler.badRequest(ctx, err)
+		return
+	}
+	if filter.To, err = parseOptionalTime(ctx.Query("to")); err != nil {
+		auditHandler.badRequest(ctx, err)
+		return
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			auditHandler.badRequest(ctx, err)
+			return
+		}
+	}
+
+	auditLogs, err := auditHandler.service.List(ctx.Request.Context(), userID, filter)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrAuditGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, auditLogs)
+}
+
+func (auditHandler *AuditHandler) badRequest(ctx *gin.Context, err error) {
+	abortWithError(ctx, err, service.ErrAuditInvalidInput)
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
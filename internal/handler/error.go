@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+// abortWithError leaves err to middleware.ErrorHandler, commonError is reported
+// when err carries no public error of its own
+func abortWithError(ctx *gin.Context, err error, commonError *apperror.Error) {
+	_ = ctx.Error(err).SetMeta(commonError)
+	ctx.Abort()
+}
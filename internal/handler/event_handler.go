@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type EventHandler struct {
+	bus      *event.Bus
+	upgrader websocket.Upgrader
+}
+
+const (
+	eventKeepAliveInterval = 30 * time.Second
+	eventWriteTimeout      = 10 * time.Second
+)
+
+// NewEventHandler accepts WebSocket connections from allowedOrigins, "*" allows any origin
+func NewEventHandler(bus *event.Bus, allowedOrigins []string) *EventHandler {
+	return &EventHandler{
+		bus: bus,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: webSocketOriginChecker(allowedOrigins),
+		},
+	}
+}
+
+// Stream pushes the events of the current user as Server-Sent Events
+func (eventHandler *EventHandler) Stream(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	subscription := eventHandler.bus.Subscribe(userID, event.DefaultBufferSize)
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := ctx.Writer.WriteString(": keep-alive\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		case message, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			ctx.Render(-1, sseEvent(message))
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// WebSocket pushes the events of the current user as JSON text messages
+func (eventHandler *EventHandler) WebSocket(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	// subscribe before the upgrade completes so no event is missed once the client is connected
+	subscription := eventHandler.bus.Subscribe(userID, event.DefaultBufferSize)
+	defer subscription.Close()
+
+	connection, err := eventHandler.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// the upgrader has already replied with an HTTP error
+		logs.FromContext(ctx.Request.Context()).Warn("websocket upgrade failed", "error", err)
+		return
+	}
+	defer connection.Close()
+
+	// the client does not send anything, reading is only needed to notice when it goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := connection.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-keepAlive.C:
+			deadline := time.Now().Add(eventWriteTimeout)
+			if err := connection.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		case message, ok := <-subscription.Events():
+			if !ok {
+				// the bus is closed on shutdown, the client may reconnect to another instance
+				goingAway := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+				_ = connection.WriteControl(websocket.CloseMessage, goingAway, time.Now().Add(eventWriteTimeout))
+				return
+			}
+			_ = connection.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+			if err := connection.WriteJSON(message); err != nil {
+				logs.FromContext(ctx.Request.Context()).Warn("websocket write failed", "error", err)
+				return
+			}
+		}
+	}
+}
+
+func sseEvent(message event.Event) sse.Event {
+	return sse.Event{
+		Id:    message.ID,
+		Event: string(message.Type),
+		Data:  message,
+	}
+}
+
+func webSocketOriginChecker(allowedOrigins []string) func(request *http.Request) bool {
+	return func(request *http.Request) bool {
+		origin := request.Header.Get("Origin")
+		if origin == "" {
+			// non-browser clients do not send an origin
+			return true
+		}
+		for _, allowedOrigin := range allowedOrigins {
+			allowedOrigin = strings.TrimSpace(allowedOrigin)
+			if allowedOrigin == "*" || allowedOrigin == origin {
+				return true
+			}
+		}
+		return strings.HasSuffix(origin, "://"+request.Host)
+	}
+}
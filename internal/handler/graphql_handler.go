@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/graphqlapi"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type GraphQLHandler struct {
+	executor        *graphqlapi.Executor
+	maxRequestBytes int64
+}
+
+func NewGraphQLHandler(services *graphqlapi.Services, clock clock.Clock, features config.Features) *GraphQLHandler {
+	executor, err := graphqlapi.NewExecutor(services, clock)
+	if err != nil {
+		// the schema is static, it can only fail on a programming error
+		panic("graphql: build schema: " + err.Error())
+	}
+	if features.GraphQLMaxDepth > 0 {
+		executor.MaxDepth = features.GraphQLMaxDepth
+	}
+	if features.GraphQLMaxComplexity > 0 {
+		executor.MaxComplexity = features.GraphQLMaxComplexity
+	}
+	graphQLHandler := &GraphQLHandler{executor: executor, maxRequestBytes: graphqlapi.DefaultMaxRequestBytes}
+	if features.GraphQLMaxRequestBytes > 0 {
+		graphQLHandler.maxRequestBytes = int64(features.GraphQLMaxRequestBytes)
+	}
+	return graphQLHandler
+}
+
+// Query answers with 413 when the body is too large and with 400 when the request is rejected before
+// execution, errors of single fields are returned with 200 next to the data of the other fields
+func (graphQLHandler *GraphQLHandler) Query(ctx *gin.Context) {
+	var input graphqlapi.Request
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, graphQLHandler.maxRequestBytes)
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, graphqlapi.Response{
+				Errors: gqlerrors.FormatErrors(gqlerrors.NewFormattedError(fmt.Sprintf("request body exceeds the limit of %d bytes", tooLarge.Limit))),
+			})
+			return
+		}
+		logs.FromContext(ctx.Request.Context()).Warn("invalid GraphQL request", "error", err)
+		ctx.JSON(http.StatusBadRequest, graphqlapi.Response{
+			Errors: gqlerrors.FormatErrors(gqlerrors.NewFormattedError("request body must be JSON with a query")),
+		})
+		return
+	}
+
+	response, executed := graphQLHandler.executor.Execute(ctx.Request.Context(), ctx.GetString("user_id"), input)
+	if !executed {
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/buildinfo"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	service *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		service: healthService,
+	}
+}
+
+// Health is the liveness probe, it answers as long as the process serves HTTP
+func (healthHandler *HealthHandler) Health(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready is the readiness probe, it answers 503 until the database is reachable and migrated
+func (healthHandler *HealthHandler) Ready(ctx *gin.Context) {
+	readiness := healthHandler.service.Ready(ctx.Request.Context())
+	if !readiness.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+	ctx.JSON(http.StatusOK, readiness)
+}
+
+func (healthHandler *HealthHandler) Version(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, buildinfo.Get())
+}
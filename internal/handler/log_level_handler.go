@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrLogLevelInvalid = apperror.BadRequest("invalid_log_level", "level must be one of debug, info, warn or error")
+
+// LogLevel is the body of the log level routes
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
+
+type LogLevelHandler struct{}
+
+func NewLogLevelHandler() *LogLevelHandler {
+	return &LogLevelHandler{}
+}
+
+func (logLevelHandler *LogLevelHandler) Get(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, LogLevel{Level: logs.Level()})
+}
+
+// Set changes the level until the next restart or SIGHUP
+func (logLevelHandler *LogLevelHandler) Set(ctx *gin.Context) {
+	var input LogLevel
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, ErrLogLevelInvalid)
+		return
+	}
+
+	previous := logs.Level()
+	if err := logs.SetLevel(input.Level); err != nil {
+		abortWithError(ctx, err, ErrLogLevelInvalid)
+		return
+	}
+	logs.FromContext(ctx.Request.Context()).Info("log level changed", "from", previous, "to", logs.Level())
+
+	ctx.JSON(http.StatusOK, LogLevel{Level: logs.Level()})
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+type OpenAPIHandler struct {
+	document []byte
+}
+
+func NewOpenAPIHandler() *OpenAPIHandler {
+	document, err := json.Marshal(openapi.Get())
+	if err != nil {
+		// the document is built from static types, it can only fail on a programming error
+		panic("openapi: marshal document: " + err.Error())
+	}
+	return &OpenAPIHandler{document: document}
+}
+
+func (openAPIHandler *OpenAPIHandler) Spec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", openAPIHandler.document)
+}
+
+func (openAPIHandler *OpenAPIHandler) UI(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", openapi.UIPage())
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PomodoroHandler struct {
+	service *service.PomodoroService
+}
+
+func NewPomodoroHandler(pomodoroService *service.PomodoroService) *PomodoroHandler {
+	return &PomodoroHandler{
+		service: pomodoroService,
+	}
+}
+
+func (pomodoroHandler *PomodoroHandler) Start(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrPomodoroInvalidInput)
+		return
+	}
+
+	// the body is optional, an empty one starts a session with default lengths
+	var input service.PomodoroInput
+	if err := ctx.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		abortWithError(ctx, err, service.ErrPomodoroInvalidInput)
+		return
+	}
+
+	session, err := pomodoroHandler.service.Start(ctx.Request.Context(), taskID, userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrPomodoroStartFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+func (pomodoroHandler *PomodoroHandler) Stop(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	session, err := pomodoroHandler.service.Stop(ctx.Request.Context(), userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrPomodoroStopFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+func (pomodoroHandler *PomodoroHandler) State(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	state, err := pomodoroHandler.service.GetState(ctx.Request.Context(), userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrPomodoroGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, state)
+}
+
+func (pomodoroHandler *PomodoroHandler) Stats(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	stats, err := pomodoroHandler.service.GetDayStats(ctx.Request.Context(), userID, ctx.Query("date"))
+	if err != nil {
+		abortWithError(ctx, err, service.ErrPomodoroGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
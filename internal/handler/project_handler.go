@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
 	"net/http"
-	"strings"
 
 	"github.com/advanced-coder-com/go-timekeeper/internal/service"
 	"github.com/gin-gonic/gin"
@@ -11,15 +9,11 @@ import (
 
 type ProjectHandler struct {
 	projectService *service.ProjectService
-	logger         logs.Logger
 }
 
-const projectHandlerErrorPrefix = "ProjectHandler"
-
-func NewProjectHandler() *ProjectHandler {
+func NewProjectHandler(projectService *service.ProjectService) *ProjectHandler {
 	return &ProjectHandler{
-		projectService: service.NewProjectService(),
-		logger:         logs.Get(),
+		projectService: projectService,
 	}
 }
 
@@ -27,15 +21,13 @@ func (projectHandler *ProjectHandler) Create(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	var input service.ProjectInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrProjectInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrProjectInvalidInput)
 		return
 	}
 
 	project, err := projectHandler.projectService.Create(ctx.Request.Context(), userID, input)
 	if err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrProjectCreateFailed.Error()})
+		abortWithError(ctx, err, service.ErrProjectCreateFailed)
 		return
 	}
 	ctx.JSON(http.StatusCreated, project)
@@ -47,8 +39,7 @@ func (projectHandler *ProjectHandler) GetByID(ctx *gin.Context) {
 
 	project, err := projectHandler.projectService.GetByID(ctx.Request.Context(), projectID, userID)
 	if err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusNotFound, gin.H{"error": service.ErrProjectGetFailed.Error()})
+		abortWithError(ctx, err, service.ErrProjectGetFailed)
 		return
 	}
 	ctx.JSON(http.StatusOK, project)
@@ -59,8 +50,7 @@ func (projectHandler *ProjectHandler) List(ctx *gin.Context) {
 
 	projects, err := projectHandler.projectService.GetAllByUser(ctx.Request.Context(), userID)
 	if err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrProjectGetFailed.Error()})
+		abortWithError(ctx, err, service.ErrProjectGetFailed)
 		return
 	}
 	ctx.JSON(http.StatusOK, projects)
@@ -70,19 +60,15 @@ func (projectHandler *ProjectHandler) Rename(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	projectID := ctx.Param("id")
 
-	var payload struct {
-		Name string `json:"name"`
-	}
-	if err := ctx.ShouldBindJSON(&payload); err != nil || strings.TrimSpace(payload.Name) == "" {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrProjectInvalidInput.Error()})
+	var input service.ProjectInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrProjectInvalidInput)
 		return
 	}
 
-	err := projectHandler.projectService.Rename(ctx.Request.Context(), projectID, userID, payload.Name)
+	err := projectHandler.projectService.Rename(ctx.Request.Context(), projectID, userID, input)
 	if err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusNotFound, gin.H{"error": service.ErrProjectUpdateFailed.Error()})
+		abortWithError(ctx, err, service.ErrProjectUpdateFailed)
 		return
 	}
 
@@ -90,11 +76,11 @@ func (projectHandler *ProjectHandler) Rename(ctx *gin.Context) {
 }
 
 func (projectHandler *ProjectHandler) Delete(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
 	projectID := ctx.Param("id")
-	err := projectHandler.projectService.Delete(ctx.Request.Context(), projectID)
+	err := projectHandler.projectService.Delete(ctx.Request.Context(), projectID, userID)
 	if err != nil {
-		projectHandler.logger.Error(projectHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrProjectDeleteFailed.Error()})
+		abortWithError(ctx, err, service.ErrProjectDeleteFailed)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "project deleted"})
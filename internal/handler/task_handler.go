@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
-	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -11,15 +9,11 @@ import (
 
 type TaskHandler struct {
 	service *service.TaskService
-	logger  logs.Logger
 }
 
-const taskHandlerErrorPrefix = "TaskHandler"
-
-func NewTaskHandler() *TaskHandler {
+func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
 	return &TaskHandler{
-		service: service.NewTaskService(),
-		logger:  logs.Get(),
+		service: taskService,
 	}
 }
 
@@ -29,21 +23,13 @@ func (taskHandler *TaskHandler) Create(ctx *gin.Context) {
 	var input service.CreateTaskInput
 
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
-		return
-	}
-
-	if input.Status != "" && !model.IsValidTaskStatus(input.Status) {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, service.ErrTaskInvalidInputStatus)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInputStatus.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 
 	task, err := taskHandler.service.Create(ctx.Request.Context(), userID, input)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskCreateFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskCreateFailed)
 		return
 	}
 
@@ -55,8 +41,7 @@ func (taskHandler *TaskHandler) ListAll(ctx *gin.Context) {
 
 	tasks, err := taskHandler.service.GetAllByUser(ctx.Request.Context(), userID)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskGetFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskGetFailed)
 		return
 	}
 
@@ -68,8 +53,7 @@ func (taskHandler *TaskHandler) ListActive(ctx *gin.Context) {
 
 	tasks, err := taskHandler.service.GetAllActiveByUser(ctx.Request.Context(), userID)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskGetFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskGetFailed)
 		return
 	}
 
@@ -80,15 +64,13 @@ func (taskHandler *TaskHandler) GetByID(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 
 	task, err := taskHandler.service.GetByID(ctx.Request.Context(), taskID, userID)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusNotFound, gin.H{"error": service.ErrTaskGetFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskGetFailed)
 		return
 	}
 
@@ -99,23 +81,20 @@ func (taskHandler *TaskHandler) Update(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 
 	var input service.UpdateTaskInput
 
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 
 	task, err := taskHandler.service.Update(ctx.Request.Context(), taskID, userID, input)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskUpdateFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskUpdateFailed)
 		return
 	}
 
@@ -126,14 +105,12 @@ func (taskHandler *TaskHandler) Delete(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 
 	if err := taskHandler.service.Delete(ctx.Request.Context(), taskID, userID); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskDeleteFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskDeleteFailed)
 		return
 	}
 
@@ -144,13 +121,11 @@ func (taskHandler *TaskHandler) Start(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 	if err := taskHandler.service.Start(ctx.Request.Context(), taskID, userID); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskStartFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskStartFailed)
 		return
 	}
 	ctx.Status(http.StatusOK)
@@ -160,13 +135,11 @@ func (taskHandler *TaskHandler) Stop(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 	if err := taskHandler.service.Stop(ctx.Request.Context(), taskID, userID); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskStopFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskStopFailed)
 		return
 	}
 	ctx.Status(http.StatusOK)
@@ -176,8 +149,7 @@ func (taskHandler *TaskHandler) StopAll(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 
 	if err := taskHandler.service.StopAll(ctx.Request.Context(), userID); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskStopFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskStopFailed)
 		return
 	}
 	ctx.Status(http.StatusOK)
@@ -187,13 +159,11 @@ func (taskHandler *TaskHandler) Close(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	taskID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": service.ErrTaskInvalidInput.Error()})
+		abortWithError(ctx, err, service.ErrTaskInvalidInput)
 		return
 	}
 	if err := taskHandler.service.Stop(ctx.Request.Context(), taskID, userID); err != nil {
-		taskHandler.logger.Error(taskHandlerErrorPrefix, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrTaskUpdateFailed.Error()})
+		abortWithError(ctx, err, service.ErrTaskUpdateFailed)
 		return
 	}
 	ctx.Status(http.StatusOK)
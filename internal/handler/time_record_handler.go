@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type TimeRecordHandler struct {
+	taskService       *service.TaskService
+	timeRecordService *service.TimeRecordService
+}
+
+func NewTimeRecordHandler(
+	taskService *service.TaskService,
+	timeRecordService *service.TimeRecordService,
+) *TimeRecordHandler {
+	return &TimeRecordHandler{
+		taskService:       taskService,
+		timeRecordService: timeRecordService,
+	}
+}
+
+func (timeRecordHandler *TimeRecordHandler) Create(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var input service.CreateTimeRecordInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordInvalidInput)
+		return
+	}
+
+	timeRecord, err := timeRecordHandler.timeRecordService.CreateManual(ctx.Request.Context(), userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordCreateFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, timeRecord)
+}
+
+// List accepts the optional query parameters task_id, from and to (RFC 3339),
+// and from_date and to_date (YYYY-MM-DD) which the service resolves in the user's time zone
+func (timeRecordHandler *TimeRecordHandler) List(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var filter service.TimeRecordFilter
+	var err error
+	if value := ctx.Query("task_id"); value != "" {
+		taskID, parseErr := strconv.ParseUint(value, 10, 64)
+		if parseErr != nil {
+			timeRecordHandler.badRequest(ctx, parseErr)
+			return
+		}
+		filter.TaskID = &taskID
+	}
+	if filter.From, err = parseOptionalTime(ctx.Query("from")); err != nil {
+		timeRecordHandler.badRequest(ctx, err)
+		return
+	}
+	if filter.To, err = parseOptionalTime(ctx.Query("to")); err != nil {
+		timeRecordHandler.badRequest(ctx, err)
+		return
+	}
+	filter.FromDate = ctx.Query("from_date")
+	filter.ToDate = ctx.Query("to_date")
+
+	timeRecords, err := timeRecordHandler.timeRecordService.GetAllByUser(ctx.Request.Context(), userID, filter)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeRecords)
+}
+
+func (timeRecordHandler *TimeRecordHandler) GetByID(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	timeRecordID, ok := timeRecordHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	timeRecord, err := timeRecordHandler.timeRecordService.GetByIDForUser(ctx.Request.Context(), timeRecordID, userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeRecord)
+}
+
+func (timeRecordHandler *TimeRecordHandler) Update(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	timeRecordID, ok := timeRecordHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var input service.UpdateTimeRecordInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordInvalidInput)
+		return
+	}
+
+	timeRecord, err := timeRecordHandler.taskService.EditTimeRecord(ctx.Request.Context(), timeRecordID, userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordUpdateFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeRecord)
+}
+
+func (timeRecordHandler *TimeRecordHandler) Delete(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	timeRecordID, ok := timeRecordHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := timeRecordHandler.taskService.DeleteTimeRecord(ctx.Request.Context(), timeRecordID, userID); err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordDeleteFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "time record deleted"})
+}
+
+func (timeRecordHandler *TimeRecordHandler) History(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	timeRecordID, ok := timeRecordHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	versions, err := timeRecordHandler.timeRecordService.History(ctx.Request.Context(), timeRecordID, userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, versions)
+}
+
+func (timeRecordHandler *TimeRecordHandler) Restore(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	timeRecordID, ok := timeRecordHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var input service.RestoreTimeRecordInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordInvalidInput)
+		return
+	}
+
+	timeRecord, err := timeRecordHandler.taskService.RestoreTimeRecord(
+		ctx.Request.Context(),
+		timeRecordID,
+		userID,
+		input,
+	)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordRestoreFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeRecord)
+}
+
+func (timeRecordHandler *TimeRecordHandler) Undo(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	timeRecord, err := timeRecordHandler.taskService.UndoTimeRecordAction(ctx.Request.Context(), userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrTimeRecordRestoreFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeRecord)
+}
+
+func (timeRecordHandler *TimeRecordHandler) parseID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		timeRecordHandler.badRequest(ctx, err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (timeRecordHandler *TimeRecordHandler) badRequest(ctx *gin.Context, err error) {
+	abortWithError(ctx, err, service.ErrTimeRecordInvalidInput)
+}
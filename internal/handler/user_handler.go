@@ -1,48 +1,42 @@
 package handler
 
 import (
-	"context"
-	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
-	"gitlab.com/tozd/go/errors"
 	"net/http"
 
 	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
-const userHandlerErrorPrefix = "UserHandler"
-
 type UserHandler struct {
 	userService *service.UserService
-	logger      logs.Logger
+	tokens      *auth.JWT
 }
 
-func NewUserHandler() *UserHandler {
-	userService := service.NewUserService()
-
+func NewUserHandler(userService *service.UserService, tokens *auth.JWT) *UserHandler {
 	return &UserHandler{
 		userService: userService,
-		logger:      logs.Get(),
+		tokens:      tokens,
 	}
 }
 
 func (handler *UserHandler) Signup(ctx *gin.Context) {
 	var input service.UserInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		handler.processErrorResponse(ctx, http.StatusBadRequest, err, service.ErrUserInvalidInput)
+		abortWithError(ctx, err, service.ErrUserInvalidInput)
 		return
 	}
 
-	user, err := handler.userService.Signup(context.Background(), input)
+	user, err := handler.userService.Signup(ctx.Request.Context(), input)
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusBadRequest, err, service.ErrUserSignUpFailed)
+		abortWithError(ctx, err, service.ErrUserSignUpFailed)
 		return
 	}
 
-	token, err := auth.GenerateJWT(user.ID.String())
+	token, err := handler.tokens.Generate(user.ID.String())
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusInternalServerError, err, service.ErrUserSignUpFailed)
+		abortWithError(ctx, err, service.ErrUserSignUpFailed)
 		return
 	}
 
@@ -56,19 +50,19 @@ func (handler *UserHandler) Signup(ctx *gin.Context) {
 func (handler *UserHandler) Signin(ctx *gin.Context) {
 	var input service.UserInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		handler.processErrorResponse(ctx, http.StatusBadRequest, err, service.ErrUserInvalidInput)
+		abortWithError(ctx, err, service.ErrUserInvalidInput)
 		return
 	}
 
-	user, err := handler.userService.Signin(context.Background(), input)
+	user, err := handler.userService.Signin(ctx.Request.Context(), input)
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusUnauthorized, err, service.ErrUserSignInFailed)
+		abortWithError(ctx, err, service.ErrUserSignInFailed)
 		return
 	}
 
-	token, err := auth.GenerateJWT(user.ID.String())
+	token, err := handler.tokens.Generate(user.ID.String())
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusInternalServerError, err, service.ErrUserSignInFailed)
+		abortWithError(ctx, err, service.ErrUserSignInFailed)
 		return
 	}
 
@@ -82,20 +76,35 @@ func (handler *UserHandler) Signin(ctx *gin.Context) {
 func (handler *UserHandler) Profile(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	if userID == "" {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": service.ErrUserUnauthorized.Error()})
+		abortWithError(ctx, service.ErrUserUnauthorized, nil)
 		return
 	}
 
 	user, err := handler.userService.GetUser(ctx.Request.Context(), userID)
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusNotFound, err, service.ErrGetUserFailed)
+		abortWithError(ctx, err, service.ErrGetUserFailed)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"id":    user.ID,
-		"email": user.Email,
-	})
+	ctx.JSON(http.StatusOK, profile(user))
+}
+
+func (handler *UserHandler) UpdateSettings(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var input service.UserSettingsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrUserInvalidInput)
+		return
+	}
+
+	user, err := handler.userService.UpdateSettings(ctx.Request.Context(), userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrUserUpdateSettingsFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile(user))
 }
 
 func (handler *UserHandler) ChangePassword(ctx *gin.Context) {
@@ -103,12 +112,12 @@ func (handler *UserHandler) ChangePassword(ctx *gin.Context) {
 
 	var input service.ChangePasswordInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		handler.processErrorResponse(ctx, http.StatusBadRequest, err, service.ErrUserInvalidInput)
+		abortWithError(ctx, err, service.ErrUserInvalidInput)
 		return
 	}
 
 	if err := handler.userService.ChangePassword(ctx.Request.Context(), userID, input); err != nil {
-		handler.processErrorResponse(ctx, http.StatusInternalServerError, err, service.ErrUserChangePasswordFailed)
+		abortWithError(ctx, err, service.ErrUserChangePasswordFailed)
 		return
 	}
 
@@ -118,28 +127,23 @@ func (handler *UserHandler) ChangePassword(ctx *gin.Context) {
 func (handler *UserHandler) DeleteCurrentUser(ctx *gin.Context) {
 	userID := ctx.GetString("user_id")
 	if userID == "" {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": service.ErrUserUnauthorized.Error()})
+		abortWithError(ctx, service.ErrUserUnauthorized, nil)
 		return
 	}
 
 	err := handler.userService.Delete(ctx.Request.Context(), userID)
 	if err != nil {
-		handler.processErrorResponse(ctx, http.StatusInternalServerError, err, service.ErrUserDeleteFailed)
+		abortWithError(ctx, err, service.ErrUserDeleteFailed)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "user deleted"})
 }
 
-func (handler *UserHandler) processErrorResponse(ctx *gin.Context, responseCode int, err error, commonError error) {
-	handler.logger.Error(err)
-	var publicErr *service.PublicMessageError
-
-	var message string
-	if errors.As(err, &publicErr) {
-		message = publicErr.Message
-	} else {
-		message = commonError.Error()
+func profile(user *model.User) gin.H {
+	return gin.H{
+		"id":        user.ID,
+		"email":     user.Email,
+		"time_zone": user.TimeZone,
 	}
-	ctx.JSON(responseCode, gin.H{"error": message})
 }
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: webhookService,
+	}
+}
+
+func (webhookHandler *WebhookHandler) Create(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	var input service.WebhookInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrWebhookInvalidInput)
+		return
+	}
+
+	webhook, err := webhookHandler.service.Create(ctx.Request.Context(), userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookCreateFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+func (webhookHandler *WebhookHandler) List(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+
+	webhooks, err := webhookHandler.service.GetAllByUser(ctx.Request.Context(), userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (webhookHandler *WebhookHandler) GetByID(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	webhookID, ok := webhookHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	webhook, err := webhookHandler.service.GetByID(ctx.Request.Context(), webhookID, userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (webhookHandler *WebhookHandler) Update(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	webhookID, ok := webhookHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var input service.UpdateWebhookInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		abortWithError(ctx, err, service.ErrWebhookInvalidInput)
+		return
+	}
+
+	webhook, err := webhookHandler.service.Update(ctx.Request.Context(), webhookID, userID, input)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookUpdateFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (webhookHandler *WebhookHandler) Delete(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	webhookID, ok := webhookHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := webhookHandler.service.Delete(ctx.Request.Context(), webhookID, userID); err != nil {
+		abortWithError(ctx, err, service.ErrWebhookDeleteFailed)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (webhookHandler *WebhookHandler) Deliveries(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	webhookID, ok := webhookHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	deliveries, err := webhookHandler.service.GetDeliveries(ctx.Request.Context(), webhookID, userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookGetFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func (webhookHandler *WebhookHandler) Redeliver(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	deliveryID, ok := webhookHandler.parseID(ctx)
+	if !ok {
+		return
+	}
+
+	delivery, err := webhookHandler.service.Redeliver(ctx.Request.Context(), deliveryID, userID)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookRedeliverFailed)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, delivery)
+}
+
+func (webhookHandler *WebhookHandler) parseID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		abortWithError(ctx, err, service.ErrWebhookInvalidInput)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,19 @@
+package logs
+
+import "context"
+
+type loggerKey struct{}
+
+// WithContext returns a copy of ctx carrying logger, FromContext returns it again
+func WithContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the request ctx belongs to, with its request ID, user and route,
+// or the application logger for background work
+func FromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return logger
+	}
+	return Get()
+}
@@ -1,19 +1,34 @@
 package logs
 
 import (
-	"go.uber.org/zap"
+	"fmt"
 	"os"
 	"sync"
 
-	"github.com/spf13/viper"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Formats of LOG_FORMAT: pretty printed JSON, one JSON object per line or human readable console lines
+const (
+	FormatPretty  = "pretty"
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Logger writes leveled structured logs: a message and alternating keys and values,
+// e.g. logger.Error("dispatch failed", "error", err, "attempts", 3)
 type Logger interface {
-	Info(args ...interface{})
-	Error(args ...interface{})
-	Debug(args ...interface{})
-	Fatal(args ...interface{})
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+	Fatal(msg string, keysAndValues ...interface{})
+	// With returns a logger adding keysAndValues to every entry
+	With(keysAndValues ...interface{}) Logger
+	// Sync flushes buffered entries
+	Sync() error
 }
 
 type ZapLogger struct {
@@ -23,28 +38,29 @@ type ZapLogger struct {
 var (
 	instance *ZapLogger
 	once     sync.Once
+
+	// level is shared by every logger, SetLevel changes it at runtime
+	level = zap.NewAtomicLevel()
 )
 
-func Init() {
+// Init builds the application logger from cfg, main calls it before anything logs.
+// Without it Get builds a logger from the defaults of config.Logging.
+func Init(cfg config.Logging) {
 	once.Do(func() {
-		instance = newZapLogger()
+		instance = newZapLogger(cfg)
 	})
 }
 
 func Get() *ZapLogger {
-	Init()
+	Init(config.Defaults().Logging)
 	return instance
 }
 
-func newZapLogger() *ZapLogger {
-	mode := viper.GetString("MODE")
-	if mode == "" {
-		mode = "development"
-	}
-
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		panic("failed to create log directory: " + err.Error())
-	}
+func newZapLogger(cfg config.Logging) *ZapLogger {
+	// the configuration is validated, the levels are known
+	_ = SetLevel(cfg.Level)
+	var stacktraceLevel zapcore.Level
+	_ = stacktraceLevel.UnmarshalText([]byte(cfg.StacktraceLevel))
 
 	encoderCfg := zapcore.EncoderConfig{
 		TimeKey:    "timestamp",
@@ -59,41 +75,51 @@ func newZapLogger() *ZapLogger {
 		EncodeDuration: zapcore.StringDurationEncoder,
 	}
 
-	level := zap.DebugLevel
-	if mode == "production" {
-		level = zap.InfoLevel
-	}
-
-	stdoutEncoder := WrapEncoderAsPretty(zapcore.NewJSONEncoder(encoderCfg))
-	stdoutCore := zapcore.NewCore(stdoutEncoder, zapcore.AddSync(os.Stdout), level)
+	var core zapcore.Core
+	if cfg.StderrOnly {
+		// containers collect stderr, stack traces go there as there is no file
+		stderrEncoderCfg := encoderCfg
+		stderrEncoderCfg.StacktraceKey = "stacktrace"
+		core = zapcore.NewCore(newEncoder(cfg.Format, stderrEncoderCfg), zapcore.Lock(os.Stderr), level)
+	} else {
+		stdoutCore := zapcore.NewCore(newEncoder(cfg.Format, encoderCfg), zapcore.Lock(os.Stdout), level)
 
-	fileEncoderCfg := encoderCfg
-	fileEncoderCfg.StacktraceKey = "stacktrace"
+		fileEncoderCfg := encoderCfg
+		fileEncoderCfg.StacktraceKey = "stacktrace"
+		fileCore := zapcore.NewCore(newEncoder(cfg.Format, fileEncoderCfg), newFileWriter(cfg), level)
 
-	var fileEncoder zapcore.Encoder
-	var loggingLevel zapcore.Level
-	if mode == "production" {
-		fileEncoder = zapcore.NewJSONEncoder(fileEncoderCfg)
-		loggingLevel = zap.ErrorLevel
-	} else {
-		fileEncoder = WrapEncoderAsPretty(zapcore.NewJSONEncoder(fileEncoderCfg))
-		loggingLevel = zap.DebugLevel
+		core = zapcore.NewTee(stdoutCore, fileCore)
 	}
 
-	fileCore := zapcore.NewCore(fileEncoder, zapcore.AddSync(getLogFileWriter()), level)
+	// the caller is the code calling the ZapLogger methods, not the methods themselves
+	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(stacktraceLevel))
+	return &ZapLogger{sugar: logger.Sugar()}
+}
 
-	core := zapcore.NewTee(stdoutCore, fileCore)
+func newEncoder(format string, encoderCfg zapcore.EncoderConfig) zapcore.Encoder {
+	switch format {
+	case FormatJSON:
+		return zapcore.NewJSONEncoder(encoderCfg)
+	case FormatConsole:
+		return zapcore.NewConsoleEncoder(encoderCfg)
+	default:
+		return WrapEncoderAsPretty(zapcore.NewJSONEncoder(encoderCfg))
+	}
+}
 
-	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(loggingLevel))
-	return &ZapLogger{sugar: logger.Sugar()}
+// Level is the current level of the application logger
+func Level() string {
+	return level.String()
 }
 
-func getLogFileWriter() zapcore.WriteSyncer {
-	file, err := os.OpenFile("logs/app.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		panic("failed to open log file: " + err.Error())
+// SetLevel changes the level of the application logger and every logger derived from it, at runtime
+func SetLevel(text string) error {
+	var parsed zapcore.Level
+	if err := parsed.UnmarshalText([]byte(text)); err != nil || parsed > zapcore.ErrorLevel {
+		return fmt.Errorf("unknown log level %q, use debug, info, warn or error", text)
 	}
-	return zapcore.AddSync(file)
+	level.SetLevel(parsed)
+	return nil
 }
 
 //// Public API
@@ -116,7 +142,28 @@ func getLogFileWriter() zapcore.WriteSyncer {
 //	Get().sugar.Warn(args...)
 //}
 
-func (l *ZapLogger) Info(args ...interface{})  { l.sugar.Info(args...) }
-func (l *ZapLogger) Error(args ...interface{}) { l.sugar.Errorw("", args) }
-func (l *ZapLogger) Debug(args ...interface{}) { l.sugar.Debug(args...) }
-func (l *ZapLogger) Fatal(args ...interface{}) { l.sugar.Fatal(args...) }
+func (l *ZapLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+func (l *ZapLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+func (l *ZapLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+func (l *ZapLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
+
+func (l *ZapLogger) Fatal(msg string, keysAndValues ...interface{}) {
+	l.sugar.Fatalw(msg, keysAndValues...)
+}
+
+func (l *ZapLogger) With(keysAndValues ...interface{}) Logger {
+	return &ZapLogger{sugar: l.sugar.With(keysAndValues...)}
+}
+
+func (l *ZapLogger) Sync() error { return l.sugar.Sync() }
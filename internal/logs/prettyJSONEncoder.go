@@ -41,6 +41,11 @@ func (e *prettyEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field)
 	return final, nil
 }
 
+// Clone keeps loggers with fields (Logger.With) pretty printed
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	return &prettyEncoder{e.Encoder.Clone()}
+}
+
 func WrapEncoderAsPretty(enc zapcore.Encoder) zapcore.Encoder {
 	return &prettyEncoder{enc}
 }
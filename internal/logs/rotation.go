@@ -0,0 +1,39 @@
+package logs
+
+import (
+	"os"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// newFileWriter returns the rotating writer of the log file, the file and its directory are created
+// on the first write and write errors are reported on stderr instead of stopping the application.
+// A file is rotated when it reaches MaxSizeMB or every RotateInterval, rotated files are gzipped
+// and removed after MaxAgeDays or when there are more than MaxBackups.
+func newFileWriter(cfg config.Logging) zapcore.WriteSyncer {
+	file := &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	}
+	if cfg.RotateInterval > 0 {
+		go rotateEvery(file, cfg.RotateInterval)
+	}
+	return zapcore.AddSync(file)
+}
+
+// rotateEvery rotates file at the multiples of interval since the zero time, e.g. at midnight UTC for 24h
+func rotateEvery(file *lumberjack.Logger, interval time.Duration) {
+	for {
+		now := time.Now()
+		time.Sleep(now.Truncate(interval).Add(interval).Sub(now))
+		if err := file.Rotate(); err != nil {
+			_, _ = os.Stderr.WriteString("log file rotation failed: " + err.Error() + "\n")
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package metrics holds the Prometheus metrics of the application and serves them in the text exposition format.
+//
+// Metrics are registered on the registry passed to New together with the Go runtime and process collectors,
+// GET /metrics is mounted on the API engine unless config.Server.MetricsPort moves it to a separate internal listener.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "timekeeper"
+
+	metricsLogPrefix = "Metrics"
+
+	// countTimeout bounds the queries counting running timers on a scrape
+	countTimeout = 5 * time.Second
+)
+
+// Results of authentication attempts and background job runs
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Names of the background jobs
+const (
+	JobPomodoroScheduler = "pomodoro_scheduler"
+	JobOutboxDispatch    = "outbox_dispatch"
+	JobOutboxCleanup     = "outbox_cleanup"
+	JobWebhookDelivery   = "webhook_delivery"
+)
+
+// Metrics records the application metrics on a registry, the container creates one and hands it to
+// the middleware, services and jobs that record
+type Metrics struct {
+	registry *prometheus.Registry
+	logger   logs.Logger
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	authAttempts        *prometheus.CounterVec
+	jobRuns             *prometheus.CounterVec
+	jobDuration         *prometheus.HistogramVec
+	jobLastSuccess      *prometheus.GaugeVec
+	webhookDeliveries   *prometheus.CounterVec
+}
+
+// New registers the metrics together with the Go runtime and process collectors on registry
+func New(registry *prometheus.Registry, logger logs.Logger) *Metrics {
+	metrics := &Metrics{
+		registry: registry,
+		logger:   logger.With("component", metricsLogPrefix),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latencies by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		authAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_attempts_total",
+			Help:      "Signups and signins by result.",
+		}, []string{"operation", "result"}),
+
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "Background job runs by job and result.",
+		}, []string{"job", "result"}),
+
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Background job run durations by job.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"job"}),
+
+		jobLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "job_last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful run by job.",
+		}, []string{"job"}),
+
+		webhookDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_delivery_attempts_total",
+			Help:      "Webhook delivery attempts by result, failure includes attempts that are retried.",
+		}, []string{"result"}),
+	}
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.httpRequests,
+		metrics.httpRequestDuration,
+		metrics.authAttempts,
+		metrics.jobRuns,
+		metrics.jobDuration,
+		metrics.jobLastSuccess,
+		metrics.webhookDeliveries,
+	)
+	return metrics
+}
+
+var runningTimersDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "running_timers"),
+	"Timers running at scrape time by kind.",
+	[]string{"kind"},
+	nil,
+)
+
+// Handler serves the registered metrics
+func (metrics *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{
+		Registry:      metrics.registry,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Register adds collectors to the registry
+func (metrics *Metrics) Register(collector ...prometheus.Collector) {
+	metrics.registry.MustRegister(collector...)
+}
+
+// ObserveHTTPRequest records a served request, route is the route pattern to keep the label set bounded
+func (metrics *Metrics) ObserveHTTPRequest(method string, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	metrics.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	metrics.httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveSignup counts a signup attempt
+func (metrics *Metrics) ObserveSignup(err error) {
+	metrics.authAttempts.WithLabelValues("signup", resultOf(err)).Inc()
+}
+
+// ObserveSignin counts a signin attempt
+func (metrics *Metrics) ObserveSignin(err error) {
+	metrics.authAttempts.WithLabelValues("signin", resultOf(err)).Inc()
+}
+
+// ObserveJob records a background job run that started at started and ended with err
+func (metrics *Metrics) ObserveJob(job string, started time.Time, err error) {
+	result := resultOf(err)
+	metrics.jobRuns.WithLabelValues(job, result).Inc()
+	metrics.jobDuration.WithLabelValues(job).Observe(time.Since(started).Seconds())
+	if result == ResultSuccess {
+		metrics.jobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// ObserveWebhookDelivery counts a webhook delivery attempt
+func (metrics *Metrics) ObserveWebhookDelivery(err error) {
+	metrics.webhookDeliveries.WithLabelValues(resultOf(err)).Inc()
+}
+
+// CountFunc counts the running timers of one kind
+type CountFunc func(ctx context.Context) (int64, error)
+
+// RegisterRunningTimers reports the result of every count as the running_timers gauge of its kind,
+// the counts are queried on every scrape so the gauge is right across restarts and instances
+func (metrics *Metrics) RegisterRunningTimers(counts map[string]CountFunc) {
+	metrics.Register(&runningTimersCollector{counts: counts, logger: metrics.logger})
+}
+
+type runningTimersCollector struct {
+	counts map[string]CountFunc
+	logger logs.Logger
+}
+
+func (collector *runningTimersCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- runningTimersDesc
+}
+
+func (collector *runningTimersCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+
+	for kind, count := range collector.counts {
+		running, err := count(ctx)
+		if err != nil {
+			// the kind is left out of the scrape, an invalid metric would drop the others as well
+			collector.logger.Error("count running timers failed", "kind", kind, "error", err)
+			continue
+		}
+		metrics <- prometheus.MustNewConstMetric(runningTimersDesc, prometheus.GaugeValue, float64(running), kind)
+	}
+}
+
+func resultOf(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errAdminDisabled     = apperror.NotFound("admin_disabled", "admin API is disabled")
+	errAdminTokenInvalid = apperror.Unauthorized("invalid_admin_token", "invalid admin token")
+)
+
+// AdminRequired accepts adminToken as a bearer token, the admin routes answer not found without one configured
+func AdminRequired(adminToken string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if adminToken == "" {
+			abortWithError(context, errAdminDisabled)
+			return
+		}
+
+		token, found := strings.CutPrefix(context.GetHeader("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			abortWithError(context, errAdminTokenInvalid)
+			return
+		}
+		context.Next()
+	}
+}
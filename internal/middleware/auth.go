@@ -1,35 +1,86 @@
 package middleware
 
 import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
 	"github.com/advanced-coder-com/go-timekeeper/internal/service"
-	"net/http"
 	"strings"
 
 	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
 	"github.com/gin-gonic/gin"
 )
 
-func AuthRequired() gin.HandlerFunc {
+// AuthRequired accepts a JWT in the Authorization header or an API key in the X-API-Key header
+func AuthRequired(tokens *auth.JWT, apiKeyService *service.APIKeyService) gin.HandlerFunc {
 	return func(context *gin.Context) {
+		if apiKey := context.GetHeader(service.APIKeyHeader); apiKey != "" {
+			authenticateAPIKey(context, apiKeyService, apiKey)
+			return
+		}
+
 		authHeader := context.GetHeader("Authorization")
 		if authHeader == "" {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": service.ErrUserMissingAuthHeader.Error()})
+			abortWithError(context, service.ErrUserMissingAuthHeader)
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": service.ErrUserInvalidAuthHeader.Error()})
+		authenticateBearer(context, tokens, authHeader)
+	}
+}
+
+// StreamAuthRequired accepts the token from the access_token query parameter as well,
+// browsers cannot set headers on EventSource and WebSocket connections.
+func StreamAuthRequired(tokens *auth.JWT, apiKeyService *service.APIKeyService) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if apiKey := context.GetHeader(service.APIKeyHeader); apiKey != "" {
+			authenticateAPIKey(context, apiKeyService, apiKey)
 			return
 		}
 
-		_, claims, err := auth.VerifyJWT(parts[1])
-		if err != nil {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": service.ErrUserTokenInvalid.Error()})
-			return
+		authHeader := context.GetHeader("Authorization")
+		if authHeader == "" {
+			token := context.Query("access_token")
+			if token == "" {
+				abortWithError(context, service.ErrUserMissingAuthHeader)
+				return
+			}
+			authHeader = "Bearer " + token
 		}
 
-		context.Set("user_id", claims["user_id"])
-		context.Next()
+		authenticateBearer(context, tokens, authHeader)
+	}
+}
+
+func authenticateBearer(context *gin.Context, tokens *auth.JWT, authHeader string) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		abortWithError(context, service.ErrUserInvalidAuthHeader)
+		return
+	}
+
+	actorID, err := tokens.UserID(parts[1])
+	if err != nil {
+		abortWithError(context, service.ErrUserTokenInvalid.Wrap(err))
+		return
 	}
+
+	setUser(context, actorID)
+}
+
+func authenticateAPIKey(context *gin.Context, apiKeyService *service.APIKeyService, apiKey string) {
+	userID, err := apiKeyService.Authenticate(context.Request.Context(), apiKey)
+	if err != nil {
+		abortWithError(context, service.ErrAPIKeyInvalid.Wrap(err))
+		return
+	}
+
+	setUser(context, userID)
+}
+
+func setUser(context *gin.Context, userID string) {
+	context.Set("user_id", userID)
+	ctx := requestctx.WithActor(context.Request.Context(), userID)
+	ctx = logs.WithContext(ctx, logs.FromContext(ctx).With("user_id", userID))
+	context.Request = context.Request.WithContext(ctx)
+	context.Next()
 }
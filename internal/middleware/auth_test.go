@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/auth"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAuthRequiredRejectsTokenWithoutUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	secret := "test-secret"
+	engine := gin.New()
+	engine.Use(middleware.ErrorHandler())
+	reached := false
+	engine.GET("/private", middleware.AuthRequired(auth.NewJWT(config.Auth{JWTSecret: secret}, clock.Real()), nil), func(context *gin.Context) {
+		reached = true
+	})
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "someone"}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token failed: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodGet, "/private", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized || reached {
+		t.Fatalf("token without user_id answered %d, handler reached %t, want 401", recorder.Code, reached)
+	}
+}
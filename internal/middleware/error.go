@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler renders the last error added with context.Error as the JSON error body.
+// The error meta may hold the *apperror.Error to report when the error has no public form,
+// see apperror.From for the mapping. Responses written by the handler itself are left alone.
+// The error is logged with the request by RequestLogger.
+func ErrorHandler() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		context.Next()
+
+		last := context.Errors.Last()
+		if last == nil || context.Writer.Written() {
+			return
+		}
+		fallback, _ := last.Meta.(*apperror.Error)
+		appErr := apperror.From(last.Err, fallback)
+		context.JSON(appErr.Status, appErr.Response())
+	}
+}
+
+// abortWithError stops the chain and leaves err to ErrorHandler
+func abortWithError(context *gin.Context, err error) {
+	_ = context.Error(err)
+	context.Abort()
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// quietRoutes are polled by orchestrators, they are only logged when they fail
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// RequestLogger puts a logger carrying the request ID, route and trace ID into the request context,
+// services and repositories log through it with logs.FromContext. When the request is done it logs
+// the status, latency, user and error, server errors at error level and client errors as warnings.
+func RequestLogger(baseLogger logs.Logger) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		started := time.Now()
+		request := context.Request
+
+		fields := []interface{}{
+			"request_id", context.GetString("request_id"),
+			"method", request.Method,
+			"route", context.FullPath(),
+		}
+		if spanContext := trace.SpanContextFromContext(request.Context()); spanContext.HasTraceID() {
+			fields = append(fields, "trace_id", spanContext.TraceID().String())
+		}
+		logger := baseLogger.With(fields...)
+		context.Request = request.WithContext(logs.WithContext(request.Context(), logger))
+
+		context.Next()
+
+		status := context.Writer.Status()
+		if quietRoutes[context.FullPath()] && status < http.StatusBadRequest {
+			return
+		}
+
+		entry := []interface{}{
+			"path", request.URL.Path,
+			"status", status,
+			"latency", time.Since(started),
+			"client_ip", context.ClientIP(),
+			"bytes", context.Writer.Size(),
+		}
+		if userID := context.GetString("user_id"); userID != "" {
+			entry = append(entry, "user_id", userID)
+		}
+		if last := context.Errors.Last(); last != nil {
+			entry = append(entry, "error", last.Err)
+		}
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("request failed", entry...)
+		case status >= http.StatusBadRequest:
+			logger.Warn("request rejected", entry...)
+		default:
+			logger.Info("request served", entry...)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests no route matched, their paths would make the label set unbounded
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by method, route pattern and status
+func Metrics(metrics *metrics.Metrics) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		started := time.Now()
+		context.Next()
+
+		route := context.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveHTTPRequest(context.Request.Method, route, context.Writer.Status(), time.Since(started))
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic of a handler into an error for ErrorHandler, it runs inside Metrics and
+// RequestLogger so the panic is counted and logged as a 500 of its route. The stack is logged here.
+func Recovery() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// the handler aborted the response on purpose, let net/http close the connection
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+			logs.FromContext(context.Request.Context()).Error("handler panicked", "panic", recovered, "stack", string(debug.Stack()))
+			abortWithError(context, fmt.Errorf("panic: %v", recovered))
+		}()
+		context.Next()
+	}
+}
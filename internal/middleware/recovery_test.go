@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordingLogger keeps the messages and fields of the entries logged at error level
+type recordingLogger struct {
+	mutex  *sync.Mutex
+	errors *[]string
+	fields []interface{}
+}
+
+func newRecordingLogger() recordingLogger {
+	return recordingLogger{mutex: &sync.Mutex{}, errors: &[]string{}}
+}
+
+func (logger recordingLogger) Debug(string, ...interface{}) {}
+func (logger recordingLogger) Info(string, ...interface{})  {}
+func (logger recordingLogger) Warn(string, ...interface{})  {}
+func (logger recordingLogger) Fatal(string, ...interface{}) {}
+func (logger recordingLogger) Sync() error                  { return nil }
+
+func (logger recordingLogger) Error(msg string, keysAndValues ...interface{}) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	*logger.errors = append(*logger.errors, fmt.Sprint(msg, append(logger.fields, keysAndValues...)))
+}
+
+func (logger recordingLogger) With(keysAndValues ...interface{}) logs.Logger {
+	logger.fields = append(append([]interface{}{}, logger.fields...), keysAndValues...)
+	return logger
+}
+
+func TestRecoveryRespondsWithLoggedInternalError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := newRecordingLogger()
+	registry := prometheus.NewRegistry()
+	engine := gin.New()
+	engine.Use(
+		middleware.Metrics(metrics.New(registry, logger)),
+		middleware.RequestContext(),
+		middleware.RequestLogger(logger),
+		middleware.ErrorHandler(),
+		middleware.Recovery(),
+	)
+	engine.GET("/panics", func(context *gin.Context) {
+		panic("handler bug")
+	})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/panics", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("panicking handler answered %d, want 500", recorder.Code)
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body.Code != "internal" {
+		t.Fatalf("panicking handler answered %q, want the internal error body", recorder.Body.String())
+	}
+
+	if counted := countRequests(t, registry, "/panics", "500"); counted != 1 {
+		t.Fatalf("counted %v requests of /panics with status 500, want 1", counted)
+	}
+
+	// one entry with the stack and one for the failed request, both carry the route
+	if len(*logger.errors) != 2 {
+		t.Fatalf("logged %q, want the panic and the failed request", *logger.errors)
+	}
+	for _, entry := range *logger.errors {
+		if !strings.Contains(entry, "/panics") || !strings.Contains(entry, "handler bug") {
+			t.Fatalf("entry %q misses the route or the panic", entry)
+		}
+	}
+}
+
+// countRequests reads the http_requests_total counter of route and status from registry
+func countRequests(t *testing.T, registry *prometheus.Registry, route string, status string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather metrics failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "timekeeper_http_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["route"] == route && labels["status"] == status {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxRequestIDLength = 128
+
+// RequestContext assigns a request ID, reusing the client supplied X-Request-ID,
+// and stores it with the client IP in the request context for the service layer.
+func RequestContext() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		requestID := context.GetHeader(requestctx.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		context.Set("request_id", requestID)
+		context.Header(requestctx.RequestIDHeader, requestID)
+
+		metadata := requestctx.Metadata{
+			RequestID: requestID,
+			ClientIP:  context.ClientIP(),
+		}
+		context.Request = context.Request.WithContext(requestctx.WithMetadata(context.Request.Context(), metadata))
+		context.Next()
+	}
+}
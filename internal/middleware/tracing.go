@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request, continuing the trace of an incoming traceparent header.
+// Services and the database get the span through the request context.
+func Tracing() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		request := context.Request
+		ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+
+		route := context.FullPath()
+		name := request.Method + " " + route
+		if route == "" {
+			name = request.Method
+		}
+		ctx, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(request.URL.Path),
+				semconv.UserAgentOriginal(request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		context.Request = request.WithContext(ctx)
+		context.Next()
+
+		status := context.Writer.Status()
+		span.SetAttributes(
+			semconv.HTTPResponseStatusCode(status),
+			attribute.String("request.id", context.GetString("request_id")),
+		)
+		if last := context.Errors.Last(); last != nil {
+			span.RecordError(last.Err)
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
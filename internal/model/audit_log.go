@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+const (
+	AuditEntityUser       = "user"
+	AuditEntityProject    = "project"
+	AuditEntityTask       = "task"
+	AuditEntityTimeRecord = "time_record"
+)
+
+func IsValidAuditEntityType(entityType string) bool {
+	switch entityType {
+	case AuditEntityUser, AuditEntityProject, AuditEntityTask, AuditEntityTimeRecord:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditLog is an append-only record of one mutation. UserID is the owner of the entity,
+// ActorID is the authenticated user who made the change and is empty for background jobs.
+type AuditLog struct {
+	ID         uint64          `gorm:"primaryKey" json:"id"`
+	UserID     uuid.UUID       `gorm:"type:uuid;not null;index" json:"user_id"`
+	ActorID    *uuid.UUID      `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Action     AuditAction     `gorm:"type:varchar(20);not null" json:"action"`
+	EntityType string          `gorm:"type:varchar(50);not null" json:"entity_type"`
+	EntityID   string          `gorm:"not null" json:"entity_id"`
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before,omitempty"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after,omitempty"`
+	Changes    json.RawMessage `gorm:"type:jsonb" json:"changes,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	CreatedAt  time.Time       `gorm:"not null" json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a domain event stored in the same transaction as the change that produced it.
+// It is pending until DispatchedAt is set, or FailedAt once it ran out of attempts.
+type OutboxMessage struct {
+	ID            uint64          `gorm:"primaryKey" json:"id"`
+	AggregateType string          `gorm:"type:varchar(50);not null" json:"aggregate_type"`
+	AggregateID   string          `gorm:"not null" json:"aggregate_id"`
+	EventID       string          `gorm:"uniqueIndex;not null" json:"event_id"`
+	EventType     string          `gorm:"type:varchar(50);not null" json:"event_type"`
+	UserID        uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	OccurredAt    time.Time       `gorm:"not null" json:"occurred_at"`
+	Attempts      int             `gorm:"not null" json:"attempts"`
+	NextAttemptAt time.Time       `gorm:"not null" json:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty"`
+	DispatchedAt  *time.Time      `json:"dispatched_at,omitempty"`
+	FailedAt      *time.Time      `json:"failed_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PomodoroPhase string
+
+const (
+	PhaseFocus      PomodoroPhase = "Focus"
+	PhaseShortBreak PomodoroPhase = "Short break"
+	PhaseLongBreak  PomodoroPhase = "Long break"
+)
+
+type PomodoroStatus string
+
+const (
+	PomodoroRunning   PomodoroStatus = "Running"
+	PomodoroCompleted PomodoroStatus = "Completed"
+	PomodoroCancelled PomodoroStatus = "Cancelled"
+)
+
+// PomodoroSession is a single focus interval or break of a pomodoro cycle.
+// Focus sessions own the TimeRecord that is tracked while they run.
+type PomodoroSession struct {
+	ID                uint64         `gorm:"primaryKey" json:"id"`
+	UserID            uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	TaskID            uint64         `gorm:"not null;index" json:"task_id"`
+	TimeRecordID      *uint64        `json:"time_record_id,omitempty"`
+	Phase             PomodoroPhase  `gorm:"type:varchar(20);not null" json:"phase"`
+	Status            PomodoroStatus `gorm:"type:varchar(20);not null" json:"status"`
+	PlannedSeconds    int            `gorm:"not null" json:"planned_seconds"`
+	FocusSeconds      int            `gorm:"not null" json:"focus_seconds"`
+	ShortBreakSeconds int            `gorm:"not null" json:"short_break_seconds"`
+	LongBreakSeconds  int            `gorm:"not null" json:"long_break_seconds"`
+	LongBreakEvery    int            `gorm:"not null" json:"long_break_every"`
+	StartTime         time.Time      `gorm:"not null" json:"start_time"`
+	EndTime           *time.Time     `json:"end_time,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// PlannedEnd returns the moment the session is due to finish.
+func (session *PomodoroSession) PlannedEnd() time.Time {
+	return session.StartTime.Add(time.Duration(session.PlannedSeconds) * time.Second)
+}
+
+func (session *PomodoroSession) IsBreak() bool {
+	return session.Phase == PhaseShortBreak || session.Phase == PhaseLongBreak
+}
@@ -7,8 +7,7 @@ import (
 )
 
 type Project struct {
-	// FIXME id to int
-	ID        uint64    `gorm:"type:uuid;primaryKey" json:"id"`
+	ID        uint64    `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"not null" json:"name"`
 	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
 	CreatedAt time.Time `json:"created_at"`
@@ -19,7 +19,7 @@ const (
 type Task struct {
 	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserID    uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
-	ProjectID uint64         `gorm:"type:uuid" json:"project_id,omitempty"`
+	ProjectID uint64         `gorm:"not null" json:"project_id,omitempty"`
 	Name      string         `gorm:"not null" json:"name"`
 	Tags      pq.StringArray `gorm:"type:text[]" json:"tags,omitempty"`
 	Status    TaskStatus     `gorm:"type:varchar(20);not null" json:"status"`
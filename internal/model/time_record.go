@@ -6,12 +6,13 @@ import (
 )
 
 type TimeRecord struct {
-	ID        uint64     `gorm:"primaryKey" json:"id"`
-	UserID    uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
-	TaskID    uint64     `gorm:"not null;index" json:"task_id"`
-	StartTime time.Time  `gorm:"not null" json:"start_time"`
-	EndTime   *time.Time `json:"end_time,omitempty"`
-	IsClosed  bool       `gorm:"default:false" json:"is_closed"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID          uint64     `gorm:"primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	TaskID      uint64     `gorm:"not null;index" json:"task_id"`
+	StartTime   time.Time  `gorm:"not null" json:"start_time"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	IsClosed    bool       `gorm:"default:false" json:"is_closed"`
+	Description string     `gorm:"not null;default:''" json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VersionOperation string
+
+const (
+	VersionCreate  VersionOperation = "create"
+	VersionEdit    VersionOperation = "edit"
+	VersionStop    VersionOperation = "stop"
+	VersionDelete  VersionOperation = "delete"
+	VersionRestore VersionOperation = "restore"
+	VersionUndo    VersionOperation = "undo"
+)
+
+// IsUndoable reports whether the undo last action endpoint may revert the operation
+func (operation VersionOperation) IsUndoable() bool {
+	switch operation {
+	case VersionEdit, VersionStop, VersionDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// TimeRecordVersion is the state of a time record after one change.
+// Versions of a deleted record are kept so it can be restored.
+type TimeRecordVersion struct {
+	ID           uint64           `gorm:"primaryKey" json:"id"`
+	TimeRecordID uint64           `gorm:"not null;uniqueIndex:idx_time_record_versions_record_version" json:"time_record_id"`
+	Version      int              `gorm:"not null;uniqueIndex:idx_time_record_versions_record_version" json:"version"`
+	UserID       uuid.UUID        `gorm:"type:uuid;not null" json:"user_id"`
+	ActorID      *uuid.UUID       `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Operation    VersionOperation `gorm:"type:varchar(20);not null" json:"operation"`
+	TaskID       uint64           `gorm:"not null" json:"task_id"`
+	StartTime    time.Time        `gorm:"not null" json:"start_time"`
+	EndTime      *time.Time       `json:"end_time,omitempty"`
+	IsClosed     bool             `gorm:"not null" json:"is_closed"`
+	Description  string           `gorm:"not null" json:"description"`
+	Deleted      bool             `gorm:"not null" json:"deleted"`
+	CreatedAt    time.Time        `gorm:"not null" json:"created_at"`
+}
+
+// Apply copies the versioned fields onto the time record
+func (version *TimeRecordVersion) Apply(timeRecord *TimeRecord) {
+	timeRecord.ID = version.TimeRecordID
+	timeRecord.UserID = version.UserID
+	timeRecord.TaskID = version.TaskID
+	timeRecord.StartTime = version.StartTime
+	timeRecord.EndTime = version.EndTime
+	timeRecord.IsClosed = version.IsClosed
+	timeRecord.Description = version.Description
+}
@@ -6,10 +6,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultTimeZone is the zone of users who have not chosen one
+const DefaultTimeZone = "UTC"
+
 type User struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	Email     string    `gorm:"uniqueIndex;not null" json:"email"`
 	Password  string    `gorm:"not null" json:"-"`
+	TimeZone  string    `gorm:"not null;default:UTC" json:"time_zone"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// Location is the IANA time zone of the user that days, reports and exports are computed in,
+// UTC when none or an unknown one is stored
+func (user *User) Location() *time.Location {
+	if user.TimeZone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WebhookSubscription struct {
+	ID         uint64         `gorm:"primaryKey" json:"id"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	URL        string         `gorm:"not null" json:"url"`
+	Secret     string         `gorm:"not null" json:"-"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null" json:"event_types"`
+	IsActive   bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// Accepts reports whether the subscription wants events of the given type
+func (subscription *WebhookSubscription) Accepts(eventType string) bool {
+	if !subscription.IsActive {
+		return false
+	}
+	for _, subscribed := range subscription.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "Pending"
+	DeliverySucceeded WebhookDeliveryStatus = "Succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "Failed"
+)
+
+type WebhookDelivery struct {
+	ID               uint64                `gorm:"primaryKey" json:"id"`
+	SubscriptionID   uint64                `gorm:"not null;index" json:"subscription_id"`
+	UserID           uuid.UUID             `gorm:"type:uuid;not null" json:"user_id"`
+	EventID          string                `gorm:"not null" json:"event_id"`
+	EventType        string                `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload          json.RawMessage       `gorm:"type:jsonb;not null" json:"payload"`
+	Status           WebhookDeliveryStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Attempts         int                   `gorm:"not null" json:"attempts"`
+	NextAttemptAt    time.Time             `gorm:"not null" json:"next_attempt_at"`
+	LastResponseCode *int                  `json:"last_response_code,omitempty"`
+	LastError        *string               `json:"last_error,omitempty"`
+	DeliveredAt      *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
@@ -0,0 +1,134 @@
+// Package openapi builds the OpenAPI 3 description of the REST API from the
+// request and response types the handlers bind and render.
+package openapi
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	Version    = "3.0.3"
+	APIVersion = "1.0.0"
+
+	bearerAuth = "bearerAuth"
+	apiKeyAuth = "apiKeyAuth"
+	adminAuth  = "adminAuth"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem maps lower case HTTP methods to operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+var (
+	document     *Document
+	documentOnce sync.Once
+	pathParam    = regexp.MustCompile(`:([A-Za-z_]+)`)
+	pathTemplate = regexp.MustCompile(`\{([A-Za-z_]+)\}`)
+)
+
+// Get returns the document, it is built once from the operation table
+func Get() *Document {
+	documentOnce.Do(func() {
+		document = build(operations)
+	})
+	return document
+}
+
+// PathFromGin converts a gin route path such as /api/tasks/detail/:id to /api/tasks/detail/{id}
+func PathFromGin(path string) string {
+	return pathParam.ReplaceAllString(path, "{$1}")
+}
+
+func build(operations []operation) *Document {
+	schemas := newSchemaRegistry()
+	errorSchema := schemas.schemaFor(typeOf(Error{}), false)
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "GoTimekeeper API",
+			Description: "Time tracking of projects and tasks. Errors are returned as {\"error\": \"message\", \"code\": \"machine_readable_code\"} with the invalid \"fields\" of a 422.",
+			Version:     APIVersion,
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Token returned by signup and signin"},
+				apiKeyAuth: {Type: "apiKey", In: "header", Name: apiKeyHeader, Description: "Key created with /api/api-keys/create"},
+				adminAuth:  {Type: "http", Scheme: "bearer", Description: "ADMIN_TOKEN of the server configuration"},
+			},
+		},
+	}
+
+	for _, definition := range operations {
+		item, ok := doc.Paths[definition.path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[definition.path] = item
+		}
+		item[strings.ToLower(definition.method)] = definition.build(schemas, errorSchema)
+	}
+	return doc
+}
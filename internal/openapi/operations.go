@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/buildinfo"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/graphqlapi"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/google/uuid"
+)
+
+const apiKeyHeader = service.APIKeyHeader
+
+// Error is the body of every 4xx and 5xx response, fields lists the invalid request fields of a 422
+type Error struct {
+	Error  string                `json:"error"`
+	Code   apperror.Code         `json:"code"`
+	Fields []apperror.FieldError `json:"fields,omitempty"`
+}
+
+// Message is the body of responses confirming an action
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Session is rendered by signup and signin
+type Session struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+	Token string    `json:"token"`
+}
+
+// Health is rendered by the liveness probe
+type Health struct {
+	Status string `json:"status"`
+}
+
+// LogLevel is bound and rendered by the log level routes
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// Profile is rendered by the profile and settings routes
+type Profile struct {
+	ID       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	TimeZone string    `json:"time_zone"`
+}
+
+// operation documents one route, request and response are zero values of the bound and rendered types
+type operation struct {
+	method      string
+	path        string
+	id          string
+	tag         string
+	summary     string
+	description string
+	public      bool
+	// admin marks the routes authenticated with the ADMIN_TOKEN instead of a user's credentials
+	admin   bool
+	query   []Parameter
+	request any
+	// optionalBody marks request bodies the handler accepts empty
+	optionalBody bool
+	status       int
+	response     any
+	contentType  string
+}
+
+func idQuery(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer", Format: "int64"}}
+}
+
+func timeQuery(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Format: "date-time"}}
+}
+
+func dateQuery(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Format: "date"}}
+}
+
+func stringQuery(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+// operations must list every route registered in the router package, the integration test fails otherwise
+var operations = []operation{
+	// User
+	{method: http.MethodPost, path: "/api/user/signup", id: "signup", tag: "User", summary: "Create an account", public: true,
+		request: service.UserInput{}, status: http.StatusCreated, response: Session{}},
+	{method: http.MethodPost, path: "/api/user/signin", id: "signin", tag: "User", summary: "Get a token", public: true,
+		request: service.UserInput{}, status: http.StatusOK, response: Session{}},
+	{method: http.MethodGet, path: "/api/user/profile", id: "getProfile", tag: "User", summary: "Current user",
+		status: http.StatusOK, response: Profile{}},
+	{method: http.MethodDelete, path: "/api/user/delete", id: "deleteCurrentUser", tag: "User", summary: "Delete the account with all its data",
+		status: http.StatusOK, response: Message{}},
+	{method: http.MethodPatch, path: "/api/user/change-password", id: "changePassword", tag: "User", summary: "Change the password",
+		request: service.ChangePasswordInput{}, status: http.StatusOK},
+	{method: http.MethodPatch, path: "/api/user/settings", id: "updateSettings", tag: "User", summary: "Change the preferences",
+		description: "The IANA time zone decides where the days of the pomodoro statistics start.",
+		request:     service.UserSettingsInput{}, status: http.StatusOK, response: Profile{}},
+
+	// Projects
+	{method: http.MethodPost, path: "/api/projects/create", id: "createProject", tag: "Projects", summary: "Create a project",
+		request: service.ProjectInput{}, status: http.StatusCreated, response: model.Project{}},
+	{method: http.MethodGet, path: "/api/projects/list", id: "listProjects", tag: "Projects", summary: "List projects",
+		status: http.StatusOK, response: []model.Project{}},
+	{method: http.MethodGet, path: "/api/projects/detail/{id}", id: "getProject", tag: "Projects", summary: "Get a project",
+		status: http.StatusOK, response: model.Project{}},
+	{method: http.MethodPatch, path: "/api/projects/update/{id}", id: "renameProject", tag: "Projects", summary: "Rename a project",
+		request: service.ProjectInput{}, status: http.StatusOK, response: Message{}},
+	{method: http.MethodDelete, path: "/api/projects/delete/{id}", id: "deleteProject", tag: "Projects", summary: "Delete a project",
+		status: http.StatusOK, response: Message{}},
+
+	// Tasks
+	{method: http.MethodPost, path: "/api/tasks/create", id: "createTask", tag: "Tasks", summary: "Create a task",
+		request: service.CreateTaskInput{}, status: http.StatusCreated, response: model.Task{}},
+	{method: http.MethodGet, path: "/api/tasks/list-all", id: "listTasks", tag: "Tasks", summary: "List all tasks",
+		status: http.StatusOK, response: []model.Task{}},
+	{method: http.MethodGet, path: "/api/tasks/list-active", id: "listActiveTasks", tag: "Tasks", summary: "List tasks that are not closed",
+		status: http.StatusOK, response: []model.Task{}},
+	{method: http.MethodGet, path: "/api/tasks/detail/{id}", id: "getTask", tag: "Tasks", summary: "Get a task",
+		status: http.StatusOK, response: model.Task{}},
+	{method: http.MethodPatch, path: "/api/tasks/update/{id}", id: "updateTask", tag: "Tasks", summary: "Update a task, omitted fields are kept",
+		request: service.UpdateTaskInput{}, status: http.StatusOK, response: model.Task{}},
+	{method: http.MethodDelete, path: "/api/tasks/delete/{id}", id: "deleteTask", tag: "Tasks", summary: "Delete a task",
+		status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/api/tasks/start/{id}", id: "startTask", tag: "Tasks", summary: "Start tracking time on a task",
+		status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/tasks/stop/{id}", id: "stopTask", tag: "Tasks", summary: "Stop tracking time on a task",
+		status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/tasks/stop-all", id: "stopAllTasks", tag: "Tasks", summary: "Stop every running task",
+		status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/tasks/close/{id}", id: "closeTask", tag: "Tasks", summary: "Stop and close a task",
+		status: http.StatusOK},
+
+	// Time records
+	{method: http.MethodPost, path: "/api/time-records/create", id: "createTimeRecord", tag: "Time records", summary: "Add a manual entry",
+		request: service.CreateTimeRecordInput{}, status: http.StatusCreated, response: model.TimeRecord{}},
+	{method: http.MethodGet, path: "/api/time-records/list", id: "listTimeRecords", tag: "Time records", summary: "List time records",
+		query: []Parameter{
+			idQuery("task_id", "records of one task"),
+			timeQuery("from", "records started at or after, RFC 3339"),
+			timeQuery("to", "records started before, RFC 3339"),
+			dateQuery("from_date", "records started on or after this day in the user's time zone"),
+			dateQuery("to_date", "records started on or before this day in the user's time zone"),
+		},
+		status: http.StatusOK, response: []model.TimeRecord{}},
+	{method: http.MethodGet, path: "/api/time-records/detail/{id}", id: "getTimeRecord", tag: "Time records", summary: "Get a time record",
+		status: http.StatusOK, response: model.TimeRecord{}},
+	{method: http.MethodPatch, path: "/api/time-records/update/{id}", id: "updateTimeRecord", tag: "Time records", summary: "Edit a time record, omitted fields are kept",
+		request: service.UpdateTimeRecordInput{}, status: http.StatusOK, response: model.TimeRecord{}},
+	{method: http.MethodDelete, path: "/api/time-records/delete/{id}", id: "deleteTimeRecord", tag: "Time records", summary: "Delete a time record",
+		status: http.StatusOK, response: Message{}},
+	{method: http.MethodGet, path: "/api/time-records/history/{id}", id: "getTimeRecordHistory", tag: "Time records", summary: "Versions of a time record, oldest first",
+		status: http.StatusOK, response: []model.TimeRecordVersion{}},
+	{method: http.MethodPost, path: "/api/time-records/restore/{id}", id: "restoreTimeRecord", tag: "Time records", summary: "Restore a version, deleted records included",
+		request: service.RestoreTimeRecordInput{}, status: http.StatusOK, response: model.TimeRecord{}},
+	{method: http.MethodPost, path: "/api/time-records/undo", id: "undoTimeRecord", tag: "Time records", summary: "Revert the latest edit, stop or delete of the last minutes",
+		status: http.StatusOK, response: model.TimeRecord{}},
+
+	// Pomodoro
+	{method: http.MethodPost, path: "/api/pomodoro/start/{id}", id: "startPomodoro", tag: "Pomodoro", summary: "Start a focus session on a task",
+		description: "The body is optional, zero fields fall back to the defaults.",
+		request:     service.PomodoroInput{}, optionalBody: true, status: http.StatusCreated, response: model.PomodoroSession{}},
+	{method: http.MethodPost, path: "/api/pomodoro/stop", id: "stopPomodoro", tag: "Pomodoro", summary: "Cancel the running session",
+		status: http.StatusOK, response: model.PomodoroSession{}},
+	{method: http.MethodGet, path: "/api/pomodoro/state", id: "getPomodoroState", tag: "Pomodoro", summary: "Countdown of the running session",
+		status: http.StatusOK, response: service.PomodoroState{}},
+	{method: http.MethodGet, path: "/api/pomodoro/stats", id: "getPomodoroStats", tag: "Pomodoro", summary: "Completed focus sessions of a day",
+		query:  []Parameter{dateQuery("date", "YYYY-MM-DD, today by default")},
+		status: http.StatusOK, response: service.PomodoroDayStats{}},
+
+	// Events
+	{method: http.MethodGet, path: "/api/events/stream", id: "streamEvents", tag: "Events", summary: "Domain events as Server-Sent Events",
+		description: "The token may be passed in the access_token query parameter.",
+		query:       []Parameter{stringQuery("access_token", "JWT for clients that cannot set headers")},
+		status:      http.StatusOK, response: event.Event{}, contentType: "text/event-stream"},
+	{method: http.MethodGet, path: "/api/events/ws", id: "websocketEvents", tag: "Events", summary: "Domain events over a WebSocket",
+		description: "Every text message is one event as JSON. The token may be passed in the access_token query parameter.",
+		query:       []Parameter{stringQuery("access_token", "JWT for clients that cannot set headers")},
+		status:      http.StatusSwitchingProtocols},
+
+	// Webhooks
+	{method: http.MethodPost, path: "/api/webhooks/create", id: "createWebhook", tag: "Webhooks", summary: "Subscribe a URL to events",
+		description: "The response is the only one holding the signing secret.",
+		request:     service.WebhookInput{}, status: http.StatusCreated, response: service.CreatedWebhook{}},
+	{method: http.MethodGet, path: "/api/webhooks/list", id: "listWebhooks", tag: "Webhooks", summary: "List webhooks",
+		status: http.StatusOK, response: []model.WebhookSubscription{}},
+	{method: http.MethodGet, path: "/api/webhooks/detail/{id}", id: "getWebhook", tag: "Webhooks", summary: "Get a webhook",
+		status: http.StatusOK, response: model.WebhookSubscription{}},
+	{method: http.MethodPatch, path: "/api/webhooks/update/{id}", id: "updateWebhook", tag: "Webhooks", summary: "Update a webhook, omitted fields are kept",
+		request: service.UpdateWebhookInput{}, status: http.StatusOK, response: model.WebhookSubscription{}},
+	{method: http.MethodDelete, path: "/api/webhooks/delete/{id}", id: "deleteWebhook", tag: "Webhooks", summary: "Delete a webhook",
+		status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/api/webhooks/deliveries/{id}", id: "listWebhookDeliveries", tag: "Webhooks", summary: "Delivery log of a webhook, newest first",
+		status: http.StatusOK, response: []model.WebhookDelivery{}},
+	{method: http.MethodPost, path: "/api/webhooks/redeliver/{id}", id: "redeliverWebhook", tag: "Webhooks", summary: "Queue the payload of a delivery again",
+		status: http.StatusAccepted, response: model.WebhookDelivery{}},
+
+	// Audit log
+	{method: http.MethodGet, path: "/api/audit/list", id: "listAuditLogs", tag: "Audit log", summary: "Audit log of the current user, newest first",
+		query: []Parameter{
+			{Name: "entity_type", In: "query", Schema: &Schema{Type: "string", Enum: []any{
+				model.AuditEntityUser, model.AuditEntityProject, model.AuditEntityTask, model.AuditEntityTimeRecord,
+			}}},
+			stringQuery("entity_id", "id of the entity, requires entity_type"),
+			timeQuery("from", "entries created at or after, RFC 3339"),
+			timeQuery("to", "entries created before, RFC 3339"),
+			{Name: "limit", In: "query", Description: "100 by default, at most 1000", Schema: &Schema{Type: "integer", Format: "int32"}},
+		},
+		status: http.StatusOK, response: []model.AuditLog{}},
+
+	// API keys
+	{method: http.MethodPost, path: "/api/api-keys/create", id: "createAPIKey", tag: "API keys", summary: "Create an API key",
+		description: "The response is the only one holding the key.",
+		request:     service.APIKeyInput{}, status: http.StatusCreated, response: service.CreatedAPIKey{}},
+	{method: http.MethodGet, path: "/api/api-keys/list", id: "listAPIKeys", tag: "API keys", summary: "List API keys",
+		status: http.StatusOK, response: []model.APIKey{}},
+	{method: http.MethodDelete, path: "/api/api-keys/delete/{id}", id: "deleteAPIKey", tag: "API keys", summary: "Revoke an API key",
+		status: http.StatusNoContent},
+
+	// GraphQL
+	{method: http.MethodPost, path: "/api/graphql", id: "graphql", tag: "GraphQL", summary: "Run a GraphQL query or mutation",
+		description: "Requests that do not parse, validate or stay within the depth and complexity limits get 400. " +
+			"Field errors are returned with 200 next to the data, with a code in their extensions.",
+		request: graphqlapi.Request{}, status: http.StatusOK, response: graphqlapi.Response{}},
+
+	// Documentation
+	{method: http.MethodGet, path: "/api/openapi.json", id: "getOpenAPI", tag: "Documentation", summary: "This document", public: true,
+		status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/docs", id: "getDocs", tag: "Documentation", summary: "Interactive documentation", public: true,
+		status: http.StatusOK, contentType: "text/html"},
+
+	// Operations
+	{method: http.MethodGet, path: "/metrics", id: "getMetrics", tag: "Operations", summary: "Prometheus metrics",
+		description: "Served on METRICS_PORT instead when it is set.", public: true,
+		status: http.StatusOK, contentType: "text/plain"},
+	{method: http.MethodGet, path: "/healthz", id: "getHealth", tag: "Operations", summary: "Liveness probe",
+		description: "Answers as long as the process serves HTTP.", public: true,
+		status: http.StatusOK, response: Health{}},
+	{method: http.MethodGet, path: "/readyz", id: "getReadiness", tag: "Operations", summary: "Readiness probe",
+		description: "Pings the database and checks it is migrated to the version of the build, answers 503 with the failed checks otherwise.", public: true,
+		status: http.StatusOK, response: service.Readiness{}},
+	{method: http.MethodGet, path: "/version", id: "getVersion", tag: "Operations", summary: "Build information",
+		public: true, status: http.StatusOK, response: buildinfo.Info{}},
+	{method: http.MethodGet, path: "/admin/log-level", id: "getLogLevel", tag: "Operations", summary: "Current log level",
+		description: "Answers 404 unless ADMIN_TOKEN is set.", admin: true,
+		status: http.StatusOK, response: LogLevel{}},
+	{method: http.MethodPut, path: "/admin/log-level", id: "setLogLevel", tag: "Operations", summary: "Change the log level",
+		description: "One of debug, info, warn or error, kept until the next restart or SIGHUP. Answers 404 unless ADMIN_TOKEN is set.", admin: true,
+		request: LogLevel{}, status: http.StatusOK, response: LogLevel{}},
+}
+
+func (definition operation) build(schemas *schemaRegistry, errorSchema *Schema) *Operation {
+	built := &Operation{
+		Tags:        []string{definition.tag},
+		Summary:     definition.summary,
+		Description: definition.description,
+		OperationID: definition.id,
+		Responses:   map[string]Response{},
+	}
+
+	for _, match := range pathTemplate.FindAllStringSubmatch(definition.path, -1) {
+		built.Parameters = append(built.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int64"},
+		})
+	}
+	built.Parameters = append(built.Parameters, definition.query...)
+
+	if definition.request != nil {
+		built.RequestBody = &RequestBody{
+			Required: !definition.optionalBody,
+			Content: map[string]MediaType{
+				"application/json": {Schema: schemas.schemaFor(reflect.TypeOf(definition.request), true)},
+			},
+		}
+	}
+
+	success := Response{Description: http.StatusText(definition.status)}
+	if definition.response != nil {
+		contentType := definition.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		success.Content = map[string]MediaType{
+			contentType: {Schema: schemas.schemaFor(reflect.TypeOf(definition.response), false)},
+		}
+	} else if definition.contentType != "" {
+		success.Content = map[string]MediaType{definition.contentType: {Schema: &Schema{Type: "string"}}}
+	}
+	built.Responses[strconv.Itoa(definition.status)] = success
+
+	errorContent := map[string]MediaType{"application/json": {Schema: errorSchema}}
+	switch {
+	case definition.admin:
+		built.Security = []map[string][]string{{adminAuth: {}}}
+		built.Responses[strconv.Itoa(http.StatusUnauthorized)] = Response{Description: "Missing or invalid admin token", Content: errorContent}
+	case !definition.public:
+		built.Security = []map[string][]string{{bearerAuth: {}}, {apiKeyAuth: {}}}
+		built.Responses[strconv.Itoa(http.StatusUnauthorized)] = Response{Description: "Missing or invalid credentials", Content: errorContent}
+	}
+	built.Responses["default"] = Response{Description: "Error", Content: errorContent}
+	return built
+}
@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/google/uuid"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// enums lists the values of the string types the API accepts or returns
+var enums = map[reflect.Type][]any{
+	reflect.TypeOf(model.StatusOpened):    {model.StatusOpened, model.StatusWorkingOn, model.StatusClosed},
+	reflect.TypeOf(model.PhaseFocus):      {model.PhaseFocus, model.PhaseShortBreak, model.PhaseLongBreak},
+	reflect.TypeOf(model.PomodoroRunning): {model.PomodoroRunning, model.PomodoroCompleted, model.PomodoroCancelled},
+	reflect.TypeOf(model.DeliveryPending): {model.DeliveryPending, model.DeliverySucceeded, model.DeliveryFailed},
+	reflect.TypeOf(model.AuditCreate):     {model.AuditCreate, model.AuditUpdate, model.AuditDelete},
+	reflect.TypeOf(model.VersionCreate):   {model.VersionCreate, model.VersionEdit, model.VersionStop, model.VersionDelete, model.VersionRestore, model.VersionUndo},
+	reflect.TypeOf(event.ProjectCreated):  eventTypes(),
+}
+
+func eventTypes() []any {
+	values := make([]any, 0, len(event.Types))
+	for _, eventType := range event.Types {
+		values = append(values, eventType)
+	}
+	return values
+}
+
+// schemaRegistry turns Go types into schemas, structs become components referenced by name.
+// Fields tagged binding:"required" or validate:"required" are required in request bodies, fields without omitempty
+// are required in responses as they are always rendered.
+type schemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+func typeOf(value any) reflect.Type {
+	return reflect.TypeOf(value)
+}
+
+func (registry *schemaRegistry) schemaFor(goType reflect.Type, request bool) *Schema {
+	if values, ok := enums[goType]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch goType {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{Description: "any JSON value"}
+	}
+
+	switch goType.Kind() {
+	case reflect.Pointer:
+		schema := registry.schemaFor(goType.Elem(), request)
+		if schema.Ref != "" {
+			// siblings of $ref are ignored in OpenAPI 3.0, the reference is kept as is
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: registry.schemaFor(goType.Elem(), request)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: registry.schemaFor(goType.Elem(), request)}
+	case reflect.Struct:
+		return &Schema{Ref: "#/components/schemas/" + registry.component(goType, request)}
+	default:
+		// interfaces such as the data of an event
+		return &Schema{Description: "any JSON value"}
+	}
+}
+
+// component registers a struct once and returns its name, the package is prepended on a name clash
+func (registry *schemaRegistry) component(goType reflect.Type, request bool) string {
+	if name, ok := registry.names[goType]; ok {
+		return name
+	}
+	name := goType.Name()
+	if _, taken := registry.components[name]; taken {
+		packagePath := strings.Split(goType.PkgPath(), "/")
+		name = capitalize(packagePath[len(packagePath)-1]) + name
+	}
+	registry.names[goType] = name
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	registry.components[name] = schema
+	registry.addFields(schema, goType, request)
+	return name
+}
+
+// addFields follows encoding/json: embedded structs are flattened and "-" is skipped
+func (registry *schemaRegistry) addFields(schema *Schema, goType reflect.Type, request bool) {
+	for i := 0; i < goType.NumField(); i++ {
+		field := goType.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(jsonTag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			registry.addFields(schema, field.Type, request)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = registry.schemaFor(field.Type, request)
+		required := !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer
+		if request {
+			required = isRequired(field.Tag.Get("binding")) || isRequired(field.Tag.Get("validate"))
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// isRequired reports whether a binding or validate tag requires the field, optional fields
+// (omitempty, omitnil) only apply their rules when present
+func isRequired(rules string) bool {
+	first, _, _ := strings.Cut(rules, ",")
+	return first == "required"
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
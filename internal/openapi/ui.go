@@ -0,0 +1,11 @@
+package openapi
+
+import _ "embed"
+
+//go:embed ui/index.html
+var uiPage []byte
+
+// UIPage is a self-contained page rendering /api/openapi.json with a request console
+func UIPage() []byte {
+	return uiPage
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gitlab.com/tozd/go/errors"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *model.APIKey) error
+	GetByID(ctx context.Context, filters []gormquery.FilterGroup) (*model.APIKey, error)
+	GetFilteredAPIKeys(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error
+	Delete(ctx context.Context, apiKey *model.APIKey) error
+}
+
+type apiKeyRepository struct {
+	database *gorm.DB
+}
+
+const apiKeyRepoErrorPrefix = "APIKeyRepository"
+
+func NewAPIKeyRepository(database *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{database: database}
+}
+
+func (apiKeyRepo *apiKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	err := db.Session(ctx, apiKeyRepo.database).Create(apiKey).Error
+	if err != nil {
+		err = fmt.Errorf("%s create api key failed: %w", apiKeyRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (apiKeyRepo *apiKeyRepository) GetByID(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+) (*model.APIKey, error) {
+	var apiKey model.APIKey
+	query := db.Session(ctx, apiKeyRepo.database).Model(&model.APIKey{})
+	query = gormquery.ApplyFilters(query, filters)
+	if err := query.First(&apiKey).Error; err != nil {
+		err = fmt.Errorf("%s find api key failed: %w", apiKeyRepoErrorPrefix, err)
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (apiKeyRepo *apiKeyRepository) GetFilteredAPIKeys(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.APIKey, error) {
+	var apiKeys []model.APIKey
+
+	query := db.Session(ctx, apiKeyRepo.database).Model(&model.APIKey{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&apiKeys).Error; err != nil {
+		err = fmt.Errorf("%s find filtered api keys failed: %w", apiKeyRepoErrorPrefix, err)
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+// TouchLastUsed updates only last_used_at so concurrent requests never overwrite other columns
+func (apiKeyRepo *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	err := db.Session(ctx, apiKeyRepo.database).
+		Model(&model.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+	if err != nil {
+		err = fmt.Errorf("%s update api key last use failed: %w", apiKeyRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (apiKeyRepo *apiKeyRepository) Delete(ctx context.Context, apiKey *model.APIKey) error {
+	result := db.Session(ctx, apiKeyRepo.database).
+		Where("id = ?", apiKey.ID).
+		Delete(&model.APIKey{})
+	if result.Error != nil {
+		return fmt.Errorf("%s delete api key failed: %w", apiKeyRepoErrorPrefix, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(
+			fmt.Sprintf("%s delete api key failed: api key you try to delete does not exist", apiKeyRepoErrorPrefix),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository is append-only, audit logs are never updated or deleted
+type AuditLogRepository interface {
+	Create(ctx context.Context, auditLog *model.AuditLog) error
+	GetFilteredAuditLogs(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	database *gorm.DB
+}
+
+const auditLogRepoErrorPrefix = "AuditLogRepository"
+
+func NewAuditLogRepository(database *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{database: database}
+}
+
+func (auditLogRepo *auditLogRepository) Create(ctx context.Context, auditLog *model.AuditLog) error {
+	err := db.Session(ctx, auditLogRepo.database).Create(auditLog).Error
+	if err != nil {
+		err = fmt.Errorf("%s create audit log failed: %w", auditLogRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (auditLogRepo *auditLogRepository) GetFilteredAuditLogs(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.AuditLog, error) {
+	var auditLogs []model.AuditLog
+
+	query := db.Session(ctx, auditLogRepo.database).Model(&model.AuditLog{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&auditLogs).Error; err != nil {
+		err = fmt.Errorf("%s find filtered audit logs failed: %w", auditLogRepoErrorPrefix, err)
+		return nil, err
+	}
+	return auditLogs, nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+// auditLogRepository is append-only like the audit_logs table
+type auditLogRepository struct {
+	store *Store
+}
+
+const auditLogRepoErrorPrefix = "AuditLogRepository"
+
+func NewAuditLogRepository(store *Store) repository.AuditLogRepository {
+	return &auditLogRepository{store: store}
+}
+
+func (auditLogRepo *auditLogRepository) Create(ctx context.Context, auditLog *model.AuditLog) error {
+	auditLogRepo.store.mutex.Lock()
+	defer auditLogRepo.store.mutex.Unlock()
+	db.NormalizeTimes(auditLog)
+	fillTimestamps(auditLog)
+
+	auditLog.ID = nextID(&auditLogRepo.store.sequences.auditLog, auditLog.ID)
+	auditLogRepo.store.auditLogs = append(auditLogRepo.store.auditLogs, *auditLog)
+	return nil
+}
+
+func (auditLogRepo *auditLogRepository) GetFilteredAuditLogs(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.AuditLog, error) {
+	auditLogRepo.store.mutex.Lock()
+	defer auditLogRepo.store.mutex.Unlock()
+
+	auditLogs, err := selectRows(auditLogRepo.store.auditLogs, filters, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered audit logs failed: %w", auditLogRepoErrorPrefix, err)
+	}
+	return auditLogs, nil
+}
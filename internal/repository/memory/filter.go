@@ -0,0 +1,363 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+// columnFunction matches the SQL functions filters may apply to a column, e.g. LOWER(name)
+var columnFunction = regexp.MustCompile(`(?i)^(LOWER|UPPER)\((\w+)\)$`)
+
+var naming = schema.NamingStrategy{}
+
+// selectRows returns the rows matching groups like gormquery.ApplyFilters and applies options
+// like gormquery.ApplyQueryOptions. Rows keep their insertion order when options set no order.
+func selectRows[T any](rows []T, groups []gormquery.FilterGroup, options *gormquery.QueryOptions) ([]T, error) {
+	selected := make([]T, 0, len(rows))
+	for _, row := range rows {
+		matched, err := match(columns(row), groups)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			selected = append(selected, row)
+		}
+	}
+	if options == nil {
+		return selected, nil
+	}
+
+	var sortErr error
+	slices.SortStableFunc(selected, func(a T, b T) int {
+		result, err := compareRows(columns(a), columns(b), options.OrderBy)
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return result
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	if options.Offset != nil {
+		selected = selected[min(max(*options.Offset, 0), len(selected)):]
+	}
+	if options.Limit != nil && *options.Limit >= 0 && *options.Limit < len(selected) {
+		selected = selected[:*options.Limit]
+	}
+	return selected, nil
+}
+
+// columns maps the column names GORM derives from the struct fields of row to their values
+func columns(row any) map[string]any {
+	value := reflect.Indirect(reflect.ValueOf(row))
+	result := make(map[string]any, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		result[naming.ColumnName("", field.Name)] = value.Field(i).Interface()
+	}
+	return result
+}
+
+// match ANDs the filters of a group and ORs the groups, no groups match every row
+func match(row map[string]any, groups []gormquery.FilterGroup) (bool, error) {
+	if len(groups) == 0 {
+		return true, nil
+	}
+	for _, group := range groups {
+		matched := true
+		for _, filter := range group {
+			ok, err := matchFilter(row, filter)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchFilter(row map[string]any, filter gormquery.Filter) (bool, error) {
+	column, function := filter.Field, ""
+	if parts := columnFunction.FindStringSubmatch(filter.Field); parts != nil {
+		function, column = strings.ToUpper(parts[1]), parts[2]
+	}
+	value, ok := row[column]
+	if !ok {
+		return false, fmt.Errorf("column %q does not exist", column)
+	}
+	left, err := normalize(value)
+	if err != nil {
+		return false, err
+	}
+	if text, ok := left.(string); ok {
+		switch function {
+		case "LOWER":
+			left = strings.ToLower(text)
+		case "UPPER":
+			left = strings.ToUpper(text)
+		}
+	}
+
+	operator := strings.ToUpper(strings.TrimSpace(filter.Operator))
+	switch operator {
+	case "IN", "NOT IN":
+		found, err := contains(left, value, filter.Value)
+		if err != nil {
+			return false, err
+		}
+		// like SQL, NULL is neither in nor not in a list
+		return left != nil && found == (operator == "IN"), nil
+	case "LIKE", "ILIKE":
+		text, ok := left.(string)
+		pattern, patternOK := filter.Value.(string)
+		if !ok || !patternOK {
+			return false, fmt.Errorf("%s needs text on both sides of column %q", operator, column)
+		}
+		return like(text, pattern, operator == "ILIKE"), nil
+	}
+
+	right, err := normalizeAs(filter.Value, value)
+	if err != nil {
+		return false, err
+	}
+	// comparisons with NULL are never true in SQL
+	if left == nil || right == nil {
+		return false, nil
+	}
+	result, err := compare(left, right)
+	if err != nil {
+		return false, fmt.Errorf("column %q: %w", column, err)
+	}
+	switch operator {
+	case "=":
+		return result == 0, nil
+	case "!=", "<>":
+		return result != 0, nil
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	case ">=":
+		return result >= 0, nil
+	default:
+		return false, fmt.Errorf("operator %q is not supported", filter.Operator)
+	}
+}
+
+func contains(left any, column any, list any) (bool, error) {
+	values := reflect.ValueOf(list)
+	if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+		return false, fmt.Errorf("IN needs a list, got %T", list)
+	}
+	for i := 0; i < values.Len(); i++ {
+		right, err := normalizeAs(values.Index(i).Interface(), column)
+		if err != nil {
+			return false, err
+		}
+		if left == nil || right == nil {
+			continue
+		}
+		result, err := compare(left, right)
+		if err != nil {
+			return false, err
+		}
+		if result == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// like matches SQL LIKE patterns, % is any text and _ is any character
+func like(text string, pattern string, caseInsensitive bool) bool {
+	var expression strings.Builder
+	expression.WriteString("^")
+	if caseInsensitive {
+		expression.WriteString("(?i)")
+	}
+	for _, char := range pattern {
+		switch char {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String()).MatchString(text)
+}
+
+// compareRows orders rows by the order options, NULLs sort last ascending and first descending like in Postgres
+func compareRows(a map[string]any, b map[string]any, orderBy []gormquery.OrderOption) (int, error) {
+	for _, order := range orderBy {
+		aValue, ok := a[order.Field]
+		if !ok {
+			return 0, fmt.Errorf("column %q does not exist", order.Field)
+		}
+		left, err := normalize(aValue)
+		if err != nil {
+			return 0, err
+		}
+		right, err := normalize(b[order.Field])
+		if err != nil {
+			return 0, err
+		}
+
+		var result int
+		switch {
+		case left == nil && right == nil:
+			result = 0
+		case left == nil:
+			result = 1
+		case right == nil:
+			result = -1
+		default:
+			if result, err = compare(left, right); err != nil {
+				return 0, err
+			}
+		}
+		if strings.ToUpper(order.Direction) == "DESC" {
+			result = -result
+		}
+		if result != 0 {
+			return result, nil
+		}
+	}
+	return 0, nil
+}
+
+// normalize turns a column or filter value into nil, string, int64, uint64, float64, bool or time.Time
+func normalize(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch typed := value.(type) {
+	case uuid.UUID:
+		return typed.String(), nil
+	case time.Time:
+		return typed, nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Pointer:
+		if reflected.IsNil() {
+			return nil, nil
+		}
+		return normalize(reflected.Elem().Interface())
+	case reflect.String:
+		return reflected.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflected.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), nil
+	case reflect.Bool:
+		return reflected.Bool(), nil
+	default:
+		return nil, fmt.Errorf("values of type %T cannot be compared", value)
+	}
+}
+
+// normalizeAs normalizes a filter value and casts text to the type of the column like Postgres does
+// for string parameters, so "user_id" = "<uuid>" and "id" = "42" match
+func normalizeAs(value any, column any) (any, error) {
+	normalized, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+	text, ok := normalized.(string)
+	if !ok {
+		return normalized, nil
+	}
+
+	columnValue, err := normalize(column)
+	if err != nil || columnValue == nil {
+		return normalized, err
+	}
+	if _, isUUID := reflect.Indirect(reflect.ValueOf(column)).Interface().(uuid.UUID); isUUID {
+		parsed, err := uuid.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input syntax for type uuid: %q", text)
+		}
+		return parsed.String(), nil
+	}
+	switch columnValue.(type) {
+	case int64:
+		return strconv.ParseInt(text, 10, 64)
+	case uint64:
+		return strconv.ParseUint(text, 10, 64)
+	}
+	return normalized, nil
+}
+
+func compare(left any, right any) (int, error) {
+	switch leftValue := left.(type) {
+	case string:
+		if rightValue, ok := right.(string); ok {
+			return cmp.Compare(leftValue, rightValue), nil
+		}
+	case bool:
+		if rightValue, ok := right.(bool); ok {
+			if leftValue == rightValue {
+				return 0, nil
+			}
+			if !leftValue {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	case time.Time:
+		if rightValue, ok := right.(time.Time); ok {
+			return leftValue.Compare(rightValue), nil
+		}
+	case int64, uint64, float64:
+		if leftNumber, rightNumber, ok := numbers(left, right); ok {
+			return cmp.Compare(leftNumber, rightNumber), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T", left, right)
+}
+
+// numbers converts two numbers to float64, exact for the IDs and counts repositories filter on
+func numbers(left any, right any) (float64, float64, bool) {
+	toFloat := func(value any) (float64, bool) {
+		switch number := value.(type) {
+		case int64:
+			return float64(number), true
+		case uint64:
+			return float64(number), true
+		case float64:
+			return number, true
+		}
+		return 0, false
+	}
+	leftNumber, leftOK := toFloat(left)
+	rightNumber, rightOK := toFloat(right)
+	return leftNumber, rightNumber, leftOK && rightOK
+}
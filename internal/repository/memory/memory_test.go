@@ -0,0 +1,12 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/memory"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/repositorytest"
+)
+
+func TestRepositories(t *testing.T) {
+	repositorytest.Run(t, memory.NewRepositories())
+}
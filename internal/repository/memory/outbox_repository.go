@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+type outboxRepository struct {
+	store *Store
+}
+
+const outboxRepoErrorPrefix = "OutboxRepository"
+
+func NewOutboxRepository(store *Store) repository.OutboxRepository {
+	return &outboxRepository{store: store}
+}
+
+func (outboxRepo *outboxRepository) Create(ctx context.Context, message *model.OutboxMessage) error {
+	outboxRepo.store.mutex.Lock()
+	defer outboxRepo.store.mutex.Unlock()
+	db.NormalizeTimes(message)
+	fillTimestamps(message)
+
+	taken := slices.ContainsFunc(outboxRepo.store.outboxMessages, func(existing model.OutboxMessage) bool {
+		return existing.EventID == message.EventID
+	})
+	if taken {
+		return duplicate(outboxRepoErrorPrefix, "outbox_messages_event_id_key")
+	}
+	message.ID = nextID(&outboxRepo.store.sequences.outboxMessage, message.ID)
+	outboxRepo.store.outboxMessages = append(outboxRepo.store.outboxMessages, *message)
+	return nil
+}
+
+// ClaimPending leases due messages like the Postgres repository: only the oldest pending message of an
+// aggregate is due, a claimed message is due again once leaseUntil has passed
+func (outboxRepo *outboxRepository) ClaimPending(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]model.OutboxMessage, error) {
+	outboxRepo.store.mutex.Lock()
+	defer outboxRepo.store.mutex.Unlock()
+
+	messages := make([]model.OutboxMessage, 0, limit)
+	waiting := make(map[string]bool)
+	for i := range outboxRepo.store.outboxMessages {
+		if len(messages) == limit {
+			break
+		}
+		message := &outboxRepo.store.outboxMessages[i]
+		if message.DispatchedAt != nil || message.FailedAt != nil {
+			continue
+		}
+		aggregate := message.AggregateType + ":" + message.AggregateID
+		if waiting[aggregate] {
+			continue
+		}
+		waiting[aggregate] = true
+		if message.NextAttemptAt.After(now) {
+			continue
+		}
+		message.NextAttemptAt = leaseUntil.UTC()
+		messages = append(messages, *message)
+	}
+	return messages, nil
+}
+
+// Update saves every field, a message that does not exist is created like gorm's Save does
+func (outboxRepo *outboxRepository) Update(ctx context.Context, message *model.OutboxMessage) error {
+	outboxRepo.store.mutex.Lock()
+	defer outboxRepo.store.mutex.Unlock()
+	db.NormalizeTimes(message)
+
+	index := slices.IndexFunc(outboxRepo.store.outboxMessages, func(existing model.OutboxMessage) bool {
+		return existing.ID == message.ID
+	})
+	if index >= 0 && message.ID != 0 {
+		outboxRepo.store.outboxMessages[index] = *message
+		return nil
+	}
+	message.ID = nextID(&outboxRepo.store.sequences.outboxMessage, message.ID)
+	outboxRepo.store.outboxMessages = append(outboxRepo.store.outboxMessages, *message)
+	return nil
+}
+
+func (outboxRepo *outboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	outboxRepo.store.mutex.Lock()
+	defer outboxRepo.store.mutex.Unlock()
+
+	count := len(outboxRepo.store.outboxMessages)
+	outboxRepo.store.outboxMessages = slices.DeleteFunc(outboxRepo.store.outboxMessages, func(message model.OutboxMessage) bool {
+		return message.DispatchedAt != nil && message.DispatchedAt.Before(before)
+	})
+	return int64(count - len(outboxRepo.store.outboxMessages)), nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+type pomodoroSessionRepository struct {
+	store *Store
+}
+
+const pomodoroSessionRepoErrorPrefix = "PomodoroSessionRepository"
+
+func NewPomodoroSessionRepository(store *Store) repository.PomodoroSessionRepository {
+	return &pomodoroSessionRepository{store: store}
+}
+
+func (sessionRepo *pomodoroSessionRepository) Create(ctx context.Context, session *model.PomodoroSession) error {
+	sessionRepo.store.mutex.Lock()
+	defer sessionRepo.store.mutex.Unlock()
+	db.NormalizeTimes(session)
+	fillTimestamps(session)
+
+	if session.ID != 0 && sessionRepo.indexOf(session.ID) >= 0 {
+		return duplicate(pomodoroSessionRepoErrorPrefix, "pomodoro_sessions_pkey")
+	}
+	session.ID = nextID(&sessionRepo.store.sequences.pomodoroSession, session.ID)
+	sessionRepo.store.pomodoroSessions = append(sessionRepo.store.pomodoroSessions, clonePomodoroSession(*session))
+	return nil
+}
+
+func (sessionRepo *pomodoroSessionRepository) GetFilteredSessions(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.PomodoroSession, error) {
+	sessionRepo.store.mutex.Lock()
+	defer sessionRepo.store.mutex.Unlock()
+
+	sessions, err := selectRows(sessionRepo.store.pomodoroSessions, filters, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered pomodoro sessions failed: %w", pomodoroSessionRepoErrorPrefix, err)
+	}
+	for i := range sessions {
+		sessions[i] = clonePomodoroSession(sessions[i])
+	}
+	return sessions, nil
+}
+
+// Update saves every field, a session that does not exist is created like gorm's Save does
+func (sessionRepo *pomodoroSessionRepository) Update(ctx context.Context, session *model.PomodoroSession) error {
+	sessionRepo.store.mutex.Lock()
+	defer sessionRepo.store.mutex.Unlock()
+	db.NormalizeTimes(session)
+
+	if index := sessionRepo.indexOf(session.ID); index >= 0 && session.ID != 0 {
+		sessionRepo.store.pomodoroSessions[index] = clonePomodoroSession(*session)
+		return nil
+	}
+	session.ID = nextID(&sessionRepo.store.sequences.pomodoroSession, session.ID)
+	sessionRepo.store.pomodoroSessions = append(sessionRepo.store.pomodoroSessions, clonePomodoroSession(*session))
+	return nil
+}
+
+// CountByStatus counts the sessions of all users in status
+func (sessionRepo *pomodoroSessionRepository) CountByStatus(ctx context.Context, status model.PomodoroStatus) (int64, error) {
+	sessionRepo.store.mutex.Lock()
+	defer sessionRepo.store.mutex.Unlock()
+
+	var count int64
+	for _, session := range sessionRepo.store.pomodoroSessions {
+		if session.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (sessionRepo *pomodoroSessionRepository) indexOf(id uint64) int {
+	return slices.IndexFunc(sessionRepo.store.pomodoroSessions, func(session model.PomodoroSession) bool {
+		return session.ID == id
+	})
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"gorm.io/gorm"
+)
+
+type projectRepository struct {
+	store *Store
+}
+
+const projectRepoErrorPrefix = "ProjectRepository"
+
+func NewProjectRepository(store *Store) repository.ProjectRepository {
+	return &projectRepository{store: store}
+}
+
+func (projectRepo *projectRepository) Create(ctx context.Context, project *model.Project) error {
+	projectRepo.store.mutex.Lock()
+	defer projectRepo.store.mutex.Unlock()
+	db.NormalizeTimes(project)
+	fillTimestamps(project)
+
+	if project.ID != 0 && projectRepo.indexOf(project.ID) >= 0 {
+		return duplicate(projectRepoErrorPrefix, "projects_pkey")
+	}
+	if projectRepo.nameTaken(*project) {
+		return duplicate(projectRepoErrorPrefix, "projects_user_id_name_key")
+	}
+	project.ID = nextID(&projectRepo.store.sequences.project, project.ID)
+	projectRepo.store.projects = append(projectRepo.store.projects, *project)
+	return nil
+}
+
+func (projectRepo *projectRepository) GetFilteredProjects(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options gormquery.QueryOptions,
+) ([]model.Project, error) {
+	projectRepo.store.mutex.Lock()
+	defer projectRepo.store.mutex.Unlock()
+
+	projects, err := selectRows(projectRepo.store.projects, filters, &options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered projects failed: %w", projectRepoErrorPrefix, err)
+	}
+	return projects, nil
+}
+
+// Update sets the columns named by the keys of updates
+func (projectRepo *projectRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	projectRepo.store.mutex.Lock()
+	defer projectRepo.store.mutex.Unlock()
+	db.NormalizeTimes(id)
+
+	projectID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s update project failed: invalid input syntax for type integer: %w", projectRepoErrorPrefix, err)
+	}
+	index := projectRepo.indexOf(projectID)
+	if index < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	project := projectRepo.store.projects[index]
+	if err := setColumns(&project, updates); err != nil {
+		return fmt.Errorf("%s update project failed: %w", projectRepoErrorPrefix, err)
+	}
+	if projectRepo.nameTaken(project) {
+		return duplicate(projectRepoErrorPrefix, "projects_user_id_name_key")
+	}
+	projectRepo.store.projects[index] = project
+	return nil
+}
+
+func (projectRepo *projectRepository) DeleteByID(ctx context.Context, id string) error {
+	projectRepo.store.mutex.Lock()
+	defer projectRepo.store.mutex.Unlock()
+
+	projectID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s delete project failed: invalid input syntax for type integer: %w", projectRepoErrorPrefix, err)
+	}
+	if !projectRepo.store.deleteProject(projectID) {
+		return fmt.Errorf("%s delete project failed: project you try to delete does not exist", projectRepoErrorPrefix)
+	}
+	return nil
+}
+
+func (projectRepo *projectRepository) indexOf(id uint64) int {
+	return slices.IndexFunc(projectRepo.store.projects, func(project model.Project) bool { return project.ID == id })
+}
+
+// nameTaken checks UNIQUE (user_id, name) against the other projects
+func (projectRepo *projectRepository) nameTaken(project model.Project) bool {
+	return slices.ContainsFunc(projectRepo.store.projects, func(existing model.Project) bool {
+		return existing.ID != project.ID && existing.UserID == project.UserID && existing.Name == project.Name
+	})
+}
+
+// setColumns assigns values to the struct fields of row by their column names
+func setColumns(row any, values map[string]interface{}) error {
+	target := reflect.ValueOf(row).Elem()
+	for column, value := range values {
+		index := slices.IndexFunc(reflect.VisibleFields(target.Type()), func(field reflect.StructField) bool {
+			return naming.ColumnName("", field.Name) == column
+		})
+		if index < 0 {
+			return fmt.Errorf("column %q does not exist", column)
+		}
+		field := target.Field(index)
+		assigned := reflect.ValueOf(value)
+		if !assigned.IsValid() {
+			field.SetZero()
+			continue
+		}
+		if !assigned.Type().ConvertibleTo(field.Type()) {
+			return fmt.Errorf("column %q cannot be set to %T", column, value)
+		}
+		field.Set(assigned.Convert(field.Type()))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+// schemaRepository reports the store as reachable and migrated, its tables always have the current schema
+type schemaRepository struct{}
+
+func NewSchemaRepository() repository.SchemaRepository {
+	return &schemaRepository{}
+}
+
+func (schemaRepo *schemaRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (schemaRepo *schemaRepository) Version(ctx context.Context) (uint, bool, error) {
+	return db.SchemaVersion, false, ctx.Err()
+}
@@ -0,0 +1,274 @@
+// Package memory implements UserRepository, ProjectRepository, TaskRepository, TimeRecordRepository,
+// TimeRecordVersionRepository, PomodoroSessionRepository, AuditLogRepository, OutboxRepository and
+// SchemaRepository in memory for unit tests.
+// Filters and query options are evaluated like gormquery applies them in SQL, the unique constraints
+// of the migrations are enforced, deletes cascade like their foreign keys, times are stored in UTC
+// like db.Open does and Create fills in zero timestamps like GORM; the existence of referenced rows is not checked. repositorytest holds the
+// behaviour they share with the Postgres implementations.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store holds the tables the repositories of one test share
+type Store struct {
+	mutex       sync.Mutex
+	users       []model.User
+	projects    []model.Project
+	tasks       []model.Task
+	timeRecords []model.TimeRecord
+
+	timeRecordVersions []model.TimeRecordVersion
+	pomodoroSessions   []model.PomodoroSession
+	auditLogs          []model.AuditLog
+	outboxMessages     []model.OutboxMessage
+
+	sequences sequences
+}
+
+// sequences are the last IDs handed out per table, like SERIAL they are not rolled back
+type sequences struct {
+	project           uint64
+	task              uint64
+	timeRecord        uint64
+	timeRecordVersion uint64
+	pomodoroSession   uint64
+	auditLog          uint64
+	outboxMessage     uint64
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// NewRepositories returns in-memory repositories on a new Store. The repositories without an
+// in-memory implementation are left nil, tests set fakes for the ones their services use.
+func NewRepositories() *repository.Repositories {
+	store := NewStore()
+	return &repository.Repositories{
+		Transactor:         store,
+		Users:              NewUserRepository(store),
+		Projects:           NewProjectRepository(store),
+		Tasks:              NewTaskRepository(store),
+		TimeRecords:        NewTimeRecordRepository(store),
+		TimeRecordVersions: NewTimeRecordVersionRepository(store),
+		PomodoroSessions:   NewPomodoroSessionRepository(store),
+		AuditLogs:          NewAuditLogRepository(store),
+		Outbox:             NewOutboxRepository(store),
+		Schema:             NewSchemaRepository(),
+	}
+}
+
+type transactionKey struct{}
+
+// WithTransaction runs fn and restores the tables when it fails, nested calls join the outer one.
+// Unlike Postgres it does not isolate fn from concurrent writes, which are undone by a rollback as well.
+func (store *Store) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(transactionKey{}) != nil {
+		return fn(ctx)
+	}
+
+	store.mutex.Lock()
+	saved := store.snapshot()
+	store.mutex.Unlock()
+
+	if err := fn(context.WithValue(ctx, transactionKey{}, true)); err != nil {
+		store.mutex.Lock()
+		store.restore(saved)
+		store.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+type snapshot struct {
+	users       []model.User
+	projects    []model.Project
+	tasks       []model.Task
+	timeRecords []model.TimeRecord
+
+	timeRecordVersions []model.TimeRecordVersion
+	pomodoroSessions   []model.PomodoroSession
+	auditLogs          []model.AuditLog
+	outboxMessages     []model.OutboxMessage
+}
+
+func (store *Store) snapshot() snapshot {
+	tasks := slices.Clone(store.tasks)
+	for i := range tasks {
+		tasks[i] = cloneTask(tasks[i])
+	}
+	timeRecords := slices.Clone(store.timeRecords)
+	for i := range timeRecords {
+		timeRecords[i] = cloneTimeRecord(timeRecords[i])
+	}
+	timeRecordVersions := slices.Clone(store.timeRecordVersions)
+	for i := range timeRecordVersions {
+		timeRecordVersions[i] = cloneTimeRecordVersion(timeRecordVersions[i])
+	}
+	pomodoroSessions := slices.Clone(store.pomodoroSessions)
+	for i := range pomodoroSessions {
+		pomodoroSessions[i] = clonePomodoroSession(pomodoroSessions[i])
+	}
+	return snapshot{
+		users:              slices.Clone(store.users),
+		projects:           slices.Clone(store.projects),
+		tasks:              tasks,
+		timeRecords:        timeRecords,
+		timeRecordVersions: timeRecordVersions,
+		pomodoroSessions:   pomodoroSessions,
+		auditLogs:          slices.Clone(store.auditLogs),
+		outboxMessages:     slices.Clone(store.outboxMessages),
+	}
+}
+
+func (store *Store) restore(saved snapshot) {
+	store.users = saved.users
+	store.projects = saved.projects
+	store.tasks = saved.tasks
+	store.timeRecords = saved.timeRecords
+	store.timeRecordVersions = saved.timeRecordVersions
+	store.pomodoroSessions = saved.pomodoroSessions
+	store.auditLogs = saved.auditLogs
+	store.outboxMessages = saved.outboxMessages
+}
+
+// deleteUser removes the user with their projects, tasks, time records, time record versions and
+// pomodoro sessions like ON DELETE CASCADE, audit logs and outbox messages have no foreign key and are kept
+func (store *Store) deleteUser(id uuid.UUID) bool {
+	index := slices.IndexFunc(store.users, func(user model.User) bool { return user.ID == id })
+	if index < 0 {
+		return false
+	}
+	store.users = slices.Delete(store.users, index, index+1)
+	for _, project := range slices.Clone(store.projects) {
+		if project.UserID == id {
+			store.deleteProject(project.ID)
+		}
+	}
+	for _, task := range slices.Clone(store.tasks) {
+		if task.UserID == id {
+			store.deleteTask(task.ID)
+		}
+	}
+	store.timeRecords = slices.DeleteFunc(store.timeRecords, func(timeRecord model.TimeRecord) bool {
+		return timeRecord.UserID == id
+	})
+	store.timeRecordVersions = slices.DeleteFunc(store.timeRecordVersions, func(version model.TimeRecordVersion) bool {
+		return version.UserID == id
+	})
+	store.pomodoroSessions = slices.DeleteFunc(store.pomodoroSessions, func(session model.PomodoroSession) bool {
+		return session.UserID == id
+	})
+	return true
+}
+
+// deleteProject removes the project with its tasks and their time records
+func (store *Store) deleteProject(id uint64) bool {
+	index := slices.IndexFunc(store.projects, func(project model.Project) bool { return project.ID == id })
+	if index < 0 {
+		return false
+	}
+	store.projects = slices.Delete(store.projects, index, index+1)
+	for _, task := range slices.Clone(store.tasks) {
+		if task.ProjectID == id {
+			store.deleteTask(task.ID)
+		}
+	}
+	return true
+}
+
+// deleteTask removes the task with its time records and pomodoro sessions
+func (store *Store) deleteTask(id uint64) bool {
+	index := slices.IndexFunc(store.tasks, func(task model.Task) bool { return task.ID == id })
+	if index < 0 {
+		return false
+	}
+	store.tasks = slices.Delete(store.tasks, index, index+1)
+	store.timeRecords = slices.DeleteFunc(store.timeRecords, func(timeRecord model.TimeRecord) bool {
+		return timeRecord.TaskID == id
+	})
+	store.pomodoroSessions = slices.DeleteFunc(store.pomodoroSessions, func(session model.PomodoroSession) bool {
+		return session.TaskID == id
+	})
+	return true
+}
+
+// cloneTask copies the tags, callers must not share them with the stored row
+func cloneTask(task model.Task) model.Task {
+	task.Tags = slices.Clone(task.Tags)
+	return task
+}
+
+// cloneTimeRecord copies the end time, callers must not share it with the stored row
+func cloneTimeRecord(timeRecord model.TimeRecord) model.TimeRecord {
+	if timeRecord.EndTime != nil {
+		endTime := *timeRecord.EndTime
+		timeRecord.EndTime = &endTime
+	}
+	return timeRecord
+}
+
+// cloneTimeRecordVersion copies the end time, callers must not share it with the stored row
+func cloneTimeRecordVersion(version model.TimeRecordVersion) model.TimeRecordVersion {
+	if version.EndTime != nil {
+		endTime := *version.EndTime
+		version.EndTime = &endTime
+	}
+	return version
+}
+
+// clonePomodoroSession copies the time record ID and end time, callers must not share them with the stored row
+func clonePomodoroSession(session model.PomodoroSession) model.PomodoroSession {
+	if session.TimeRecordID != nil {
+		timeRecordID := *session.TimeRecordID
+		session.TimeRecordID = &timeRecordID
+	}
+	if session.EndTime != nil {
+		endTime := *session.EndTime
+		session.EndTime = &endTime
+	}
+	return session
+}
+
+// fillTimestamps sets the zero CreatedAt and UpdatedAt of a new row to the current time in UTC,
+// like GORM does for its autoCreateTime and autoUpdateTime fields
+func fillTimestamps(row any) {
+	created := time.Now().UTC()
+	value := reflect.ValueOf(row).Elem()
+	for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+		if field := value.FieldByName(name); field.IsValid() && field.IsZero() {
+			field.Set(reflect.ValueOf(created))
+		}
+	}
+}
+
+// nextID returns id when it is set and the next value of sequence otherwise
+func nextID(sequence *uint64, id uint64) uint64 {
+	if id == 0 {
+		*sequence++
+		return *sequence
+	}
+	*sequence = max(*sequence, id)
+	return id
+}
+
+// duplicate is the error of a write violating a unique constraint, apperror.From reports it as a conflict
+func duplicate(prefix string, constraint string) error {
+	return fmt.Errorf("%s duplicate key violates unique constraint %s: %w", prefix, constraint, gorm.ErrDuplicatedKey)
+}
+
+func notFound(prefix string, what string) error {
+	return fmt.Errorf("%s find %s failed: %w", prefix, what, gorm.ErrRecordNotFound)
+}
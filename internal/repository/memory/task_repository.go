@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+type taskRepository struct {
+	store *Store
+}
+
+const taskRepoErrorPrefix = "TaskRepository"
+
+func NewTaskRepository(store *Store) repository.TaskRepository {
+	return &taskRepository{store: store}
+}
+
+func (taskRepo *taskRepository) Create(ctx context.Context, task *model.Task) error {
+	taskRepo.store.mutex.Lock()
+	defer taskRepo.store.mutex.Unlock()
+	db.NormalizeTimes(task)
+	fillTimestamps(task)
+
+	if task.ID != 0 && taskRepo.indexOf(task.ID) >= 0 {
+		return duplicate(taskRepoErrorPrefix, "tasks_pkey")
+	}
+	if taskRepo.nameTaken(*task) {
+		return duplicate(taskRepoErrorPrefix, "tasks_user_id_project_id_name_key")
+	}
+	task.ID = nextID(&taskRepo.store.sequences.task, task.ID)
+	taskRepo.store.tasks = append(taskRepo.store.tasks, cloneTask(*task))
+	return nil
+}
+
+func (taskRepo *taskRepository) GetByID(ctx context.Context, filters []gormquery.FilterGroup) (*model.Task, error) {
+	taskRepo.store.mutex.Lock()
+	defer taskRepo.store.mutex.Unlock()
+
+	tasks, err := selectRows(taskRepo.store.tasks, filters, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s find task by id failed: %w", taskRepoErrorPrefix, err)
+	}
+	if len(tasks) == 0 {
+		return nil, notFound(taskRepoErrorPrefix, "task by id")
+	}
+	task := cloneTask(tasks[0])
+	return &task, nil
+}
+
+func (taskRepo *taskRepository) GetFilteredTasks(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.Task, error) {
+	taskRepo.store.mutex.Lock()
+	defer taskRepo.store.mutex.Unlock()
+
+	tasks, err := selectRows(taskRepo.store.tasks, filters, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered tasks failed: %w", taskRepoErrorPrefix, err)
+	}
+	for i := range tasks {
+		tasks[i] = cloneTask(tasks[i])
+	}
+	return tasks, nil
+}
+
+// Update saves every field, a task that does not exist is created like gorm's Save does
+func (taskRepo *taskRepository) Update(ctx context.Context, task *model.Task) error {
+	taskRepo.store.mutex.Lock()
+	defer taskRepo.store.mutex.Unlock()
+	db.NormalizeTimes(task)
+
+	if taskRepo.nameTaken(*task) {
+		return duplicate(taskRepoErrorPrefix, "tasks_user_id_project_id_name_key")
+	}
+	if index := taskRepo.indexOf(task.ID); index >= 0 && task.ID != 0 {
+		taskRepo.store.tasks[index] = cloneTask(*task)
+		return nil
+	}
+	task.ID = nextID(&taskRepo.store.sequences.task, task.ID)
+	taskRepo.store.tasks = append(taskRepo.store.tasks, cloneTask(*task))
+	return nil
+}
+
+func (taskRepo *taskRepository) Delete(ctx context.Context, task *model.Task) error {
+	taskRepo.store.mutex.Lock()
+	defer taskRepo.store.mutex.Unlock()
+
+	if !taskRepo.store.deleteTask(task.ID) {
+		return fmt.Errorf("%s delete task failed: task you try to delete does not exist", taskRepoErrorPrefix)
+	}
+	return nil
+}
+
+func (taskRepo *taskRepository) indexOf(id uint64) int {
+	return slices.IndexFunc(taskRepo.store.tasks, func(task model.Task) bool { return task.ID == id })
+}
+
+// nameTaken checks UNIQUE (user_id, project_id, name) against the other tasks
+func (taskRepo *taskRepository) nameTaken(task model.Task) bool {
+	return slices.ContainsFunc(taskRepo.store.tasks, func(existing model.Task) bool {
+		return existing.ID != task.ID &&
+			existing.UserID == task.UserID &&
+			existing.ProjectID == task.ProjectID &&
+			existing.Name == task.Name
+	})
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+type timeRecordRepository struct {
+	store *Store
+}
+
+const timeRecordRepoErrorPrefix = "TimeRecordRepository"
+
+func NewTimeRecordRepository(store *Store) repository.TimeRecordRepository {
+	return &timeRecordRepository{store: store}
+}
+
+func (timeRecordRepo *timeRecordRepository) Create(ctx context.Context, timeRecord *model.TimeRecord) error {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+	db.NormalizeTimes(timeRecord)
+	fillTimestamps(timeRecord)
+
+	if timeRecord.ID != 0 && timeRecordRepo.indexOf(timeRecord.ID) >= 0 {
+		return duplicate(timeRecordRepoErrorPrefix, "time_records_pkey")
+	}
+	timeRecord.ID = nextID(&timeRecordRepo.store.sequences.timeRecord, timeRecord.ID)
+	timeRecordRepo.store.timeRecords = append(timeRecordRepo.store.timeRecords, cloneTimeRecord(*timeRecord))
+	return nil
+}
+
+func (timeRecordRepo *timeRecordRepository) GetByID(ctx context.Context, id uint64) (*model.TimeRecord, error) {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+
+	index := timeRecordRepo.indexOf(id)
+	if index < 0 {
+		return nil, notFound(timeRecordRepoErrorPrefix, "time record by id")
+	}
+	timeRecord := cloneTimeRecord(timeRecordRepo.store.timeRecords[index])
+	return &timeRecord, nil
+}
+
+func (timeRecordRepo *timeRecordRepository) GetByTaskID(ctx context.Context, taskID uint64) (*[]model.TimeRecord, error) {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("task_id", "=", taskID),
+		),
+	}
+	return timeRecordRepo.GetFilteredTimeRecords(ctx, filters, nil)
+}
+
+func (timeRecordRepo *timeRecordRepository) GetFilteredTimeRecords(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) (*[]model.TimeRecord, error) {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+
+	timeRecords, err := selectRows(timeRecordRepo.store.timeRecords, filters, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered time records failed: %w", timeRecordRepoErrorPrefix, err)
+	}
+	for i := range timeRecords {
+		timeRecords[i] = cloneTimeRecord(timeRecords[i])
+	}
+	return &timeRecords, nil
+}
+
+// Update saves every field, a time record that does not exist is created like gorm's Save does
+func (timeRecordRepo *timeRecordRepository) Update(ctx context.Context, timeRecord *model.TimeRecord) error {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+	db.NormalizeTimes(timeRecord)
+
+	if index := timeRecordRepo.indexOf(timeRecord.ID); index >= 0 && timeRecord.ID != 0 {
+		timeRecordRepo.store.timeRecords[index] = cloneTimeRecord(*timeRecord)
+		return nil
+	}
+	timeRecord.ID = nextID(&timeRecordRepo.store.sequences.timeRecord, timeRecord.ID)
+	timeRecordRepo.store.timeRecords = append(timeRecordRepo.store.timeRecords, cloneTimeRecord(*timeRecord))
+	return nil
+}
+
+func (timeRecordRepo *timeRecordRepository) Delete(ctx context.Context, timeRecord *model.TimeRecord) error {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+
+	index := timeRecordRepo.indexOf(timeRecord.ID)
+	if index < 0 {
+		return fmt.Errorf(
+			"%s delete time record failed: time record you try to delete does not exist",
+			timeRecordRepoErrorPrefix,
+		)
+	}
+	timeRecordRepo.store.timeRecords = slices.Delete(timeRecordRepo.store.timeRecords, index, index+1)
+	// time_record_id of pomodoro sessions is ON DELETE SET NULL
+	for i := range timeRecordRepo.store.pomodoroSessions {
+		if session := &timeRecordRepo.store.pomodoroSessions[i]; session.TimeRecordID != nil && *session.TimeRecordID == timeRecord.ID {
+			session.TimeRecordID = nil
+		}
+	}
+	return nil
+}
+
+// CountActive counts the time records of all users that are not stopped yet
+func (timeRecordRepo *timeRecordRepository) CountActive(ctx context.Context) (int64, error) {
+	timeRecordRepo.store.mutex.Lock()
+	defer timeRecordRepo.store.mutex.Unlock()
+
+	var count int64
+	for _, timeRecord := range timeRecordRepo.store.timeRecords {
+		if timeRecord.EndTime == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (timeRecordRepo *timeRecordRepository) indexOf(id uint64) int {
+	return slices.IndexFunc(timeRecordRepo.store.timeRecords, func(timeRecord model.TimeRecord) bool {
+		return timeRecord.ID == id
+	})
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+type timeRecordVersionRepository struct {
+	store *Store
+}
+
+const timeRecordVersionRepoErrorPrefix = "TimeRecordVersionRepository"
+
+func NewTimeRecordVersionRepository(store *Store) repository.TimeRecordVersionRepository {
+	return &timeRecordVersionRepository{store: store}
+}
+
+func (versionRepo *timeRecordVersionRepository) Create(ctx context.Context, version *model.TimeRecordVersion) error {
+	versionRepo.store.mutex.Lock()
+	defer versionRepo.store.mutex.Unlock()
+	db.NormalizeTimes(version)
+	fillTimestamps(version)
+
+	taken := slices.ContainsFunc(versionRepo.store.timeRecordVersions, func(existing model.TimeRecordVersion) bool {
+		return existing.TimeRecordID == version.TimeRecordID && existing.Version == version.Version
+	})
+	if taken {
+		return duplicate(timeRecordVersionRepoErrorPrefix, "time_record_versions_time_record_id_version_key")
+	}
+	version.ID = nextID(&versionRepo.store.sequences.timeRecordVersion, version.ID)
+	versionRepo.store.timeRecordVersions = append(versionRepo.store.timeRecordVersions, cloneTimeRecordVersion(*version))
+	return nil
+}
+
+func (versionRepo *timeRecordVersionRepository) GetFilteredVersions(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.TimeRecordVersion, error) {
+	versionRepo.store.mutex.Lock()
+	defer versionRepo.store.mutex.Unlock()
+
+	versions, err := selectRows(versionRepo.store.timeRecordVersions, filters, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s find filtered time record versions failed: %w", timeRecordVersionRepoErrorPrefix, err)
+	}
+	for i := range versions {
+		versions[i] = cloneTimeRecordVersion(versions[i])
+	}
+	return versions, nil
+}
+
+// GetLatestVersionNumber returns 0 when the time record has no versions yet
+func (versionRepo *timeRecordVersionRepository) GetLatestVersionNumber(ctx context.Context, timeRecordID uint64) (int, error) {
+	versionRepo.store.mutex.Lock()
+	defer versionRepo.store.mutex.Unlock()
+
+	latest := 0
+	for _, version := range versionRepo.store.timeRecordVersions {
+		if version.TimeRecordID == timeRecordID {
+			latest = max(latest, version.Version)
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/google/uuid"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+const userRepoErrorPrefix = "UserRepository"
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{store: store}
+}
+
+func (userRepo *userRepository) Create(ctx context.Context, user *model.User) error {
+	userRepo.store.mutex.Lock()
+	defer userRepo.store.mutex.Unlock()
+	db.NormalizeTimes(user)
+	fillTimestamps(user)
+
+	if userRepo.indexOf(user.ID.String()) >= 0 {
+		return duplicate(userRepoErrorPrefix, "users_pkey")
+	}
+	if userRepo.emailTaken(user) {
+		return duplicate(userRepoErrorPrefix, "users_email_key")
+	}
+	userRepo.store.users = append(userRepo.store.users, *user)
+	return nil
+}
+
+func (userRepo *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	userRepo.store.mutex.Lock()
+	defer userRepo.store.mutex.Unlock()
+
+	for _, user := range userRepo.store.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, notFound(userRepoErrorPrefix, "user by email")
+}
+
+func (userRepo *userRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	userRepo.store.mutex.Lock()
+	defer userRepo.store.mutex.Unlock()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%s get user by id failed: invalid input syntax for type uuid: %w", userRepoErrorPrefix, err)
+	}
+	index := userRepo.indexOf(id)
+	if index < 0 {
+		return nil, notFound(userRepoErrorPrefix, "user by id")
+	}
+	user := userRepo.store.users[index]
+	return &user, nil
+}
+
+// Update saves every field, a user that does not exist is created like gorm's Save does
+func (userRepo *userRepository) Update(ctx context.Context, user *model.User) error {
+	userRepo.store.mutex.Lock()
+	defer userRepo.store.mutex.Unlock()
+	db.NormalizeTimes(user)
+
+	if userRepo.emailTaken(user) {
+		return duplicate(userRepoErrorPrefix, "users_email_key")
+	}
+	if index := userRepo.indexOf(user.ID.String()); index >= 0 {
+		userRepo.store.users[index] = *user
+		return nil
+	}
+	userRepo.store.users = append(userRepo.store.users, *user)
+	return nil
+}
+
+func (userRepo *userRepository) Delete(ctx context.Context, user *model.User) error {
+	userRepo.store.mutex.Lock()
+	defer userRepo.store.mutex.Unlock()
+
+	if !userRepo.store.deleteUser(user.ID) {
+		return fmt.Errorf(
+			"%s delete user failed: user you try to delete does not exist. User ID: %s", userRepoErrorPrefix, user.ID,
+		)
+	}
+	return nil
+}
+
+func (userRepo *userRepository) indexOf(id string) int {
+	return slices.IndexFunc(userRepo.store.users, func(user model.User) bool { return user.ID.String() == id })
+}
+
+func (userRepo *userRepository) emailTaken(user *model.User) bool {
+	return slices.ContainsFunc(userRepo.store.users, func(existing model.User) bool {
+		return existing.Email == user.Email && existing.ID != user.ID
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, message *model.OutboxMessage) error
+	ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.OutboxMessage, error)
+	Update(ctx context.Context, message *model.OutboxMessage) error
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	database *gorm.DB
+}
+
+const outboxRepoErrorPrefix = "OutboxRepository"
+
+func NewOutboxRepository(database *gorm.DB) OutboxRepository {
+	return &outboxRepository{database: database}
+}
+
+func (outboxRepo *outboxRepository) Create(ctx context.Context, message *model.OutboxMessage) error {
+	err := db.Session(ctx, outboxRepo.database).Create(message).Error
+	if err != nil {
+		err = fmt.Errorf("%s create outbox message failed: %w", outboxRepoErrorPrefix, err)
+	}
+	return err
+}
+
+// claimPendingSQL moves the next attempt of due messages to the end of the lease, other dispatchers skip them
+// until it runs out. Only the oldest pending message of an aggregate is due, its later messages wait for it
+// to be relayed or given up, also while it waits for a retry.
+const claimPendingSQL = `
+UPDATE outbox_messages SET next_attempt_at = @lease_until
+WHERE id IN (
+    SELECT id FROM outbox_messages AS message
+    WHERE message.dispatched_at IS NULL AND message.failed_at IS NULL AND message.next_attempt_at <= @now
+        AND NOT EXISTS (
+            SELECT 1 FROM outbox_messages AS earlier
+            WHERE earlier.aggregate_type = message.aggregate_type
+                AND earlier.aggregate_id = message.aggregate_id
+                AND earlier.id < message.id
+                AND earlier.dispatched_at IS NULL AND earlier.failed_at IS NULL
+        )
+    ORDER BY message.id
+    LIMIT @limit
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimPending leases up to limit due messages until leaseUntil and returns them in insertion order.
+// A message that is neither relayed nor given up by then is claimed again.
+func (outboxRepo *outboxRepository) ClaimPending(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]model.OutboxMessage, error) {
+	var messages []model.OutboxMessage
+	err := db.Session(ctx, outboxRepo.database).
+		Raw(claimPendingSQL, sql.Named("now", now), sql.Named("lease_until", leaseUntil), sql.Named("limit", limit)).
+		Scan(&messages).Error
+	if err != nil {
+		err = fmt.Errorf("%s claim pending outbox messages failed: %w", outboxRepoErrorPrefix, err)
+		return nil, err
+	}
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(messages, func(a, b model.OutboxMessage) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return messages, nil
+}
+
+func (outboxRepo *outboxRepository) Update(ctx context.Context, message *model.OutboxMessage) error {
+	err := db.Session(ctx, outboxRepo.database).Save(message).Error
+	if err != nil {
+		err = fmt.Errorf("%s update outbox message failed: %w", outboxRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (outboxRepo *outboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := db.Session(ctx, outboxRepo.database).
+		Where("dispatched_at IS NOT NULL AND dispatched_at < ?", before).
+		Delete(&model.OutboxMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("%s delete dispatched outbox messages failed: %w", outboxRepoErrorPrefix, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gorm.io/gorm"
+)
+
+type PomodoroSessionRepository interface {
+	Create(ctx context.Context, session *model.PomodoroSession) error
+	GetFilteredSessions(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.PomodoroSession, error)
+	Update(ctx context.Context, session *model.PomodoroSession) error
+	CountByStatus(ctx context.Context, status model.PomodoroStatus) (int64, error)
+}
+
+type pomodoroSessionRepository struct {
+	database *gorm.DB
+}
+
+const pomodoroSessionRepoErrorPrefix = "PomodoroSessionRepository"
+
+func NewPomodoroSessionRepository(database *gorm.DB) PomodoroSessionRepository {
+	return &pomodoroSessionRepository{database: database}
+}
+
+func (sessionRepo *pomodoroSessionRepository) Create(ctx context.Context, session *model.PomodoroSession) error {
+	err := db.Session(ctx, sessionRepo.database).Create(session).Error
+	if err != nil {
+		err = fmt.Errorf("%s create pomodoro session failed: %w", pomodoroSessionRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (sessionRepo *pomodoroSessionRepository) GetFilteredSessions(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.PomodoroSession, error) {
+	var sessions []model.PomodoroSession
+
+	query := db.Session(ctx, sessionRepo.database).Model(&model.PomodoroSession{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&sessions).Error; err != nil {
+		err = fmt.Errorf("%s find filtered pomodoro sessions failed: %w", pomodoroSessionRepoErrorPrefix, err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (sessionRepo *pomodoroSessionRepository) Update(ctx context.Context, session *model.PomodoroSession) error {
+	err := db.Session(ctx, sessionRepo.database).Save(session).Error
+	if err != nil {
+		err = fmt.Errorf("%s update pomodoro session failed: %w", pomodoroSessionRepoErrorPrefix, err)
+	}
+	return err
+}
+
+// CountByStatus counts the sessions of all users in status
+func (sessionRepo *pomodoroSessionRepository) CountByStatus(ctx context.Context, status model.PomodoroStatus) (int64, error) {
+	var count int64
+	err := db.Session(ctx, sessionRepo.database).
+		Model(&model.PomodoroSession{}).
+		Where("status = ?", status).
+		Count(&count).Error
+	if err != nil {
+		err = fmt.Errorf("%s count pomodoro sessions failed: %w", pomodoroSessionRepoErrorPrefix, err)
+	}
+	return count, err
+}
@@ -28,12 +28,12 @@ type projectRepository struct {
 
 const projectRepoErrorPrefix = "ProjectRepository"
 
-func NewProjectRepository() ProjectRepository {
-	return &projectRepository{database: db.Get()}
+func NewProjectRepository(database *gorm.DB) ProjectRepository {
+	return &projectRepository{database: database}
 }
 
 func (projectRepo *projectRepository) Create(ctx context.Context, project *model.Project) error {
-	err := projectRepo.database.WithContext(ctx).Create(project).Error
+	err := db.Session(ctx, projectRepo.database).Create(project).Error
 	if err != nil {
 		err = fmt.Errorf("%s create project failed: %w", projectRepoErrorPrefix, err)
 	}
@@ -47,7 +47,7 @@ func (projectRepo *projectRepository) GetFilteredProjects(
 ) ([]model.Project, error) {
 	var projects []model.Project
 
-	query := projectRepo.database.WithContext(ctx).Model(&model.Project{})
+	query := db.Session(ctx, projectRepo.database).Model(&model.Project{})
 	query = gormquery.ApplyFilters(query, filters)
 	query = gormquery.ApplyQueryOptions(query, options)
 
@@ -60,7 +60,7 @@ func (projectRepo *projectRepository) GetFilteredProjects(
 
 func (projectRepo *projectRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
 	// FIXME param should be  *model.Project
-	result := projectRepo.database.WithContext(ctx).
+	result := db.Session(ctx, projectRepo.database).
 		Model(&model.Project{}).
 		Where("id = ?", id).
 		Updates(updates)
@@ -76,7 +76,7 @@ func (projectRepo *projectRepository) Update(ctx context.Context, id string, upd
 }
 
 func (projectRepo *projectRepository) DeleteByID(ctx context.Context, id string) error {
-	result := projectRepo.database.WithContext(ctx).Where("id = ?", id).Delete(&model.Project{})
+	result := db.Session(ctx, projectRepo.database).Where("id = ?", id).Delete(&model.Project{})
 	if result.Error != nil {
 		result.Error = fmt.Errorf("%s delete project failed: %w", projectRepoErrorPrefix, result.Error)
 		return result.Error
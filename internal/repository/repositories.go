@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"gorm.io/gorm"
+)
+
+// Transactor runs fn in a transaction, repositories called with the context passed to fn join it
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Repositories are the repositories of one database, services take the ones they need from it
+type Repositories struct {
+	Transactor           Transactor
+	Users                UserRepository
+	Projects             ProjectRepository
+	Tasks                TaskRepository
+	TimeRecords          TimeRecordRepository
+	TimeRecordVersions   TimeRecordVersionRepository
+	PomodoroSessions     PomodoroSessionRepository
+	APIKeys              APIKeyRepository
+	AuditLogs            AuditLogRepository
+	Outbox               OutboxRepository
+	WebhookSubscriptions WebhookSubscriptionRepository
+	WebhookDeliveries    WebhookDeliveryRepository
+	Schema               SchemaRepository
+}
+
+func NewRepositories(database *gorm.DB) *Repositories {
+	return &Repositories{
+		Transactor:           db.NewTransactor(database),
+		Users:                NewUserRepository(database),
+		Projects:             NewProjectRepository(database),
+		Tasks:                NewTaskRepository(database),
+		TimeRecords:          NewTimeRecordRepository(database),
+		TimeRecordVersions:   NewTimeRecordVersionRepository(database),
+		PomodoroSessions:     NewPomodoroSessionRepository(database),
+		APIKeys:              NewAPIKeyRepository(database),
+		AuditLogs:            NewAuditLogRepository(database),
+		Outbox:               NewOutboxRepository(database),
+		WebhookSubscriptions: NewWebhookSubscriptionRepository(database),
+		WebhookDeliveries:    NewWebhookDeliveryRepository(database),
+		Schema:               NewSchemaRepository(database),
+	}
+}
@@ -0,0 +1,718 @@
+// Package repositorytest is the behaviour every implementation of the repository interfaces shares.
+// The in-memory repositories run it in their unit tests, the Postgres ones in tests/integration/repository.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Run checks the Users, Projects, Tasks, TimeRecords, PomodoroSessions, Outbox and Transactor of repositories and the
+// timestamps Create fills in. The suite only works with rows of users it creates, so it can run against a database
+// other tests use as well.
+func Run(t *testing.T, repositories *repository.Repositories) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, repositories) })
+	t.Run("Projects", func(t *testing.T) { testProjects(t, repositories) })
+	t.Run("Tasks", func(t *testing.T) { testTasks(t, repositories) })
+	t.Run("TimeRecords", func(t *testing.T) { testTimeRecords(t, repositories) })
+	t.Run("PomodoroSessions", func(t *testing.T) { testPomodoroSessions(t, repositories) })
+	t.Run("Outbox", func(t *testing.T) { testOutbox(t, repositories) })
+	t.Run("Transactor", func(t *testing.T) { testTransactor(t, repositories) })
+	t.Run("Timestamps", func(t *testing.T) { testTimestamps(t, repositories) })
+}
+
+func testUsers(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	users := repositories.Users
+	user := newUser(t, repositories)
+
+	found, err := users.GetByID(ctx, user.ID.String())
+	if err != nil || found.Email != user.Email || found.Password != user.Password {
+		t.Fatalf("GetByID returned %+v, %v, want %+v", found, err, user)
+	}
+	found, err = users.GetByEmail(ctx, user.Email)
+	if err != nil || found.ID != user.ID {
+		t.Fatalf("GetByEmail returned %+v, %v, want user %s", found, err, user.ID)
+	}
+	if _, err := users.GetByEmail(ctx, "missing-"+user.Email); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByEmail of a missing email returned %v, want a record not found error", err)
+	}
+
+	taken := &model.User{ID: uuid.New(), Email: user.Email, Password: "hash", CreatedAt: now(), UpdatedAt: now()}
+	expectConflict(t, "Create with a taken email", users.Create(ctx, taken))
+
+	user.Password = "changed"
+	user.TimeZone = "Europe/Berlin"
+	user.UpdatedAt = now().Add(time.Minute)
+	if err := users.Update(ctx, user); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	found, err = users.GetByID(ctx, user.ID.String())
+	if err != nil || found.Password != "changed" || found.TimeZone != "Europe/Berlin" || !found.UpdatedAt.Equal(user.UpdatedAt) {
+		t.Fatalf("GetByID after Update returned %+v, %v", found, err)
+	}
+
+	other := newUser(t, repositories)
+	other.Email = user.Email
+	expectConflict(t, "Update to a taken email", users.Update(ctx, other))
+
+	if err := users.Delete(ctx, user); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := users.GetByID(ctx, user.ID.String()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID of a deleted user returned %v, want a record not found error", err)
+	}
+	if err := users.Delete(ctx, user); err == nil {
+		t.Fatal("Delete of a deleted user succeeded")
+	}
+}
+
+func testProjects(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	projects := repositories.Projects
+	user := newUser(t, repositories)
+	other := newUser(t, repositories)
+
+	alpha := newProject(t, repositories, user, "Alpha")
+	beta := newProject(t, repositories, user, "Beta")
+	gamma := newProject(t, repositories, user, "Gamma")
+	newProject(t, repositories, other, "Alpha")
+	if alpha.ID == 0 || alpha.ID == beta.ID {
+		t.Fatalf("Create assigned the IDs %d and %d", alpha.ID, beta.ID)
+	}
+
+	duplicate := &model.Project{Name: "Alpha", UserID: user.ID, CreatedAt: now(), UpdatedAt: now()}
+	expectConflict(t, "Create with a taken name", projects.Create(ctx, duplicate))
+
+	ownedBy := gormquery.NewFilter("user_id", "=", user.ID.String())
+	list := func(options gormquery.QueryOptions, groups ...gormquery.FilterGroup) []uint64 {
+		t.Helper()
+		found, err := projects.GetFilteredProjects(ctx, groups, options)
+		if err != nil {
+			t.Fatalf("GetFilteredProjects failed: %v", err)
+		}
+		ids := make([]uint64, 0, len(found))
+		for _, project := range found {
+			ids = append(ids, project.ID)
+		}
+		return ids
+	}
+	byID := gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "ASC"}}}
+
+	expectIDs(t, "projects of the user", list(byID, gormquery.NewFilterGroup(ownedBy)), alpha.ID, beta.ID, gamma.ID)
+	expectIDs(t, "projects by lower-cased name",
+		list(byID, gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("LOWER(name)", "=", "beta"))), beta.ID)
+	expectIDs(t, "projects by id list",
+		list(byID, gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("id", "IN", []uint64{alpha.ID, gamma.ID}))),
+		alpha.ID, gamma.ID)
+	expectIDs(t, "projects by an empty id list",
+		list(byID, gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("id", "IN", []uint64{}))))
+	expectIDs(t, "projects matching either group",
+		list(byID,
+			gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("name", "=", "Alpha")),
+			gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("name", "=", "Gamma")),
+		), alpha.ID, gamma.ID)
+	expectIDs(t, "projects by name pattern",
+		list(byID, gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("name", "ILIKE", "%A"))), alpha.ID, beta.ID, gamma.ID)
+	expectIDs(t, "second page of projects newest first",
+		list(gormquery.QueryOptions{
+			OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "DESC"}},
+			Limit:   gormquery.IntPtr(1),
+			Offset:  gormquery.IntPtr(1),
+		}, gormquery.NewFilterGroup(ownedBy)), beta.ID)
+
+	renamedAt := now().Add(time.Hour)
+	if err := projects.Update(ctx, id(alpha.ID), map[string]interface{}{"name": "Delta", "updated_at": renamedAt}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	found, err := projects.GetFilteredProjects(ctx, []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(gormquery.NewFilter("id", "=", alpha.ID)),
+	}, gormquery.QueryOptions{})
+	if err != nil || len(found) != 1 || found[0].Name != "Delta" || !found[0].UpdatedAt.Equal(renamedAt) {
+		t.Fatalf("project after Update is %+v, %v", found, err)
+	}
+	expectConflict(t, "Update to a taken name",
+		projects.Update(ctx, id(alpha.ID), map[string]interface{}{"name": "Beta"}))
+	missing := id(gamma.ID + 1_000_000)
+	if err := projects.Update(ctx, missing, map[string]interface{}{"name": "Nope"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Update of a missing project returned %v, want a record not found error", err)
+	}
+
+	task := newTask(t, repositories, user, beta, "Task of Beta")
+	if err := projects.DeleteByID(ctx, id(beta.ID)); err != nil {
+		t.Fatalf("DeleteByID failed: %v", err)
+	}
+	expectIDs(t, "projects after delete", list(byID, gormquery.NewFilterGroup(ownedBy)), alpha.ID, gamma.ID)
+	if _, err := repositories.Tasks.GetByID(ctx, taskFilter(task.ID)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("task of a deleted project returned %v, want a record not found error", err)
+	}
+	if err := projects.DeleteByID(ctx, id(beta.ID)); err == nil {
+		t.Fatal("DeleteByID of a deleted project succeeded")
+	}
+}
+
+func testTasks(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	tasks := repositories.Tasks
+	user := newUser(t, repositories)
+	other := newUser(t, repositories)
+	work := newProject(t, repositories, user, "Work")
+	home := newProject(t, repositories, user, "Home")
+
+	write := newTask(t, repositories, user, work, "Write")
+	write.Tags = []string{"docs", "urgent"}
+	write.Status = model.StatusWorkingOn
+	if err := tasks.Update(ctx, write); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	review := newTask(t, repositories, user, work, "Review")
+	homeWrite := newTask(t, repositories, user, home, "Write")
+
+	duplicate := &model.Task{
+		UserID: user.ID, ProjectID: work.ID, Name: "Write", Status: model.StatusOpened, CreatedAt: now(), UpdatedAt: now(),
+	}
+	expectConflict(t, "Create with a name taken in the project", tasks.Create(ctx, duplicate))
+
+	found, err := tasks.GetByID(ctx, taskFilter(write.ID, gormquery.NewFilter("user_id", "=", user.ID.String())))
+	if err != nil || found.Status != model.StatusWorkingOn || !slices.Equal(found.Tags, []string{"docs", "urgent"}) {
+		t.Fatalf("GetByID returned %+v, %v", found, err)
+	}
+	found.Tags[0] = "changed"
+	if again, _ := tasks.GetByID(ctx, taskFilter(write.ID)); again == nil || again.Tags[0] != "docs" {
+		t.Fatalf("changing a returned task changed the stored one: %+v", again)
+	}
+	if _, err := tasks.GetByID(ctx, taskFilter(write.ID, gormquery.NewFilter("user_id", "=", other.ID.String()))); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID of another user's task returned %v, want a record not found error", err)
+	}
+
+	list := func(groups ...gormquery.FilterGroup) []uint64 {
+		t.Helper()
+		options := &gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "ASC"}}}
+		found, err := tasks.GetFilteredTasks(ctx, groups, options)
+		if err != nil {
+			t.Fatalf("GetFilteredTasks failed: %v", err)
+		}
+		ids := make([]uint64, 0, len(found))
+		for _, task := range found {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	ownedBy := gormquery.NewFilter("user_id", "=", user.ID.String())
+	expectIDs(t, "working tasks",
+		list(gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("status", "IN", []model.TaskStatus{model.StatusWorkingOn}))),
+		write.ID)
+	expectIDs(t, "tasks of the projects",
+		list(gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("project_id", "IN", []uint64{work.ID}))),
+		write.ID, review.ID)
+	expectIDs(t, "tasks by lower-cased name",
+		list(gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("LOWER(name)", "=", "write"))),
+		write.ID, homeWrite.ID)
+	expectIDs(t, "tasks not closed",
+		list(gormquery.NewFilterGroup(ownedBy, gormquery.NewFilter("status", "!=", model.StatusClosed))),
+		write.ID, review.ID, homeWrite.ID)
+
+	review.Name = "Write"
+	expectConflict(t, "Update to a name taken in the project", tasks.Update(ctx, review))
+
+	if err := tasks.Delete(ctx, homeWrite); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := tasks.GetByID(ctx, taskFilter(homeWrite.ID)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID of a deleted task returned %v, want a record not found error", err)
+	}
+	if err := tasks.Delete(ctx, homeWrite); err == nil {
+		t.Fatal("Delete of a deleted task succeeded")
+	}
+}
+
+func testTimeRecords(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	timeRecords := repositories.TimeRecords
+	user := newUser(t, repositories)
+	project := newProject(t, repositories, user, "Timed")
+	task := newTask(t, repositories, user, project, "Timed task")
+	otherTask := newTask(t, repositories, user, project, "Other timed task")
+
+	day := now().Truncate(24 * time.Hour)
+	record := func(taskID uint64, start time.Time, duration time.Duration) *model.TimeRecord {
+		t.Helper()
+		timeRecord := &model.TimeRecord{
+			UserID: user.ID, TaskID: taskID, StartTime: start, CreatedAt: now(), UpdatedAt: now(),
+		}
+		if duration > 0 {
+			end := start.Add(duration)
+			timeRecord.EndTime = &end
+		}
+		if err := timeRecords.Create(ctx, timeRecord); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if timeRecord.ID == 0 {
+			t.Fatal("Create did not assign an ID")
+		}
+		return timeRecord
+	}
+	morning := record(task.ID, day.Add(9*time.Hour), time.Hour)
+	noon := record(otherTask.ID, day.Add(12*time.Hour), 30*time.Minute)
+	running := record(task.ID, day.Add(15*time.Hour), 0)
+
+	found, err := timeRecords.GetByID(ctx, morning.ID)
+	if err != nil || !found.StartTime.Equal(morning.StartTime) || found.EndTime == nil || !found.EndTime.Equal(*morning.EndTime) {
+		t.Fatalf("GetByID returned %+v, %v", found, err)
+	}
+	// times of any zone are stored as the same instant and read back in UTC
+	berlin := time.FixedZone("CET", 60*60)
+	zoned := &model.TimeRecord{
+		UserID:    user.ID,
+		TaskID:    otherTask.ID,
+		StartTime: day.Add(20 * time.Hour).In(berlin),
+		IsClosed:  true,
+		CreatedAt: now().In(berlin),
+		UpdatedAt: now().In(berlin),
+	}
+	zonedEnd := day.Add(21 * time.Hour).In(berlin)
+	zoned.EndTime = &zonedEnd
+	if err := timeRecords.Create(ctx, zoned); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	found, err = timeRecords.GetByID(ctx, zoned.ID)
+	if err != nil || found.StartTime.Location() != time.UTC || !found.StartTime.Equal(day.Add(20*time.Hour)) ||
+		found.EndTime.Location() != time.UTC || !found.EndTime.Equal(zonedEnd) {
+		t.Fatalf("GetByID of a time record written in CET returned %+v, %v, want the same instants in UTC", found, err)
+	}
+	if err := timeRecords.Delete(ctx, zoned); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	byTask, err := timeRecords.GetByTaskID(ctx, task.ID)
+	if err != nil || len(*byTask) != 2 {
+		t.Fatalf("GetByTaskID returned %v, %v, want 2 time records", byTask, err)
+	}
+
+	list := func(groups ...gormquery.FilterGroup) []uint64 {
+		t.Helper()
+		options := &gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "start_time", Direction: "DESC"}}}
+		found, err := timeRecords.GetFilteredTimeRecords(ctx, groups, options)
+		if err != nil {
+			t.Fatalf("GetFilteredTimeRecords failed: %v", err)
+		}
+		ids := make([]uint64, 0, len(*found))
+		for _, timeRecord := range *found {
+			ids = append(ids, timeRecord.ID)
+		}
+		return ids
+	}
+	ownedBy := gormquery.NewFilter("user_id", "=", user.ID.String())
+	expectIDs(t, "time records newest first", list(gormquery.NewFilterGroup(ownedBy)), running.ID, noon.ID, morning.ID)
+	expectIDs(t, "time records in a time range", list(gormquery.NewFilterGroup(ownedBy,
+		gormquery.NewFilter("start_time", ">=", day.Add(10*time.Hour)),
+		gormquery.NewFilter("start_time", "<", day.Add(15*time.Hour)),
+	)), noon.ID)
+	expectIDs(t, "time records of the tasks", list(gormquery.NewFilterGroup(ownedBy,
+		gormquery.NewFilter("task_id", "IN", []uint64{otherTask.ID}),
+	)), noon.ID)
+
+	active, err := timeRecords.CountActive(ctx)
+	if err != nil || active < 1 {
+		t.Fatalf("CountActive returned %d, %v, want at least the running time record", active, err)
+	}
+
+	end := day.Add(16 * time.Hour)
+	running.EndTime = &end
+	running.IsClosed = true
+	if err := timeRecords.Update(ctx, running); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	expectIDs(t, "closed time records of the task", list(gormquery.NewFilterGroup(ownedBy,
+		gormquery.NewFilter("task_id", "=", task.ID),
+		gormquery.NewFilter("is_closed", "=", true),
+	)), running.ID)
+
+	if err := timeRecords.Delete(ctx, noon); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := timeRecords.GetByID(ctx, noon.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID of a deleted time record returned %v, want a record not found error", err)
+	}
+	if err := timeRecords.Delete(ctx, noon); err == nil {
+		t.Fatal("Delete of a deleted time record succeeded")
+	}
+
+	if err := repositories.Tasks.Delete(ctx, task); err != nil {
+		t.Fatalf("Delete of the task failed: %v", err)
+	}
+	if _, err := timeRecords.GetByID(ctx, morning.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("time record of a deleted task returned %v, want a record not found error", err)
+	}
+}
+
+func testPomodoroSessions(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	sessions := repositories.PomodoroSessions
+	user := newUser(t, repositories)
+	project := newProject(t, repositories, user, "Focused")
+	task := newTask(t, repositories, user, project, "Focused task")
+
+	day := now().Truncate(24 * time.Hour)
+	timeRecord := &model.TimeRecord{
+		UserID: user.ID, TaskID: task.ID, StartTime: day.Add(9 * time.Hour), CreatedAt: now(), UpdatedAt: now(),
+	}
+	if err := repositories.TimeRecords.Create(ctx, timeRecord); err != nil {
+		t.Fatalf("Create time record failed: %v", err)
+	}
+	session := func(start time.Time, phase model.PomodoroPhase, timeRecordID *uint64) *model.PomodoroSession {
+		t.Helper()
+		pomodoroSession := &model.PomodoroSession{
+			UserID:            user.ID,
+			TaskID:            task.ID,
+			TimeRecordID:      timeRecordID,
+			Phase:             phase,
+			Status:            model.PomodoroRunning,
+			PlannedSeconds:    1500,
+			FocusSeconds:      1500,
+			ShortBreakSeconds: 300,
+			LongBreakSeconds:  900,
+			LongBreakEvery:    4,
+			StartTime:         start,
+			CreatedAt:         now(),
+			UpdatedAt:         now(),
+		}
+		if err := sessions.Create(ctx, pomodoroSession); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if pomodoroSession.ID == 0 {
+			t.Fatal("Create did not assign an ID")
+		}
+		return pomodoroSession
+	}
+	focus := session(day.Add(9*time.Hour), model.PhaseFocus, &timeRecord.ID)
+	pause := session(day.Add(9*time.Hour+25*time.Minute), model.PhaseShortBreak, nil)
+
+	list := func(filters ...gormquery.Filter) []model.PomodoroSession {
+		t.Helper()
+		filters = append([]gormquery.Filter{gormquery.NewFilter("user_id", "=", user.ID.String())}, filters...)
+		options := &gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "start_time", Direction: "ASC"}}}
+		found, err := sessions.GetFilteredSessions(ctx, []gormquery.FilterGroup{gormquery.NewFilterGroup(filters...)}, options)
+		if err != nil {
+			t.Fatalf("GetFilteredSessions failed: %v", err)
+		}
+		return found
+	}
+	ids := func(found []model.PomodoroSession) []uint64 {
+		ids := make([]uint64, 0, len(found))
+		for _, pomodoroSession := range found {
+			ids = append(ids, pomodoroSession.ID)
+		}
+		return ids
+	}
+	expectIDs(t, "sessions oldest first", ids(list()), focus.ID, pause.ID)
+	expectIDs(t, "focus sessions", ids(list(gormquery.NewFilter("phase", "=", model.PhaseFocus))), focus.ID)
+
+	end := day.Add(9*time.Hour + 25*time.Minute)
+	focus.Status = model.PomodoroCompleted
+	focus.EndTime = &end
+	if err := sessions.Update(ctx, focus); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	completed := list(gormquery.NewFilter("status", "=", model.PomodoroCompleted))
+	if len(completed) != 1 || completed[0].EndTime == nil || !completed[0].EndTime.Equal(end) {
+		t.Fatalf("completed sessions are %+v, want the updated focus session", completed)
+	}
+	running, err := sessions.CountByStatus(ctx, model.PomodoroRunning)
+	if err != nil || running < 1 {
+		t.Fatalf("CountByStatus returned %d, %v, want at least the running break", running, err)
+	}
+
+	if err := repositories.TimeRecords.Delete(ctx, timeRecord); err != nil {
+		t.Fatalf("Delete of the time record failed: %v", err)
+	}
+	if found := list(gormquery.NewFilter("id", "=", focus.ID)); len(found) != 1 || found[0].TimeRecordID != nil {
+		t.Fatalf("session of a deleted time record is %+v, want it kept without a time record", found)
+	}
+	if err := repositories.Tasks.Delete(ctx, task); err != nil {
+		t.Fatalf("Delete of the task failed: %v", err)
+	}
+	expectIDs(t, "sessions of a deleted task", ids(list()))
+}
+
+// testOutbox claims at a time long past, messages of other tests are not due then and stay untouched
+func testOutbox(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	outbox := repositories.Outbox
+	past := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	lease := past.Add(time.Minute)
+	create := func(aggregateID string) *model.OutboxMessage {
+		t.Helper()
+		message := &model.OutboxMessage{
+			AggregateType: "contract",
+			AggregateID:   aggregateID,
+			EventID:       uuid.NewString(),
+			EventType:     "project.created",
+			UserID:        uuid.New(),
+			Payload:       []byte(`{}`),
+			OccurredAt:    past,
+			NextAttemptAt: past,
+			CreatedAt:     past,
+		}
+		if err := outbox.Create(ctx, message); err != nil {
+			t.Fatalf("Create outbox message failed: %v", err)
+		}
+		return message
+	}
+	claim := func(at time.Time) []uint64 {
+		t.Helper()
+		messages, err := outbox.ClaimPending(ctx, at, lease, 100)
+		if err != nil {
+			t.Fatalf("ClaimPending failed: %v", err)
+		}
+		claimed := make([]uint64, 0, len(messages))
+		for _, message := range messages {
+			if !message.NextAttemptAt.Equal(lease) {
+				t.Fatalf("claimed message %d is due at %v, want the end of the lease %v", message.ID, message.NextAttemptAt, lease)
+			}
+			claimed = append(claimed, message.ID)
+		}
+		return claimed
+	}
+	save := func(message *model.OutboxMessage) {
+		t.Helper()
+		if err := outbox.Update(ctx, message); err != nil {
+			t.Fatalf("Update outbox message failed: %v", err)
+		}
+	}
+
+	aggregate, other := uuid.NewString(), uuid.NewString()
+	first, second, single := create(aggregate), create(aggregate), create(other)
+	duplicate := &model.OutboxMessage{EventID: first.EventID, Payload: []byte(`{}`), UserID: uuid.New()}
+	expectConflict(t, "Create with a taken event id", outbox.Create(ctx, duplicate))
+
+	expectIDs(t, "messages claimed first", claim(past), first.ID, single.ID)
+	expectIDs(t, "messages claimed during the lease", claim(past))
+
+	first.NextAttemptAt = lease
+	first.DispatchedAt = &past
+	save(first)
+	expectIDs(t, "messages claimed after the first was dispatched", claim(past), second.ID)
+	expectIDs(t, "messages claimed once the lease ran out", claim(lease), second.ID, single.ID)
+
+	second.DispatchedAt = &past
+	save(second)
+	single.FailedAt = &past
+	save(single)
+	expectIDs(t, "messages claimed after all were dispatched or failed", claim(lease.Add(time.Hour)))
+
+	deleted, err := outbox.DeleteDispatchedBefore(ctx, lease)
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteDispatchedBefore returned %d, %v, want the 2 dispatched messages deleted", deleted, err)
+	}
+	t.Cleanup(func() {
+		// the failed message is kept by DeleteDispatchedBefore
+		single.DispatchedAt = &past
+		_ = outbox.Update(ctx, single)
+		_, _ = outbox.DeleteDispatchedBefore(ctx, lease)
+	})
+}
+
+func testTransactor(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	user := newUser(t, repositories)
+	failure := errors.New("rolled back")
+
+	err := repositories.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		project := &model.Project{Name: "Rolled back", UserID: user.ID, CreatedAt: now(), UpdatedAt: now()}
+		if err := repositories.Projects.Create(ctx, project); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTransaction returned %v, want the error of fn", err)
+	}
+
+	var committed *model.Project
+	err = repositories.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		committed = &model.Project{Name: "Committed", UserID: user.ID, CreatedAt: now(), UpdatedAt: now()}
+		return repositories.Projects.Create(ctx, committed)
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction failed: %v", err)
+	}
+
+	found, err := repositories.Projects.GetFilteredProjects(ctx, []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(gormquery.NewFilter("user_id", "=", user.ID.String())),
+	}, gormquery.QueryOptions{})
+	if err != nil || len(found) != 1 || found[0].ID != committed.ID {
+		t.Fatalf("projects after the transactions are %+v, %v, want only the committed one", found, err)
+	}
+}
+
+// testTimestamps creates rows without CreatedAt and UpdatedAt, Create sets both to the current time in UTC
+// like GORM does for its autoCreateTime fields
+func testTimestamps(t *testing.T, repositories *repository.Repositories) {
+	ctx := context.Background()
+	before := time.Now()
+
+	user := &model.User{
+		ID:       uuid.New(),
+		Email:    fmt.Sprintf("contract-%s@example.com", uuid.NewString()),
+		Password: "hash",
+		TimeZone: model.DefaultTimeZone,
+	}
+	if err := repositories.Users.Create(ctx, user); err != nil {
+		t.Fatalf("Create user failed: %v", err)
+	}
+	t.Cleanup(func() { _ = repositories.Users.Delete(context.Background(), user) })
+	project := &model.Project{Name: "Stamped", UserID: user.ID}
+	if err := repositories.Projects.Create(ctx, project); err != nil {
+		t.Fatalf("Create project failed: %v", err)
+	}
+	task := &model.Task{UserID: user.ID, ProjectID: project.ID, Name: "Stamped task", Status: model.StatusOpened}
+	if err := repositories.Tasks.Create(ctx, task); err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	timeRecord := &model.TimeRecord{UserID: user.ID, TaskID: task.ID, StartTime: now()}
+	if err := repositories.TimeRecords.Create(ctx, timeRecord); err != nil {
+		t.Fatalf("Create time record failed: %v", err)
+	}
+	version := &model.TimeRecordVersion{
+		TimeRecordID: timeRecord.ID,
+		Version:      1,
+		UserID:       user.ID,
+		Operation:    model.VersionCreate,
+		TaskID:       task.ID,
+		StartTime:    timeRecord.StartTime,
+	}
+	if err := repositories.TimeRecordVersions.Create(ctx, version); err != nil {
+		t.Fatalf("Create time record version failed: %v", err)
+	}
+	session := &model.PomodoroSession{
+		UserID:         user.ID,
+		TaskID:         task.ID,
+		Phase:          model.PhaseFocus,
+		Status:         model.PomodoroRunning,
+		PlannedSeconds: 1500,
+		StartTime:      now(),
+	}
+	if err := repositories.PomodoroSessions.Create(ctx, session); err != nil {
+		t.Fatalf("Create pomodoro session failed: %v", err)
+	}
+	auditLog := &model.AuditLog{
+		UserID:     user.ID,
+		Action:     model.AuditCreate,
+		EntityType: model.AuditEntityProject,
+		EntityID:   id(project.ID),
+	}
+	if err := repositories.AuditLogs.Create(ctx, auditLog); err != nil {
+		t.Fatalf("Create audit log failed: %v", err)
+	}
+	after := time.Now()
+
+	expectFilled := func(what string, createdAt time.Time, updatedAt time.Time) {
+		t.Helper()
+		if createdAt.Location() != time.UTC || createdAt.Before(before) || createdAt.After(after) {
+			t.Fatalf("Create set the created_at of the %s to %v, want the current time in UTC", what, createdAt)
+		}
+		if !updatedAt.Equal(createdAt) {
+			t.Fatalf("Create set the updated_at of the %s to %v, want its created_at %v", what, updatedAt, createdAt)
+		}
+	}
+	expectFilled("user", user.CreatedAt, user.UpdatedAt)
+	expectFilled("project", project.CreatedAt, project.UpdatedAt)
+	expectFilled("task", task.CreatedAt, task.UpdatedAt)
+	expectFilled("time record", timeRecord.CreatedAt, timeRecord.UpdatedAt)
+	expectFilled("time record version", version.CreatedAt, version.CreatedAt)
+	expectFilled("pomodoro session", session.CreatedAt, session.UpdatedAt)
+	expectFilled("audit log", auditLog.CreatedAt, auditLog.CreatedAt)
+
+	found, err := repositories.TimeRecords.GetByID(ctx, timeRecord.ID)
+	if err != nil || found.CreatedAt.Sub(timeRecord.CreatedAt).Abs() > time.Microsecond {
+		t.Fatalf("GetByID returned %+v, %v, want the created_at %v Create set", found, err, timeRecord.CreatedAt)
+	}
+}
+
+// now is truncated to the microseconds Postgres stores
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func id(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+func newUser(t *testing.T, repositories *repository.Repositories) *model.User {
+	t.Helper()
+	user := &model.User{
+		ID:        uuid.New(),
+		Email:     fmt.Sprintf("contract-%s@example.com", uuid.NewString()),
+		Password:  "hash",
+		TimeZone:  model.DefaultTimeZone,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	if err := repositories.Users.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create user failed: %v", err)
+	}
+	t.Cleanup(func() {
+		// the user may be deleted by the test already, cascading deletes remove the rest
+		_ = repositories.Users.Delete(context.Background(), user)
+	})
+	return user
+}
+
+func newProject(t *testing.T, repositories *repository.Repositories, user *model.User, name string) *model.Project {
+	t.Helper()
+	project := &model.Project{Name: name, UserID: user.ID, CreatedAt: now(), UpdatedAt: now()}
+	if err := repositories.Projects.Create(context.Background(), project); err != nil {
+		t.Fatalf("Create project failed: %v", err)
+	}
+	return project
+}
+
+func newTask(t *testing.T, repositories *repository.Repositories, user *model.User, project *model.Project, name string) *model.Task {
+	t.Helper()
+	task := &model.Task{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      name,
+		Status:    model.StatusOpened,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	if err := repositories.Tasks.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	return task
+}
+
+func taskFilter(taskID uint64, filters ...gormquery.Filter) []gormquery.FilterGroup {
+	return []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(append([]gormquery.Filter{gormquery.NewFilter("id", "=", taskID)}, filters...)...),
+	}
+}
+
+// expectConflict checks that err is what the API reports as a conflict, on Postgres a unique violation
+func expectConflict(t *testing.T, operation string, err error) {
+	t.Helper()
+	if err == nil || apperror.From(err, nil) != apperror.ErrConflict {
+		t.Fatalf("%s returned %v, want a unique violation", operation, err)
+	}
+}
+
+func expectIDs(t *testing.T, what string, got []uint64, want ...uint64) {
+	t.Helper()
+	if !slices.Equal(got, want) {
+		t.Fatalf("%s are %v, want %v", what, got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"gorm.io/gorm"
+)
+
+type SchemaRepository interface {
+	Ping(ctx context.Context) error
+	// Version is the applied migration version, dirty when a migration failed halfway
+	Version(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+type schemaRepository struct {
+	database *gorm.DB
+}
+
+const schemaRepoErrorPrefix = "SchemaRepository"
+
+func NewSchemaRepository(database *gorm.DB) SchemaRepository {
+	return &schemaRepository{database: database}
+}
+
+func (schemaRepo *schemaRepository) Ping(ctx context.Context) error {
+	sqlDB, err := schemaRepo.database.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		err = fmt.Errorf("%s ping failed: %w", schemaRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (schemaRepo *schemaRepository) Version(ctx context.Context) (uint, bool, error) {
+	var migration struct {
+		Version uint
+		Dirty   bool
+	}
+	err := db.Session(ctx, schemaRepo.database).
+		Table(db.MigrationsTable).
+		Select("version", "dirty").
+		Take(&migration).Error
+	if err != nil {
+		err = fmt.Errorf("%s read migration version failed: %w", schemaRepoErrorPrefix, err)
+		return 0, false, err
+	}
+	return migration.Version, migration.Dirty, nil
+}
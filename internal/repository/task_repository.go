@@ -28,12 +28,12 @@ type taskRepository struct {
 
 const taskRepoErrorPrefix = "TaskRepository"
 
-func NewTaskRepository() TaskRepository {
-	return &taskRepository{database: db.Get()}
+func NewTaskRepository(database *gorm.DB) TaskRepository {
+	return &taskRepository{database: database}
 }
 
 func (taskRepo *taskRepository) Create(ctx context.Context, task *model.Task) error {
-	err := taskRepo.database.WithContext(ctx).Create(task).Error
+	err := db.Session(ctx, taskRepo.database).Create(task).Error
 	if err != nil {
 		err = fmt.Errorf("%s create task failed: %w", taskRepoErrorPrefix, err)
 	}
@@ -49,7 +49,7 @@ func (taskRepo *taskRepository) GetByID(ctx context.Context, filters []gormquery
 	//	),
 	//}
 	var task model.Task
-	query := taskRepo.database.WithContext(ctx).Model(&model.Task{})
+	query := db.Session(ctx, taskRepo.database).Model(&model.Task{})
 	query = gormquery.ApplyFilters(query, filters)
 	if err := query.First(&task).Error; err != nil {
 		err = fmt.Errorf("%s find task by id failed: %w", taskRepoErrorPrefix, err)
@@ -65,7 +65,7 @@ func (taskRepo *taskRepository) GetFilteredTasks(
 ) ([]model.Task, error) {
 	var tasks []model.Task
 
-	query := taskRepo.database.WithContext(ctx).Model(&model.Task{})
+	query := db.Session(ctx, taskRepo.database).Model(&model.Task{})
 	query = gormquery.ApplyFilters(query, filters)
 
 	if options != nil {
@@ -80,7 +80,7 @@ func (taskRepo *taskRepository) GetFilteredTasks(
 }
 
 func (taskRepo *taskRepository) Update(ctx context.Context, task *model.Task) error {
-	err := taskRepo.database.WithContext(ctx).Save(task).Error
+	err := db.Session(ctx, taskRepo.database).Save(task).Error
 	if err != nil {
 		err = fmt.Errorf("%s update task failed: %w", taskRepoErrorPrefix, err)
 	}
@@ -88,7 +88,7 @@ func (taskRepo *taskRepository) Update(ctx context.Context, task *model.Task) er
 }
 
 func (taskRepo *taskRepository) Delete(ctx context.Context, task *model.Task) error {
-	result := taskRepo.database.WithContext(ctx).Where("id = ?", task.ID).Delete(&model.Task{})
+	result := db.Session(ctx, taskRepo.database).Where("id = ?", task.ID).Delete(&model.Task{})
 	if result.Error != nil {
 		return fmt.Errorf("%s delete task failed: %w", taskRepoErrorPrefix, result.Error)
 	}
@@ -21,6 +21,7 @@ type TimeRecordRepository interface {
 	) (*[]model.TimeRecord, error)
 	Update(ctx context.Context, timeRecord *model.TimeRecord) error
 	Delete(ctx context.Context, timeRecord *model.TimeRecord) error
+	CountActive(ctx context.Context) (int64, error)
 }
 
 type timeRecordRepository struct {
@@ -29,12 +30,12 @@ type timeRecordRepository struct {
 
 const timeRecordRepoErrorPrefix = "TimeRecordRepository"
 
-func NewTimeRecordRepository() TimeRecordRepository {
-	return &timeRecordRepository{database: db.Get()}
+func NewTimeRecordRepository(database *gorm.DB) TimeRecordRepository {
+	return &timeRecordRepository{database: database}
 }
 
 func (timeRecordRepo *timeRecordRepository) Create(ctx context.Context, timeRecord *model.TimeRecord) error {
-	err := timeRecordRepo.database.WithContext(ctx).Create(timeRecord).Error
+	err := db.Session(ctx, timeRecordRepo.database).Create(timeRecord).Error
 	if err != nil {
 		err = fmt.Errorf("%s create time record failed: %w", timeRecordRepoErrorPrefix, err)
 	}
@@ -48,7 +49,7 @@ func (timeRecordRepo *timeRecordRepository) GetByID(ctx context.Context, id uint
 			gormquery.NewFilter("id", "=", id),
 		),
 	}
-	query := timeRecordRepo.database.WithContext(ctx).Model(&model.TimeRecord{})
+	query := db.Session(ctx, timeRecordRepo.database).Model(&model.TimeRecord{})
 	query = gormquery.ApplyFilters(query, filters)
 	if err := query.First(&timeRecord).Error; err != nil {
 		return nil, fmt.Errorf("%s find time record by id failed: %w", timeRecordRepoErrorPrefix, err)
@@ -63,7 +64,7 @@ func (timeRecordRepo *timeRecordRepository) GetByTaskID(ctx context.Context, tas
 			gormquery.NewFilter("task_id", "=", taskID),
 		),
 	}
-	query := timeRecordRepo.database.WithContext(ctx).Model(&model.TimeRecord{})
+	query := db.Session(ctx, timeRecordRepo.database).Model(&model.TimeRecord{})
 	query = gormquery.ApplyFilters(query, filters)
 	if err := query.Find(&timeRecords).Error; err != nil {
 		err = fmt.Errorf("%s find time records by task id failed: %w", timeRecordRepoErrorPrefix, err)
@@ -80,7 +81,7 @@ func (timeRecordRepo *timeRecordRepository) GetFilteredTimeRecords(
 ) (*[]model.TimeRecord, error) {
 	var timeRecords []model.TimeRecord
 
-	query := timeRecordRepo.database.WithContext(ctx).Model(&model.TimeRecord{})
+	query := db.Session(ctx, timeRecordRepo.database).Model(&model.TimeRecord{})
 	query = gormquery.ApplyFilters(query, filters)
 	if options != nil {
 		query = gormquery.ApplyQueryOptions(query, *options)
@@ -93,7 +94,7 @@ func (timeRecordRepo *timeRecordRepository) GetFilteredTimeRecords(
 }
 
 func (timeRecordRepo *timeRecordRepository) Update(ctx context.Context, timeRecord *model.TimeRecord) error {
-	err := timeRecordRepo.database.WithContext(ctx).Save(timeRecord).Error
+	err := db.Session(ctx, timeRecordRepo.database).Save(timeRecord).Error
 	if err != nil {
 		err = fmt.Errorf("%s update time record failed: %w", timeRecordRepoErrorPrefix, err)
 	}
@@ -101,8 +102,7 @@ func (timeRecordRepo *timeRecordRepository) Update(ctx context.Context, timeReco
 }
 
 func (timeRecordRepo *timeRecordRepository) Delete(ctx context.Context, timeRecord *model.TimeRecord) error {
-	result := timeRecordRepo.database.
-		WithContext(ctx).
+	result := db.Session(ctx, timeRecordRepo.database).
 		Where("id = ?", timeRecord.ID).
 		Delete(&model.TimeRecord{})
 	if result.Error != nil {
@@ -118,3 +118,16 @@ func (timeRecordRepo *timeRecordRepository) Delete(ctx context.Context, timeReco
 	}
 	return nil
 }
+
+// CountActive counts the time records of all users that are not stopped yet
+func (timeRecordRepo *timeRecordRepository) CountActive(ctx context.Context) (int64, error) {
+	var count int64
+	err := db.Session(ctx, timeRecordRepo.database).
+		Model(&model.TimeRecord{}).
+		Where("end_time IS NULL").
+		Count(&count).Error
+	if err != nil {
+		err = fmt.Errorf("%s count active time records failed: %w", timeRecordRepoErrorPrefix, err)
+	}
+	return count, err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gorm.io/gorm"
+)
+
+type TimeRecordVersionRepository interface {
+	Create(ctx context.Context, version *model.TimeRecordVersion) error
+	GetFilteredVersions(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.TimeRecordVersion, error)
+	GetLatestVersionNumber(ctx context.Context, timeRecordID uint64) (int, error)
+}
+
+type timeRecordVersionRepository struct {
+	database *gorm.DB
+}
+
+const timeRecordVersionRepoErrorPrefix = "TimeRecordVersionRepository"
+
+func NewTimeRecordVersionRepository(database *gorm.DB) TimeRecordVersionRepository {
+	return &timeRecordVersionRepository{database: database}
+}
+
+func (versionRepo *timeRecordVersionRepository) Create(ctx context.Context, version *model.TimeRecordVersion) error {
+	err := db.Session(ctx, versionRepo.database).Create(version).Error
+	if err != nil {
+		err = fmt.Errorf("%s create time record version failed: %w", timeRecordVersionRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (versionRepo *timeRecordVersionRepository) GetFilteredVersions(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.TimeRecordVersion, error) {
+	var versions []model.TimeRecordVersion
+
+	query := db.Session(ctx, versionRepo.database).Model(&model.TimeRecordVersion{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&versions).Error; err != nil {
+		err = fmt.Errorf("%s find filtered time record versions failed: %w", timeRecordVersionRepoErrorPrefix, err)
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetLatestVersionNumber returns 0 when the time record has no versions yet
+func (versionRepo *timeRecordVersionRepository) GetLatestVersionNumber(ctx context.Context, timeRecordID uint64) (int, error) {
+	var latest int
+	err := db.Session(ctx, versionRepo.database).
+		Model(&model.TimeRecordVersion{}).
+		Where("time_record_id = ?", timeRecordID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		err = fmt.Errorf("%s find latest time record version failed: %w", timeRecordVersionRepoErrorPrefix, err)
+	}
+	return latest, err
+}
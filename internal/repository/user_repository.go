@@ -21,23 +21,23 @@ type userRepository struct {
 	db *gorm.DB
 }
 
-func NewUserRepository() UserRepository {
-	return &userRepository{db: db.Get()}
+func NewUserRepository(database *gorm.DB) UserRepository {
+	return &userRepository{db: database}
 }
 
 func (repository *userRepository) Create(ctx context.Context, user *model.User) error {
-	err := repository.db.WithContext(ctx).Create(user).Error
+	err := db.Session(ctx, repository.db).Create(user).Error
 	if err != nil {
-		err = errors.Errorf("create user failed: %v", err)
+		err = errors.Errorf("create user failed: %w", err)
 	}
 	return err
 }
 
 func (repository *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	err := repository.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := db.Session(ctx, repository.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
-		err = errors.Errorf("get user by email failed: %v", err)
+		err = errors.Errorf("get user by email failed: %w", err)
 		return nil, err
 	}
 	return &user, nil
@@ -45,26 +45,26 @@ func (repository *userRepository) GetByEmail(ctx context.Context, email string)
 
 func (repository *userRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
 	var user model.User
-	err := repository.db.WithContext(ctx).First(&user, "id = ?", id).Error
+	err := db.Session(ctx, repository.db).First(&user, "id = ?", id).Error
 	if err != nil {
-		err = errors.Errorf("get user by id failed: %v", err)
+		err = errors.Errorf("get user by id failed: %w", err)
 		return nil, err
 	}
 	return &user, nil
 }
 
 func (repository *userRepository) Update(ctx context.Context, user *model.User) error {
-	err := repository.db.WithContext(ctx).Save(user).Error
+	err := db.Session(ctx, repository.db).Save(user).Error
 	if err != nil {
-		err = errors.Errorf("update user failed: %v", err)
+		err = errors.Errorf("update user failed: %w", err)
 	}
 	return err
 }
 
 func (repository *userRepository) Delete(ctx context.Context, user *model.User) error {
-	result := repository.db.WithContext(ctx).Delete(user)
+	result := db.Session(ctx, repository.db).Delete(user)
 	if result.Error != nil {
-		return errors.Errorf("delete user failed: %v", result.Error)
+		return errors.Errorf("delete user failed: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
 		return errors.New(
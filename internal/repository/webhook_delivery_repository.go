@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *model.WebhookDelivery) error
+	GetByID(ctx context.Context, filters []gormquery.FilterGroup) (*model.WebhookDelivery, error)
+	GetFilteredDeliveries(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.WebhookDelivery, error)
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]model.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+type webhookDeliveryRepository struct {
+	database *gorm.DB
+}
+
+const webhookDeliveryRepoErrorPrefix = "WebhookDeliveryRepository"
+
+func NewWebhookDeliveryRepository(database *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{database: database}
+}
+
+func (deliveryRepo *webhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	err := db.Session(ctx, deliveryRepo.database).Create(delivery).Error
+	if err != nil {
+		err = fmt.Errorf("%s create webhook delivery failed: %w", webhookDeliveryRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (deliveryRepo *webhookDeliveryRepository) GetByID(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	query := db.Session(ctx, deliveryRepo.database).Model(&model.WebhookDelivery{})
+	query = gormquery.ApplyFilters(query, filters)
+	if err := query.First(&delivery).Error; err != nil {
+		err = fmt.Errorf("%s find webhook delivery by id failed: %w", webhookDeliveryRepoErrorPrefix, err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (deliveryRepo *webhookDeliveryRepository) GetFilteredDeliveries(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+
+	query := db.Session(ctx, deliveryRepo.database).Model(&model.WebhookDelivery{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		err = fmt.Errorf("%s find filtered webhook deliveries failed: %w", webhookDeliveryRepoErrorPrefix, err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// claimDueSQL moves the next attempt of due deliveries to the end of the lease, other dispatchers skip them
+// until it runs out
+const claimDueSQL = `
+UPDATE webhook_deliveries SET next_attempt_at = @lease_until
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = @pending AND next_attempt_at <= @now
+    ORDER BY next_attempt_at, id
+    LIMIT @limit
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimDue leases up to limit pending deliveries that are due until leaseUntil and returns them, the longest
+// waiting first. A delivery that neither succeeds nor gets its next attempt scheduled by then is claimed again.
+func (deliveryRepo *webhookDeliveryRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := db.Session(ctx, deliveryRepo.database).
+		Raw(
+			claimDueSQL,
+			sql.Named("pending", model.DeliveryPending),
+			sql.Named("now", now),
+			sql.Named("lease_until", leaseUntil),
+			sql.Named("limit", limit),
+		).
+		Scan(&deliveries).Error
+	if err != nil {
+		err = fmt.Errorf("%s claim due webhook deliveries failed: %w", webhookDeliveryRepoErrorPrefix, err)
+		return nil, err
+	}
+	// RETURNING does not keep the order of the subquery and the claimed rows share next_attempt_at now
+	slices.SortFunc(deliveries, func(a, b model.WebhookDelivery) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return deliveries, nil
+}
+
+func (deliveryRepo *webhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) error {
+	err := db.Session(ctx, deliveryRepo.database).Save(delivery).Error
+	if err != nil {
+		err = fmt.Errorf("%s update webhook delivery failed: %w", webhookDeliveryRepoErrorPrefix, err)
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"gitlab.com/tozd/go/errors"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *model.WebhookSubscription) error
+	GetByID(ctx context.Context, filters []gormquery.FilterGroup) (*model.WebhookSubscription, error)
+	GetFilteredSubscriptions(
+		ctx context.Context,
+		filters []gormquery.FilterGroup,
+		options *gormquery.QueryOptions,
+	) ([]model.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *model.WebhookSubscription) error
+	Delete(ctx context.Context, subscription *model.WebhookSubscription) error
+}
+
+type webhookSubscriptionRepository struct {
+	database *gorm.DB
+}
+
+const webhookSubscriptionRepoErrorPrefix = "WebhookSubscriptionRepository"
+
+func NewWebhookSubscriptionRepository(database *gorm.DB) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{database: database}
+}
+
+func (subscriptionRepo *webhookSubscriptionRepository) Create(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+) error {
+	err := db.Session(ctx, subscriptionRepo.database).Create(subscription).Error
+	if err != nil {
+		err = fmt.Errorf("%s create webhook subscription failed: %w", webhookSubscriptionRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (subscriptionRepo *webhookSubscriptionRepository) GetByID(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+) (*model.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+	query := db.Session(ctx, subscriptionRepo.database).Model(&model.WebhookSubscription{})
+	query = gormquery.ApplyFilters(query, filters)
+	if err := query.First(&subscription).Error; err != nil {
+		err = fmt.Errorf("%s find webhook subscription by id failed: %w", webhookSubscriptionRepoErrorPrefix, err)
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (subscriptionRepo *webhookSubscriptionRepository) GetFilteredSubscriptions(
+	ctx context.Context,
+	filters []gormquery.FilterGroup,
+	options *gormquery.QueryOptions,
+) ([]model.WebhookSubscription, error) {
+	var subscriptions []model.WebhookSubscription
+
+	query := db.Session(ctx, subscriptionRepo.database).Model(&model.WebhookSubscription{})
+	query = gormquery.ApplyFilters(query, filters)
+	if options != nil {
+		query = gormquery.ApplyQueryOptions(query, *options)
+	}
+
+	if err := query.Find(&subscriptions).Error; err != nil {
+		err = fmt.Errorf("%s find filtered webhook subscriptions failed: %w", webhookSubscriptionRepoErrorPrefix, err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (subscriptionRepo *webhookSubscriptionRepository) Update(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+) error {
+	err := db.Session(ctx, subscriptionRepo.database).Save(subscription).Error
+	if err != nil {
+		err = fmt.Errorf("%s update webhook subscription failed: %w", webhookSubscriptionRepoErrorPrefix, err)
+	}
+	return err
+}
+
+func (subscriptionRepo *webhookSubscriptionRepository) Delete(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+) error {
+	result := db.Session(ctx, subscriptionRepo.database).
+		Where("id = ?", subscription.ID).
+		Delete(&model.WebhookSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("%s delete webhook subscription failed: %w", webhookSubscriptionRepoErrorPrefix, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(
+			fmt.Sprintf(
+				"%s delete webhook subscription failed: subscription you try to delete does not exist",
+				webhookSubscriptionRepoErrorPrefix,
+			),
+		)
+	}
+	return nil
+}
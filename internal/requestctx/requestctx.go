@@ -0,0 +1,31 @@
+package requestctx
+
+import "context"
+
+const RequestIDHeader = "X-Request-ID"
+
+// Metadata describes the HTTP request a service call belongs to
+type Metadata struct {
+	RequestID string
+	ClientIP  string
+	ActorID   string
+}
+
+type metadataKey struct{}
+
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// FromContext returns the request metadata, it is empty for background jobs
+func FromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(metadataKey{}).(Metadata)
+	return metadata
+}
+
+// WithActor sets the user performing the request
+func WithActor(ctx context.Context, actorID string) context.Context {
+	metadata := FromContext(ctx)
+	metadata.ActorID = actorID
+	return WithMetadata(ctx, metadata)
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupAdminRoutes(engine *gin.Engine, container *app.Container) {
+	logLevelHandler := handler.NewLogLevelHandler()
+	admin := engine.Group("/admin", middleware.AdminRequired(container.Config.Auth.AdminToken))
+	{
+		admin.GET("/log-level", logLevelHandler.Get)
+		admin.PUT("/log-level", logLevelHandler.Set)
+	}
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupAPIKeyRoutes(engine *gin.Engine, container *app.Container) {
+	apiKeyHandler := handler.NewAPIKeyHandler(container.Services.APIKey)
+	apiKeys := engine.Group("/api/api-keys", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		apiKeys.POST("/create", apiKeyHandler.Create)
+		apiKeys.GET("/list", apiKeyHandler.List)
+		apiKeys.DELETE("/delete/:id", apiKeyHandler.Delete)
+	}
+}
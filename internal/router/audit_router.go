@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupAuditRoutes(engine *gin.Engine, container *app.Container) {
+	auditHandler := handler.NewAuditHandler(container.Services.Audit)
+	audit := engine.Group("/api/audit", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		audit.GET("/list", auditHandler.List)
+	}
+}
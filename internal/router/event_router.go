@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupEventRoutes(engine *gin.Engine, container *app.Container) {
+	eventHandler := handler.NewEventHandler(container.Bus, container.Config.Server.WSAllowedOrigins)
+	events := engine.Group("/api/events", middleware.StreamAuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		events.GET("/stream", eventHandler.Stream)
+		events.GET("/ws", eventHandler.WebSocket)
+	}
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/graphqlapi"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupGraphQLRoutes(engine *gin.Engine, container *app.Container) {
+	graphQLHandler := handler.NewGraphQLHandler(&graphqlapi.Services{
+		User:       container.Services.User,
+		Project:    container.Services.Project,
+		Task:       container.Services.Task,
+		TimeRecord: container.Services.TimeRecord,
+	}, container.Clock, container.Config.Features)
+	engine.POST("/api/graphql", middleware.AuthRequired(container.Tokens, container.Services.APIKey), graphQLHandler.Query)
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// setupHealthRoutes mounts the probes and the build information, they are public and
+// successful probes are not logged, see middleware.RequestLogger
+func setupHealthRoutes(engine *gin.Engine, container *app.Container) {
+	healthHandler := handler.NewHealthHandler(container.Services.Health)
+	engine.GET("/healthz", healthHandler.Health)
+	engine.GET("/readyz", healthHandler.Ready)
+	engine.GET("/version", healthHandler.Version)
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/gin-gonic/gin"
+)
+
+// setupMetricsRoutes serves the metrics on the API port unless a separate metrics port is configured
+func setupMetricsRoutes(engine *gin.Engine, container *app.Container) {
+	if container.Config.Server.MetricsPort != "" {
+		return
+	}
+	engine.GET("/metrics", gin.WrapH(container.Metrics.Handler()))
+}
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+func setupOpenAPIRoutes(engine *gin.Engine) {
+	openAPIHandler := handler.NewOpenAPIHandler()
+	engine.GET("/api/openapi.json", openAPIHandler.Spec)
+	engine.GET("/api/docs", openAPIHandler.UI)
+}
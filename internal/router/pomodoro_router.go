@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupPomodoroRoutes(engine *gin.Engine, container *app.Container) {
+	pomodoroHandler := handler.NewPomodoroHandler(container.Services.Pomodoro)
+	pomodoro := engine.Group("/api/pomodoro", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		pomodoro.POST("/start/:id", pomodoroHandler.Start)
+		pomodoro.POST("/stop", pomodoroHandler.Stop)
+		pomodoro.GET("/state", pomodoroHandler.State)
+		pomodoro.GET("/stats", pomodoroHandler.Stats)
+	}
+}
@@ -1,14 +1,15 @@
 package router
 
 import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
 	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
 	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func setupProjectRoutes(engine *gin.Engine) {
-	projectHandler := handler.NewProjectHandler()
-	projects := engine.Group("/api/projects", middleware.AuthRequired())
+func setupProjectRoutes(engine *gin.Engine, container *app.Container) {
+	projectHandler := handler.NewProjectHandler(container.Services.Project)
+	projects := engine.Group("/api/projects", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
 	{
 		projects.POST("/create", projectHandler.Create)
 		projects.GET("/list", projectHandler.List)
@@ -1,16 +1,61 @@
 package router
 
 import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(engine *gin.Engine) {
+// SetupRoutes mounts the REST API, its handlers call the services of container
+func SetupRoutes(engine *gin.Engine, container *app.Container) {
+	engine.Use(
+		middleware.Metrics(container.Metrics),
+		middleware.Tracing(),
+		middleware.RequestContext(),
+		middleware.RequestLogger(container.Logger),
+		middleware.ErrorHandler(),
+		middleware.Recovery(),
+	)
+
 	// User API
-	setupUserRoutes(engine)
+	setupUserRoutes(engine, container)
 
 	// Project API
-	setupProjectRoutes(engine)
+	setupProjectRoutes(engine, container)
 
 	// Task API
-	setupTaskRoutes(engine)
+	setupTaskRoutes(engine, container)
+
+	// Time record API
+	setupTimeRecordRoutes(engine, container)
+
+	// Pomodoro API
+	setupPomodoroRoutes(engine, container)
+
+	// Live events API
+	setupEventRoutes(engine, container)
+
+	// Webhooks API
+	setupWebhookRoutes(engine, container)
+
+	// Audit log API
+	setupAuditRoutes(engine, container)
+
+	// API keys API
+	setupAPIKeyRoutes(engine, container)
+
+	// GraphQL API
+	setupGraphQLRoutes(engine, container)
+
+	// OpenAPI document and interactive documentation
+	setupOpenAPIRoutes(engine)
+
+	// Prometheus metrics
+	setupMetricsRoutes(engine, container)
+
+	// Probes and build information
+	setupHealthRoutes(engine, container)
+
+	// Admin API
+	setupAdminRoutes(engine, container)
 }
@@ -1,14 +1,15 @@
 package router
 
 import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
 	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
 	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func setupTaskRoutes(engine *gin.Engine) {
-	taskHandler := handler.NewTaskHandler()
-	tasks := engine.Group("/api/tasks", middleware.AuthRequired())
+func setupTaskRoutes(engine *gin.Engine, container *app.Container) {
+	taskHandler := handler.NewTaskHandler(container.Services.Task)
+	tasks := engine.Group("/api/tasks", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
 	{
 		tasks.POST("/create", taskHandler.Create)
 		tasks.GET("/list-all", taskHandler.ListAll)
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupTimeRecordRoutes(engine *gin.Engine, container *app.Container) {
+	timeRecordHandler := handler.NewTimeRecordHandler(container.Services.Task, container.Services.TimeRecord)
+	timeRecords := engine.Group("/api/time-records", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		timeRecords.POST("/create", timeRecordHandler.Create)
+		timeRecords.GET("/list", timeRecordHandler.List)
+		timeRecords.GET("/detail/:id", timeRecordHandler.GetByID)
+		timeRecords.PATCH("/update/:id", timeRecordHandler.Update)
+		timeRecords.DELETE("/delete/:id", timeRecordHandler.Delete)
+		timeRecords.GET("/history/:id", timeRecordHandler.History)
+		timeRecords.POST("/restore/:id", timeRecordHandler.Restore)
+		timeRecords.POST("/undo", timeRecordHandler.Undo)
+	}
+}
@@ -1,19 +1,22 @@
 package router
 
 import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
 	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
 	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func setupUserRoutes(engine *gin.Engine) {
-	userHandler := handler.NewUserHandler()
+func setupUserRoutes(engine *gin.Engine, container *app.Container) {
+	userHandler := handler.NewUserHandler(container.Services.User, container.Tokens)
+	authRequired := middleware.AuthRequired(container.Tokens, container.Services.APIKey)
 	user := engine.Group("/api/user")
 	{
 		user.POST("/signup", userHandler.Signup)
 		user.POST("/signin", userHandler.Signin)
-		user.GET("/profile", middleware.AuthRequired(), userHandler.Profile)
-		user.DELETE("/delete", middleware.AuthRequired(), userHandler.DeleteCurrentUser)
-		user.PATCH("/change-password", middleware.AuthRequired(), userHandler.ChangePassword)
+		user.GET("/profile", authRequired, userHandler.Profile)
+		user.DELETE("/delete", authRequired, userHandler.DeleteCurrentUser)
+		user.PATCH("/change-password", authRequired, userHandler.ChangePassword)
+		user.PATCH("/settings", authRequired, userHandler.UpdateSettings)
 	}
 }
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/handler"
+	"github.com/advanced-coder-com/go-timekeeper/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func setupWebhookRoutes(engine *gin.Engine, container *app.Container) {
+	webhookHandler := handler.NewWebhookHandler(container.Services.Webhook)
+	webhooks := engine.Group("/api/webhooks", middleware.AuthRequired(container.Tokens, container.Services.APIKey))
+	{
+		webhooks.POST("/create", webhookHandler.Create)
+		webhooks.GET("/list", webhookHandler.List)
+		webhooks.GET("/detail/:id", webhookHandler.GetByID)
+		webhooks.PATCH("/update/:id", webhookHandler.Update)
+		webhooks.DELETE("/delete/:id", webhookHandler.Delete)
+		webhooks.GET("/deliveries/:id", webhookHandler.Deliveries)
+		webhooks.POST("/redeliver/:id", webhookHandler.Redeliver)
+	}
+}
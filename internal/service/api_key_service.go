@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyCreateFailed = apperror.Internal(apperror.CodeInternal, "failed to create api key")
+	ErrAPIKeyDeleteFailed = apperror.Internal(apperror.CodeInternal, "failed to delete api key")
+	ErrAPIKeyGetFailed    = apperror.Internal(apperror.CodeInternal, "failed to get api key(s)")
+	ErrAPIKeyInvalidInput = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrAPIKeyInvalid      = apperror.Unauthorized("invalid_api_key", "invalid api key")
+)
+
+// APIKeyHeader is the request header carrying an API key instead of the Authorization header
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyInput struct {
+	Name string `json:"name" normalize:"trim" validate:"required,max=100,name"`
+}
+
+// CreatedAPIKey is returned once on creation, it is the only response exposing the key
+type CreatedAPIKey struct {
+	model.APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyService struct {
+	repo  repository.APIKeyRepository
+	clock clock.Clock
+}
+
+const (
+	apiKeyServiceLogPrefix = "APIKeyService"
+	apiKeyPrefix           = "tk_"
+	apiKeyBytes            = 32
+	apiKeyDisplayLength    = 8
+	// last_used_at is refreshed at most once per interval to keep authentication read only
+	apiKeyTouchInterval = time.Minute
+)
+
+func NewAPIKeyService(repositories *repository.Repositories, clock clock.Clock) *APIKeyService {
+	return &APIKeyService{
+		repo:  repositories.APIKeys,
+		clock: clock,
+	}
+}
+
+func (apiKeyService *APIKeyService) Create(ctx context.Context, userID string, input APIKeyInput) (*CreatedAPIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Create")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &model.APIKey{
+		UserID:    uuid.MustParse(userID),
+		Name:      input.Name,
+		Prefix:    key[:len(apiKeyPrefix)+apiKeyDisplayLength],
+		KeyHash:   hashAPIKey(key),
+		CreatedAt: apiKeyService.clock.Now(),
+	}
+	if err := apiKeyService.repo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: *apiKey, Key: key}, nil
+}
+
+func (apiKeyService *APIKeyService) GetAllByUser(ctx context.Context, userID string) ([]model.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.GetAllByUser")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "ASC"}},
+	}
+	return apiKeyService.repo.GetFilteredAPIKeys(ctx, filters, options)
+}
+
+func (apiKeyService *APIKeyService) Delete(ctx context.Context, id uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Delete")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("id", "=", id),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	apiKey, err := apiKeyService.repo.GetByID(ctx, filters)
+	if err != nil {
+		return err
+	}
+	return apiKeyService.repo.Delete(ctx, apiKey)
+}
+
+// Authenticate resolves a key to the id of its owner
+func (apiKeyService *APIKeyService) Authenticate(ctx context.Context, key string) (string, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", fmt.Errorf("%s: %w", apiKeyServiceLogPrefix, ErrAPIKeyInvalid)
+	}
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("key_hash", "=", hashAPIKey(key)),
+		),
+	}
+	apiKey, err := apiKeyService.repo.GetByID(ctx, filters)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %w", apiKeyServiceLogPrefix, ErrAPIKeyInvalid, err)
+	}
+
+	now := apiKeyService.clock.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := apiKeyService.repo.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			return "", err
+		}
+	}
+	return apiKey.UserID.String(), nil
+}
+
+func generateAPIKey() (string, error) {
+	key := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("%s generate key failed: %w", apiKeyServiceLogPrefix, err)
+	}
+	return apiKeyPrefix + hex.EncodeToString(key), nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuditGetFailed    = apperror.Internal(apperror.CodeInternal, "failed to get audit log")
+	ErrAuditInvalidInput = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+)
+
+const (
+	auditServiceLogPrefix = "AuditService"
+	defaultAuditLogLimit  = 100
+	maxAuditLogLimit      = 1000
+)
+
+// AuditFilter narrows the audit log, empty fields are not applied
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+// AuditChange is the value of one top-level field before and after a mutation
+type AuditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type AuditService struct {
+	repo repository.AuditLogRepository
+}
+
+func NewAuditService(repositories *repository.Repositories) *AuditService {
+	return &AuditService{repo: repositories.AuditLogs}
+}
+
+// List returns audit logs of the user's entities, newest first
+func (auditService *AuditService) List(ctx context.Context, userID string, filter AuditFilter) ([]model.AuditLog, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.List")
+	defer span.End()
+
+	conditions := []gormquery.Filter{gormquery.NewFilter("user_id", "=", userID)}
+	if filter.EntityType != "" {
+		if !model.IsValidAuditEntityType(filter.EntityType) {
+			return nil, fmt.Errorf("%s unknown entity type %q: %w", auditServiceLogPrefix, filter.EntityType, ErrAuditInvalidInput)
+		}
+		conditions = append(conditions, gormquery.NewFilter("entity_type", "=", filter.EntityType))
+	}
+	if filter.EntityID != "" {
+		conditions = append(conditions, gormquery.NewFilter("entity_id", "=", filter.EntityID))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, gormquery.NewFilter("created_at", ">=", *filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, gormquery.NewFilter("created_at", "<", *filter.To))
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%s from must be before to: %w", auditServiceLogPrefix, ErrAuditInvalidInput)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "DESC"}},
+		Limit:   gormquery.IntPtr(limit),
+	}
+	return auditService.repo.GetFilteredAuditLogs(ctx, []gormquery.FilterGroup{gormquery.NewFilterGroup(conditions...)}, options)
+}
+
+// auditRecorder writes audit logs, services call it inside the transaction of the mutation
+type auditRecorder struct {
+	repo  repository.AuditLogRepository
+	clock clock.Clock
+}
+
+func newAuditRecorder(repositories *repository.Repositories, clock clock.Clock) *auditRecorder {
+	return &auditRecorder{repo: repositories.AuditLogs, clock: clock}
+}
+
+// record stores a mutation of an entity owned by ownerID. before is nil for creates, after is nil for deletes.
+// The actor, request ID and IP are taken from the request context.
+func (recorder *auditRecorder) record(
+	ctx context.Context,
+	action model.AuditAction,
+	entityType string,
+	entityID any,
+	ownerID uuid.UUID,
+	before any,
+	after any,
+) error {
+	beforeJSON, beforeFields, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, afterFields, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+	changes, err := json.Marshal(auditDiff(beforeFields, afterFields))
+	if err != nil {
+		return fmt.Errorf("%s marshal changes failed: %w", auditServiceLogPrefix, err)
+	}
+
+	metadata := requestctx.FromContext(ctx)
+	auditLog := &model.AuditLog{
+		UserID:     ownerID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Changes:    changes,
+		RequestID:  metadata.RequestID,
+		IP:         metadata.ClientIP,
+		CreatedAt:  recorder.clock.Now(),
+	}
+	if actorID, err := uuid.Parse(metadata.ActorID); err == nil {
+		auditLog.ActorID = &actorID
+	}
+	return recorder.repo.Create(ctx, auditLog)
+}
+
+// auditSnapshot returns the JSON form of an entity and its top-level fields, nil entities have no snapshot
+func auditSnapshot(entity any) (json.RawMessage, map[string]any, error) {
+	if entity == nil {
+		return nil, nil, nil
+	}
+	snapshot, err := json.Marshal(entity)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s marshal snapshot failed: %w", auditServiceLogPrefix, err)
+	}
+	if bytes.Equal(snapshot, []byte("null")) {
+		return nil, nil, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(snapshot, &fields); err != nil {
+		return nil, nil, fmt.Errorf("%s decode snapshot failed: %w", auditServiceLogPrefix, err)
+	}
+	return snapshot, fields, nil
+}
+
+// auditDiff lists the top-level fields whose values differ between the two snapshots
+func auditDiff(before map[string]any, after map[string]any) map[string]AuditChange {
+	changes := make(map[string]AuditChange)
+	for field, from := range before {
+		to, ok := after[field]
+		if !ok || !reflect.DeepEqual(from, to) {
+			changes[field] = AuditChange{From: from, To: to}
+		}
+	}
+	for field, to := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = AuditChange{From: nil, To: to}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+// Readiness results, the checks only report a short public state, the cause of a failure is logged
+const (
+	ReadinessReady    = "ready"
+	ReadinessNotReady = "not_ready"
+
+	healthCheckOK = "ok"
+
+	// healthCheckTimeout bounds the checks so a hanging database fails the probe instead of timing it out
+	healthCheckTimeout = 2 * time.Second
+)
+
+// Readiness is the result of the readiness checks by check name
+type Readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func (readiness Readiness) Ready() bool {
+	return readiness.Status == ReadinessReady
+}
+
+type HealthService struct {
+	repo repository.SchemaRepository
+}
+
+func NewHealthService(repositories *repository.Repositories) *HealthService {
+	return &HealthService{
+		repo: repositories.Schema,
+	}
+}
+
+// Ready reports whether the server can serve requests: the database answers and is migrated to db.SchemaVersion
+func (healthService *HealthService) Ready(ctx context.Context) Readiness {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	readiness := Readiness{Status: ReadinessReady, Checks: map[string]string{}}
+	fail := func(check string, state string, err error) {
+		readiness.Status = ReadinessNotReady
+		readiness.Checks[check] = state
+		logs.FromContext(ctx).Warn("readiness check failed", "check", check, "state", state, "error", err)
+	}
+
+	if err := healthService.repo.Ping(ctx); err != nil {
+		fail("database", "unreachable", err)
+		fail("migrations", "unknown", err)
+		return readiness
+	}
+	readiness.Checks["database"] = healthCheckOK
+
+	version, dirty, err := healthService.repo.Version(ctx)
+	switch {
+	case err != nil:
+		fail("migrations", "unknown", err)
+	case dirty:
+		fail("migrations", fmt.Sprintf("dirty at version %d", version), nil)
+	case version < db.SchemaVersion:
+		fail("migrations", fmt.Sprintf("at version %d, expected %d", version, db.SchemaVersion), nil)
+	default:
+		readiness.Checks["migrations"] = healthCheckOK
+	}
+	return readiness
+}
@@ -0,0 +1,16 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/memory"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+)
+
+func TestReadyWithMemoryRepositories(t *testing.T) {
+	readiness := service.NewHealthService(memory.NewRepositories()).Ready(context.Background())
+	if !readiness.Ready() {
+		t.Fatalf("memory repositories are not ready: %v", readiness.Checks)
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	outboxLogPrefix        = "Outbox"
+	aggregateProject       = "project"
+	aggregateTask          = "task"
+	aggregateTimeRecord    = "time_record"
+	defaultOutboxBatchSize = 100
+)
+
+// Outbox stores events in the transaction of the change they describe and wakes the dispatcher
+// once the transaction is committed. The services and the OutboxDispatcher of an application share one.
+type Outbox struct {
+	repo       repository.OutboxRepository
+	transactor repository.Transactor
+	clock      clock.Clock
+	wake       chan struct{}
+}
+
+func NewOutbox(repositories *repository.Repositories, clock clock.Clock) *Outbox {
+	return &Outbox{
+		repo:       repositories.Outbox,
+		transactor: repositories.Transactor,
+		clock:      clock,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// notify lets the dispatcher pick up committed messages without waiting for the next poll
+func (outbox *Outbox) notify() {
+	select {
+	case outbox.wake <- struct{}{}:
+	default:
+	}
+}
+
+// withTransaction runs fn in a transaction and wakes the dispatcher once it is committed.
+// Events recorded inside fn are stored only when the change itself is stored.
+func (outbox *Outbox) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := outbox.transactor.WithTransaction(ctx, fn); err != nil {
+		return err
+	}
+	outbox.notify()
+	return nil
+}
+
+func (outbox *Outbox) record(
+	ctx context.Context,
+	aggregateType string,
+	aggregateID uint64,
+	published event.Event,
+) error {
+	payload, err := json.Marshal(published.Data)
+	if err != nil {
+		return fmt.Errorf("%s marshal event failed: %w", outboxLogPrefix, err)
+	}
+	message := &model.OutboxMessage{
+		AggregateType: aggregateType,
+		AggregateID:   fmt.Sprint(aggregateID),
+		EventID:       published.ID,
+		EventType:     string(published.Type),
+		UserID:        uuid.MustParse(published.UserID),
+		Payload:       payload,
+		OccurredAt:    published.OccurredAt,
+		NextAttemptAt: outbox.clock.Now(),
+		CreatedAt:     outbox.clock.Now(),
+	}
+	return outbox.repo.Create(ctx, message)
+}
+
+// OutboxHandler receives relayed events, returning an error makes the dispatcher retry the event later
+type OutboxHandler func(ctx context.Context, published event.Event) error
+
+type outboxSubscriber struct {
+	name    string
+	handler OutboxHandler
+}
+
+// OutboxDispatcher relays stored events to in-process subscribers at least once.
+// Events of one aggregate are relayed in the order they were recorded. Dispatchers of several
+// instances may run at once, each message is leased to one of them while it is relayed.
+type OutboxDispatcher struct {
+	outbox      *Outbox
+	logger      logs.Logger
+	metrics     *metrics.Metrics
+	subscribers []outboxSubscriber
+
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease is how long a claimed message is hidden from other dispatchers, it has to outlast relaying a batch
+	Lease           time.Duration
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+func NewOutboxDispatcher(outbox *Outbox, logger logs.Logger, metrics *metrics.Metrics) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outbox:          outbox,
+		logger:          logger.With("component", outboxLogPrefix),
+		metrics:         metrics,
+		PollInterval:    time.Second,
+		BatchSize:       defaultOutboxBatchSize,
+		MaxAttempts:     25,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      10 * time.Minute,
+		Lease:           5 * time.Minute,
+		Retention:       24 * time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+// Subscribe registers a handler, it must be called before Run
+func (dispatcher *OutboxDispatcher) Subscribe(name string, handler OutboxHandler) {
+	dispatcher.subscribers = append(dispatcher.subscribers, outboxSubscriber{name: name, handler: handler})
+}
+
+// PublishToBus is an OutboxHandler forwarding events to the live event bus
+func PublishToBus(bus *event.Bus) OutboxHandler {
+	return func(ctx context.Context, published event.Event) error {
+		bus.Publish(published)
+		return nil
+	}
+}
+
+// Run relays pending messages until ctx is cancelled, it returns once the run in progress is done
+func (dispatcher *OutboxDispatcher) Run(ctx context.Context) {
+	ctx = logs.WithContext(ctx, dispatcher.logger)
+	poll := time.NewTicker(dispatcher.PollInterval)
+	defer poll.Stop()
+	cleanup := time.NewTicker(dispatcher.CleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			started := time.Now()
+			err := dispatcher.Cleanup(context.WithoutCancel(ctx))
+			dispatcher.metrics.ObserveJob(metrics.JobOutboxCleanup, started, err)
+			if err != nil {
+				dispatcher.logger.Error("clean up dispatched messages failed", "error", err)
+			}
+			continue
+		case <-poll.C:
+		case <-dispatcher.outbox.wake:
+		}
+		// a run in progress is finished on shutdown, cancelling it would count as failed attempts
+		started := time.Now()
+		err := dispatcher.DispatchPending(context.WithoutCancel(ctx))
+		dispatcher.metrics.ObserveJob(metrics.JobOutboxDispatch, started, err)
+		if err != nil {
+			dispatcher.logger.Error("dispatch pending messages failed", "error", err)
+		}
+	}
+}
+
+// DispatchPending relays the due messages. A failed message is retried with exponential backoff and holds
+// back the later messages of its aggregate until it is relayed, or set aside as failed after MaxAttempts.
+func (dispatcher *OutboxDispatcher) DispatchPending(ctx context.Context) error {
+	for {
+		now := dispatcher.outbox.clock.Now()
+		messages, err := dispatcher.outbox.repo.ClaimPending(ctx, now, now.Add(dispatcher.Lease), dispatcher.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		done := 0
+		for i := range messages {
+			message := &messages[i]
+			message.Attempts++
+			err := dispatcher.dispatch(ctx, message)
+			now := dispatcher.outbox.clock.Now()
+			switch {
+			case err == nil:
+				message.DispatchedAt = &now
+				message.LastError = nil
+				done++
+			case message.Attempts >= dispatcher.MaxAttempts:
+				dispatcher.logger.Error("dispatch message failed, giving up",
+					"message_id", message.ID, "event_type", message.EventType, "attempts", message.Attempts, "error", err)
+				errorMessage := err.Error()
+				message.LastError = &errorMessage
+				message.FailedAt = &now
+				done++
+			default:
+				dispatcher.logger.Warn("dispatch message failed",
+					"message_id", message.ID, "event_type", message.EventType, "attempts", message.Attempts, "error", err)
+				errorMessage := err.Error()
+				message.LastError = &errorMessage
+				message.NextAttemptAt = now.Add(dispatcher.backoff(message.Attempts))
+			}
+			if err := dispatcher.outbox.repo.Update(ctx, message); err != nil {
+				return err
+			}
+		}
+
+		// a message that is done makes the next message of its aggregate due
+		if done == 0 {
+			return nil
+		}
+	}
+}
+
+// Cleanup removes relayed messages older than Retention
+func (dispatcher *OutboxDispatcher) Cleanup(ctx context.Context) error {
+	_, err := dispatcher.outbox.repo.DeleteDispatchedBefore(ctx, dispatcher.outbox.clock.Now().Add(-dispatcher.Retention))
+	return err
+}
+
+// backoff doubles the delay after every failed attempt up to MaxBackoff
+func (dispatcher *OutboxDispatcher) backoff(attempts int) time.Duration {
+	delay := dispatcher.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= dispatcher.MaxBackoff {
+			return dispatcher.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (dispatcher *OutboxDispatcher) dispatch(ctx context.Context, message *model.OutboxMessage) error {
+	published := event.Event{
+		ID:         message.EventID,
+		Type:       event.Type(message.EventType),
+		UserID:     message.UserID.String(),
+		OccurredAt: message.OccurredAt,
+		Data:       message.Payload,
+	}
+	for _, subscriber := range dispatcher.subscribers {
+		if err := subscriber.handler(ctx, published); err != nil {
+			return fmt.Errorf("%s subscriber %s failed on event %s: %w", outboxLogPrefix, subscriber.name, published.ID, err)
+		}
+	}
+	return nil
+}
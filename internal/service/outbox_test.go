@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/memory"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/google/uuid"
+)
+
+// recordingOutbox keeps the last saved state of every outbox message
+type recordingOutbox struct {
+	repository.OutboxRepository
+	mutex sync.Mutex
+	saved map[uint64]model.OutboxMessage
+}
+
+func (outbox *recordingOutbox) Update(ctx context.Context, message *model.OutboxMessage) error {
+	outbox.mutex.Lock()
+	outbox.saved[message.ID] = *message
+	outbox.mutex.Unlock()
+	return outbox.OutboxRepository.Update(ctx, message)
+}
+
+type outboxFixture struct {
+	ctx        context.Context
+	clock      *clock.Fake
+	repo       *recordingOutbox
+	projects   *service.ProjectService
+	dispatcher *service.OutboxDispatcher
+	userID     string
+	// failing makes the subscriber reject the events whose payload contains it
+	failing  string
+	relayed  []string
+	attempts map[string]int
+}
+
+func newOutboxFixture(t *testing.T) *outboxFixture {
+	t.Helper()
+	fakeClock := clock.NewFake(started)
+	repositories := memory.NewRepositories()
+	repo := &recordingOutbox{OutboxRepository: repositories.Outbox, saved: make(map[uint64]model.OutboxMessage)}
+	repositories.Outbox = repo
+	outbox := service.NewOutbox(repositories, fakeClock)
+	userID := uuid.NewString()
+
+	fixture := &outboxFixture{
+		ctx:        requestctx.WithActor(context.Background(), userID),
+		clock:      fakeClock,
+		repo:       repo,
+		projects:   service.NewProjectService(repositories, outbox, fakeClock),
+		dispatcher: service.NewOutboxDispatcher(outbox, logs.Get(), newMetrics()),
+		userID:     userID,
+		attempts:   make(map[string]int),
+	}
+	fixture.dispatcher.Subscribe("recorder", func(ctx context.Context, published event.Event) error {
+		payload, _ := published.Data.(json.RawMessage)
+		name := fixture.describe(published.Type, payload)
+		fixture.attempts[name]++
+		if fixture.failing != "" && bytes.Contains(payload, []byte(fixture.failing)) {
+			return errors.New("subscriber unavailable")
+		}
+		fixture.relayed = append(fixture.relayed, name)
+		return nil
+	})
+	return fixture
+}
+
+// describe names an event by its type and the project name it carries
+func (fixture *outboxFixture) describe(eventType event.Type, payload []byte) string {
+	for _, name := range []string{"Beta", "Alpha v2", "Alpha"} {
+		if bytes.Contains(payload, []byte(strconv.Quote(name))) {
+			return fmt.Sprintf("%s %s", eventType, name)
+		}
+	}
+	return string(eventType)
+}
+
+func (fixture *outboxFixture) createProject(t *testing.T, name string) *model.Project {
+	t.Helper()
+	project, err := fixture.projects.Create(fixture.ctx, fixture.userID, service.ProjectInput{Name: name})
+	if err != nil {
+		t.Fatalf("create project failed: %v", err)
+	}
+	return project
+}
+
+func (fixture *outboxFixture) renameProject(t *testing.T, project *model.Project, name string) {
+	t.Helper()
+	err := fixture.projects.Rename(fixture.ctx, strconv.FormatUint(project.ID, 10), fixture.userID, service.ProjectInput{Name: name})
+	if err != nil {
+		t.Fatalf("rename project failed: %v", err)
+	}
+}
+
+func (fixture *outboxFixture) dispatch(t *testing.T) {
+	t.Helper()
+	if err := fixture.dispatcher.DispatchPending(fixture.ctx); err != nil {
+		t.Fatalf("dispatch pending messages failed: %v", err)
+	}
+}
+
+func (fixture *outboxFixture) expectRelayed(t *testing.T, want ...string) {
+	t.Helper()
+	if fmt.Sprint(fixture.relayed) != fmt.Sprint(want) {
+		t.Fatalf("relayed %q, want %q", fixture.relayed, want)
+	}
+}
+
+func TestOutboxRetriesFailedMessagesInOrder(t *testing.T) {
+	fixture := newOutboxFixture(t)
+	alpha := fixture.createProject(t, "Alpha")
+	fixture.renameProject(t, alpha, "Alpha v2")
+	fixture.createProject(t, "Beta")
+
+	fixture.failing = "Alpha"
+	fixture.dispatch(t)
+	fixture.expectRelayed(t, "project.created Beta")
+
+	// the failed message waits for its backoff and holds back the rename, also in later passes
+	fixture.clock.Advance(fixture.dispatcher.BaseBackoff / 2)
+	fixture.dispatch(t)
+	fixture.expectRelayed(t, "project.created Beta")
+	if attempts := fixture.attempts["project.created Alpha"]; attempts != 1 {
+		t.Fatalf("created event of Alpha was attempted %d times before its backoff ran out, want 1", attempts)
+	}
+
+	fixture.failing = ""
+	fixture.clock.Advance(fixture.dispatcher.BaseBackoff / 2)
+	fixture.dispatch(t)
+	fixture.expectRelayed(t, "project.created Beta", "project.created Alpha", "project.updated Alpha v2")
+}
+
+func TestOutboxGivesUpAfterMaxAttempts(t *testing.T) {
+	fixture := newOutboxFixture(t)
+	fixture.dispatcher.MaxAttempts = 3
+	alpha := fixture.createProject(t, "Alpha")
+	fixture.renameProject(t, alpha, "Alpha v2")
+	fixture.failing = `"Alpha"`
+
+	for range fixture.dispatcher.MaxAttempts {
+		fixture.expectRelayed(t)
+		fixture.dispatch(t)
+		fixture.clock.Advance(fixture.dispatcher.MaxBackoff)
+	}
+	// giving up on the created event lets the rename through
+	fixture.expectRelayed(t, "project.updated Alpha v2")
+
+	var failed model.OutboxMessage
+	for _, message := range fixture.repo.saved {
+		if message.EventType == string(event.ProjectCreated) {
+			failed = message
+		}
+	}
+	if failed.FailedAt == nil || failed.DispatchedAt != nil {
+		t.Fatalf("message that ran out of attempts has failed_at %v and dispatched_at %v, want only failed_at",
+			failed.FailedAt, failed.DispatchedAt)
+	}
+	if failed.Attempts != 3 || failed.LastError == nil {
+		t.Fatalf("message that ran out of attempts has %d attempts and last error %v, want 3 and the error",
+			failed.Attempts, failed.LastError)
+	}
+
+	fixture.clock.Advance(24 * time.Hour)
+	fixture.dispatch(t)
+	if attempts := fixture.attempts["project.created Alpha"]; attempts != 3 {
+		t.Fatalf("message was attempted %d times, want no attempt after giving up", attempts)
+	}
+}
@@ -0,0 +1,509 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPomodoroStartFailed    = apperror.Internal(apperror.CodeInternal, "failed to start pomodoro")
+	ErrPomodoroStopFailed     = apperror.Internal(apperror.CodeInternal, "failed to stop pomodoro")
+	ErrPomodoroGetFailed      = apperror.Internal(apperror.CodeInternal, "failed to get pomodoro state")
+	ErrPomodoroInvalidInput   = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrPomodoroAlreadyRunning = apperror.Conflict("pomodoro_already_running", "pomodoro focus session is already running")
+	ErrPomodoroNotRunning     = apperror.NotFound("pomodoro_not_running", "no running pomodoro session")
+)
+
+const (
+	DefaultPomodoroFocusMinutes      = 25
+	DefaultPomodoroShortBreakMinutes = 5
+	DefaultPomodoroLongBreakMinutes  = 15
+	DefaultPomodoroLongBreakEvery    = 4
+)
+
+// PomodoroInput Input for starting a focus session, zero values fall back to defaults
+type PomodoroInput struct {
+	FocusMinutes      int `json:"focus_minutes" validate:"min=0,max=240"`
+	ShortBreakMinutes int `json:"short_break_minutes" validate:"min=0,max=240"`
+	LongBreakMinutes  int `json:"long_break_minutes" validate:"min=0,max=240"`
+	LongBreakEvery    int `json:"long_break_every" validate:"min=0,max=24"`
+}
+
+// PomodoroState is the countdown of the running session of a user
+type PomodoroState struct {
+	Active           bool                   `json:"active"`
+	Session          *model.PomodoroSession `json:"session,omitempty"`
+	EndsAt           *time.Time             `json:"ends_at,omitempty"`
+	RemainingSeconds int                    `json:"remaining_seconds"`
+	CompletedToday   int                    `json:"completed_today"`
+}
+
+type PomodoroTaskStats struct {
+	TaskID       uint64 `json:"task_id"`
+	Completed    int    `json:"completed"`
+	FocusSeconds int    `json:"focus_seconds"`
+}
+
+type PomodoroDayStats struct {
+	Date      string              `json:"date"`
+	Completed int                 `json:"completed"`
+	Tasks     []PomodoroTaskStats `json:"tasks"`
+}
+
+type PomodoroService struct {
+	repo              repository.PomodoroSessionRepository
+	users             repository.UserRepository
+	taskService       *TaskService
+	timeRecordService *TimeRecordService
+	clock             clock.Clock
+	logger            logs.Logger
+	metrics           *metrics.Metrics
+
+	// mutex serializes session transitions between API calls and the scheduler,
+	// both have to use the same PomodoroService
+	mutex sync.Mutex
+}
+
+const pomodoroServiceLogPrefix = "PomodoroService"
+
+func NewPomodoroService(
+	repositories *repository.Repositories,
+	clock clock.Clock,
+	taskService *TaskService,
+	timeRecordService *TimeRecordService,
+	logger logs.Logger,
+	metrics *metrics.Metrics,
+) *PomodoroService {
+	return &PomodoroService{
+		repo:              repositories.PomodoroSessions,
+		users:             repositories.Users,
+		taskService:       taskService,
+		timeRecordService: timeRecordService,
+		clock:             clock,
+		logger:            logger.With("component", pomodoroServiceLogPrefix),
+		metrics:           metrics,
+	}
+}
+
+// Start starts the task timer and opens a focus session for it.
+// A running break is cancelled, a running focus session is an error.
+func (pomodoroService *PomodoroService) Start(
+	ctx context.Context,
+	taskID uint64,
+	userID string,
+	input PomodoroInput,
+) (*model.PomodoroSession, error) {
+	ctx, span := tracing.Start(ctx, "PomodoroService.Start")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	input.applyDefaults()
+
+	pomodoroService.mutex.Lock()
+	defer pomodoroService.mutex.Unlock()
+
+	running, err := pomodoroService.getRunning(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		if !running.IsBreak() {
+			return nil, fmt.Errorf("%s: %w", pomodoroServiceLogPrefix, ErrPomodoroAlreadyRunning)
+		}
+		if err := pomodoroService.finish(ctx, running, model.PomodoroCancelled, pomodoroService.clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pomodoroService.taskService.Start(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	timeRecord, err := pomodoroService.timeRecordService.GetActiveByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.PomodoroSession{
+		UserID:            uuid.MustParse(userID),
+		TaskID:            taskID,
+		Phase:             model.PhaseFocus,
+		Status:            model.PomodoroRunning,
+		PlannedSeconds:    input.FocusMinutes * 60,
+		FocusSeconds:      input.FocusMinutes * 60,
+		ShortBreakSeconds: input.ShortBreakMinutes * 60,
+		LongBreakSeconds:  input.LongBreakMinutes * 60,
+		LongBreakEvery:    input.LongBreakEvery,
+		StartTime:         pomodoroService.clock.Now(),
+		CreatedAt:         pomodoroService.clock.Now(),
+		UpdatedAt:         pomodoroService.clock.Now(),
+	}
+	if timeRecord != nil {
+		session.TimeRecordID = &timeRecord.ID
+	}
+
+	err = pomodoroService.repo.Create(ctx, session)
+	return session, err
+}
+
+// Stop cancels the running session of the user and stops the task timer of a focus session.
+func (pomodoroService *PomodoroService) Stop(ctx context.Context, userID string) (*model.PomodoroSession, error) {
+	ctx, span := tracing.Start(ctx, "PomodoroService.Stop")
+	defer span.End()
+
+	pomodoroService.mutex.Lock()
+	defer pomodoroService.mutex.Unlock()
+
+	running, err := pomodoroService.getRunning(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if running == nil {
+		return nil, fmt.Errorf("%s: %w", pomodoroServiceLogPrefix, ErrPomodoroNotRunning)
+	}
+	if !running.IsBreak() {
+		err = pomodoroService.taskService.Stop(ctx, running.TaskID, userID)
+		if err != nil && !errors.Is(err, ErrTaskHasInvalidStatus) {
+			return nil, err
+		}
+	}
+	err = pomodoroService.finish(ctx, running, model.PomodoroCancelled, pomodoroService.clock.Now())
+	return running, err
+}
+
+// GetState returns the countdown of the running session of the user.
+func (pomodoroService *PomodoroService) GetState(ctx context.Context, userID string) (*PomodoroState, error) {
+	ctx, span := tracing.Start(ctx, "PomodoroService.GetState")
+	defer span.End()
+
+	pomodoroService.mutex.Lock()
+	defer pomodoroService.mutex.Unlock()
+
+	running, err := pomodoroService.getRunning(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := pomodoroService.location(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := pomodoroService.clock.Now()
+	completed, err := pomodoroService.getCompletedFocusSessions(ctx, userID, startOfDay(now.In(location)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &PomodoroState{CompletedToday: len(completed)}
+	if running != nil {
+		endsAt := running.PlannedEnd()
+		state.Active = true
+		state.Session = running
+		state.EndsAt = &endsAt
+		state.RemainingSeconds = int(endsAt.Sub(now).Seconds())
+	}
+	return state, nil
+}
+
+// GetDayStats returns completed focus sessions of the date, YYYY-MM-DD or today when empty, grouped by task.
+// The day runs from midnight to midnight in the user's time zone, so it has 23 or 25 hours on DST changes.
+func (pomodoroService *PomodoroService) GetDayStats(
+	ctx context.Context,
+	userID string,
+	date string,
+) (*PomodoroDayStats, error) {
+	ctx, span := tracing.Start(ctx, "PomodoroService.GetDayStats")
+	defer span.End()
+
+	location, err := pomodoroService.location(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	day := pomodoroService.clock.Now().In(location)
+	if date != "" {
+		if day, err = time.ParseInLocation(time.DateOnly, date, location); err != nil {
+			return nil, ErrPomodoroInvalidInput.Wrap(err)
+		}
+	}
+
+	from := startOfDay(day)
+	to := from.AddDate(0, 0, 1)
+	sessions, err := pomodoroService.getCompletedFocusSessions(ctx, userID, from, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	byTask := make(map[uint64]*PomodoroTaskStats)
+	for _, session := range sessions {
+		stats, ok := byTask[session.TaskID]
+		if !ok {
+			stats = &PomodoroTaskStats{TaskID: session.TaskID}
+			byTask[session.TaskID] = stats
+		}
+		stats.Completed++
+		stats.FocusSeconds += session.PlannedSeconds
+	}
+
+	result := &PomodoroDayStats{
+		Date:      from.Format(time.DateOnly),
+		Completed: len(sessions),
+		Tasks:     make([]PomodoroTaskStats, 0, len(byTask)),
+	}
+	for _, stats := range byTask {
+		result.Tasks = append(result.Tasks, *stats)
+	}
+	sort.Slice(result.Tasks, func(i, j int) bool { return result.Tasks[i].TaskID < result.Tasks[j].TaskID })
+	return result, nil
+}
+
+// RunScheduler completes elapsed sessions every interval until ctx is cancelled,
+// it returns once the run in progress is done.
+func (pomodoroService *PomodoroService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ctx = logs.WithContext(ctx, pomodoroService.logger)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// a run in progress is finished on shutdown, sessions are completed in one go
+			started := time.Now()
+			err := pomodoroService.CompleteElapsed(context.WithoutCancel(ctx))
+			pomodoroService.metrics.ObserveJob(metrics.JobPomodoroScheduler, started, err)
+			if err != nil {
+				pomodoroService.logger.Error("complete elapsed sessions failed", "error", err)
+			}
+		}
+	}
+}
+
+// CountRunning counts the running sessions of all users
+func (pomodoroService *PomodoroService) CountRunning(ctx context.Context) (int64, error) {
+	return pomodoroService.repo.CountByStatus(ctx, model.PomodoroRunning)
+}
+
+// CompleteElapsed completes every running session whose planned length has elapsed.
+// Completing a focus session closes its time record at the planned end and starts a break.
+func (pomodoroService *PomodoroService) CompleteElapsed(ctx context.Context) error {
+	pomodoroService.mutex.Lock()
+	defer pomodoroService.mutex.Unlock()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("status", "=", model.PomodoroRunning),
+		),
+	}
+	sessions, err := pomodoroService.repo.GetFilteredSessions(ctx, filters, nil)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range sessions {
+		if err := pomodoroService.advance(ctx, &sessions[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getRunning returns the running session of the user, elapsed sessions are advanced first.
+func (pomodoroService *PomodoroService) getRunning(ctx context.Context, userID string) (*model.PomodoroSession, error) {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("status", "=", model.PomodoroRunning),
+		),
+	}
+	sessions, err := pomodoroService.repo.GetFilteredSessions(ctx, filters, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		if err := pomodoroService.advance(ctx, &sessions[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "start_time", Direction: "DESC"}},
+		Limit:   gormquery.IntPtr(1),
+	}
+	sessions, err = pomodoroService.repo.GetFilteredSessions(ctx, filters, options)
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return &sessions[0], nil
+}
+
+// advance moves an elapsed session to its next phase, running sessions that have not elapsed are left untouched.
+func (pomodoroService *PomodoroService) advance(ctx context.Context, session *model.PomodoroSession) error {
+	end := session.PlannedEnd()
+	if session.Status != model.PomodoroRunning || end.After(pomodoroService.clock.Now()) {
+		return nil
+	}
+	if session.IsBreak() {
+		return pomodoroService.finish(ctx, session, model.PomodoroCompleted, end)
+	}
+
+	status := model.PomodoroCompleted
+	active, err := pomodoroService.timeRecordService.GetActiveByTaskID(ctx, session.TaskID)
+	if err != nil {
+		return err
+	}
+	if session.TimeRecordID != nil && (active == nil || active.ID != *session.TimeRecordID) {
+		// the task timer was stopped by hand before the interval elapsed and may run again since,
+		// the record started later is not part of the session
+		err = ErrTaskHasInvalidStatus
+	} else {
+		err = pomodoroService.taskService.StopAt(ctx, session.TaskID, session.UserID.String(), end)
+	}
+	if errors.Is(err, ErrTaskHasInvalidStatus) || errors.Is(err, ErrTimeRecordInvalidRange) {
+		status = model.PomodoroCancelled
+		end = pomodoroService.timeRecordEnd(ctx, session, end)
+	} else if err != nil {
+		return err
+	}
+	if err := pomodoroService.finish(ctx, session, status, end); err != nil {
+		return err
+	}
+	if status != model.PomodoroCompleted {
+		return nil
+	}
+	return pomodoroService.startBreak(ctx, session)
+}
+
+func (pomodoroService *PomodoroService) startBreak(ctx context.Context, focus *model.PomodoroSession) error {
+	location, err := pomodoroService.location(ctx, focus.UserID.String())
+	if err != nil {
+		return err
+	}
+	completed, err := pomodoroService.getCompletedFocusSessions(
+		ctx,
+		focus.UserID.String(),
+		startOfDay(focus.EndTime.In(location)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	phase := model.PhaseShortBreak
+	planned := focus.ShortBreakSeconds
+	if focus.LongBreakEvery > 0 && len(completed)%focus.LongBreakEvery == 0 {
+		phase = model.PhaseLongBreak
+		planned = focus.LongBreakSeconds
+	}
+
+	breakSession := &model.PomodoroSession{
+		UserID:            focus.UserID,
+		TaskID:            focus.TaskID,
+		Phase:             phase,
+		Status:            model.PomodoroRunning,
+		PlannedSeconds:    planned,
+		FocusSeconds:      focus.FocusSeconds,
+		ShortBreakSeconds: focus.ShortBreakSeconds,
+		LongBreakSeconds:  focus.LongBreakSeconds,
+		LongBreakEvery:    focus.LongBreakEvery,
+		StartTime:         *focus.EndTime,
+		CreatedAt:         pomodoroService.clock.Now(),
+		UpdatedAt:         pomodoroService.clock.Now(),
+	}
+	if err := pomodoroService.repo.Create(ctx, breakSession); err != nil {
+		return err
+	}
+	// the break may already be over when the focus session is completed late
+	return pomodoroService.advance(ctx, breakSession)
+}
+
+func (pomodoroService *PomodoroService) finish(
+	ctx context.Context,
+	session *model.PomodoroSession,
+	status model.PomodoroStatus,
+	endTime time.Time,
+) error {
+	session.Status = status
+	session.EndTime = &endTime
+	session.UpdatedAt = pomodoroService.clock.Now()
+	return pomodoroService.repo.Update(ctx, session)
+}
+
+func (pomodoroService *PomodoroService) timeRecordEnd(
+	ctx context.Context,
+	session *model.PomodoroSession,
+	fallback time.Time,
+) time.Time {
+	if session.TimeRecordID == nil {
+		return fallback
+	}
+	timeRecord, err := pomodoroService.timeRecordService.GetByID(ctx, *session.TimeRecordID)
+	if err != nil || timeRecord.EndTime == nil {
+		return fallback
+	}
+	return *timeRecord.EndTime
+}
+
+func (pomodoroService *PomodoroService) getCompletedFocusSessions(
+	ctx context.Context,
+	userID string,
+	from time.Time,
+	to *time.Time,
+) ([]model.PomodoroSession, error) {
+	group := gormquery.NewFilterGroup(
+		gormquery.NewFilter("user_id", "=", userID),
+		gormquery.NewFilter("phase", "=", model.PhaseFocus),
+		gormquery.NewFilter("status", "=", model.PomodoroCompleted),
+		gormquery.NewFilter("start_time", ">=", from),
+	)
+	if to != nil {
+		group = append(group, gormquery.NewFilter("start_time", "<", *to))
+	}
+	return pomodoroService.repo.GetFilteredSessions(ctx, []gormquery.FilterGroup{group}, nil)
+}
+
+func (input *PomodoroInput) applyDefaults() {
+	if input.FocusMinutes == 0 {
+		input.FocusMinutes = DefaultPomodoroFocusMinutes
+	}
+	if input.ShortBreakMinutes == 0 {
+		input.ShortBreakMinutes = DefaultPomodoroShortBreakMinutes
+	}
+	if input.LongBreakMinutes == 0 {
+		input.LongBreakMinutes = DefaultPomodoroLongBreakMinutes
+	}
+	if input.LongBreakEvery == 0 {
+		input.LongBreakEvery = DefaultPomodoroLongBreakEvery
+	}
+}
+
+// location is the time zone the days of the user start in
+func (pomodoroService *PomodoroService) location(ctx context.Context, userID string) (*time.Location, error) {
+	user, err := pomodoroService.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Location(), nil
+}
+
+// startOfDay is the midnight of the day of t in the location of t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
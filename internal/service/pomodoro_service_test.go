@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/google/uuid"
+)
+
+// newPomodoroService returns the pomodoro service of fixture for a user living in timeZone
+func (fixture *fixture) newPomodoroService(t *testing.T, timeZone string) *service.PomodoroService {
+	t.Helper()
+	fixture.createUser(t, timeZone)
+	return service.NewPomodoroService(fixture.repositories, fixture.clock, fixture.tasks, fixture.timeRecords, logs.Get(), newMetrics())
+}
+
+// completedFocus stores a focus session of task that started at start and is completed
+func (fixture *fixture) completedFocus(t *testing.T, task *model.Task, start time.Time) {
+	t.Helper()
+	end := start.Add(25 * time.Minute)
+	session := &model.PomodoroSession{
+		UserID:            uuid.MustParse(fixture.userID),
+		TaskID:            task.ID,
+		Phase:             model.PhaseFocus,
+		Status:            model.PomodoroCompleted,
+		PlannedSeconds:    1500,
+		FocusSeconds:      1500,
+		ShortBreakSeconds: 300,
+		LongBreakSeconds:  900,
+		LongBreakEvery:    4,
+		StartTime:         start,
+		EndTime:           &end,
+		CreatedAt:         start,
+		UpdatedAt:         end,
+	}
+	if err := fixture.repositories.PomodoroSessions.Create(fixture.ctx, session); err != nil {
+		t.Fatalf("create pomodoro session failed: %v", err)
+	}
+}
+
+func expectDayStats(t *testing.T, stats *service.PomodoroDayStats, date string, tasks ...service.PomodoroTaskStats) {
+	t.Helper()
+	completed := 0
+	for _, task := range tasks {
+		completed += task.Completed
+	}
+	if stats.Date != date || stats.Completed != completed || len(stats.Tasks) != len(tasks) {
+		t.Fatalf("stats are %+v, want %d sessions of %v on %s", stats, completed, tasks, date)
+	}
+	for i, task := range tasks {
+		if stats.Tasks[i] != task {
+			t.Fatalf("stats of task %d are %+v, want %+v", task.TaskID, stats.Tasks[i], task)
+		}
+	}
+}
+
+func TestDayStatsOnShortDay(t *testing.T) {
+	fixture := newFixture(t)
+	pomodoro := fixture.newPomodoroService(t, "Europe/Berlin")
+	sunday := fixture.newTask(t, "Sunday")
+	other := fixture.newTask(t, "Saturday and Monday")
+
+	// Berlin switches to CEST at 02:00 on 2025-03-30, the day lasts from 23:00 to 22:00 UTC
+	fixture.completedFocus(t, other, time.Date(2025, time.March, 29, 22, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, sunday, time.Date(2025, time.March, 29, 23, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, sunday, time.Date(2025, time.March, 30, 21, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, other, time.Date(2025, time.March, 30, 22, 30, 0, 0, time.UTC))
+
+	stats, err := pomodoro.GetDayStats(fixture.ctx, fixture.userID, "2025-03-30")
+	if err != nil {
+		t.Fatalf("get day stats failed: %v", err)
+	}
+	expectDayStats(t, stats, "2025-03-30", service.PomodoroTaskStats{TaskID: sunday.ID, Completed: 2, FocusSeconds: 3000})
+
+	// 08:00 on Monday in Berlin
+	fixture.clock.Set(time.Date(2025, time.March, 31, 6, 0, 0, 0, time.UTC))
+	stats, err = pomodoro.GetDayStats(fixture.ctx, fixture.userID, "")
+	if err != nil {
+		t.Fatalf("get stats of today failed: %v", err)
+	}
+	expectDayStats(t, stats, "2025-03-31", service.PomodoroTaskStats{TaskID: other.ID, Completed: 1, FocusSeconds: 1500})
+	state, err := pomodoro.GetState(fixture.ctx, fixture.userID)
+	if err != nil {
+		t.Fatalf("get state failed: %v", err)
+	}
+	if state.Active || state.CompletedToday != 1 {
+		t.Fatalf("state is %+v, want one session completed today", state)
+	}
+}
+
+func TestDayStatsOnLongDay(t *testing.T) {
+	fixture := newFixture(t)
+	pomodoro := fixture.newPomodoroService(t, "Europe/Berlin")
+	sunday := fixture.newTask(t, "Sunday")
+	other := fixture.newTask(t, "Saturday and Monday")
+
+	// Berlin switches back to CET at 03:00 on 2025-10-26, the day lasts from 22:00 to 23:00 UTC
+	fixture.completedFocus(t, other, time.Date(2025, time.October, 25, 21, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, sunday, time.Date(2025, time.October, 25, 22, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, sunday, time.Date(2025, time.October, 26, 22, 30, 0, 0, time.UTC))
+	fixture.completedFocus(t, other, time.Date(2025, time.October, 26, 23, 30, 0, 0, time.UTC))
+
+	stats, err := pomodoro.GetDayStats(fixture.ctx, fixture.userID, "2025-10-26")
+	if err != nil {
+		t.Fatalf("get day stats failed: %v", err)
+	}
+	expectDayStats(t, stats, "2025-10-26", service.PomodoroTaskStats{TaskID: sunday.ID, Completed: 2, FocusSeconds: 3000})
+}
+
+func TestDayStatsOfInvalidDate(t *testing.T) {
+	fixture := newFixture(t)
+	pomodoro := fixture.newPomodoroService(t, "UTC")
+
+	if _, err := pomodoro.GetDayStats(fixture.ctx, fixture.userID, "30.03.2025"); !errors.Is(err, service.ErrPomodoroInvalidInput) {
+		t.Fatalf("get day stats returned %v, want %v", err, service.ErrPomodoroInvalidInput)
+	}
+}
+
+func TestUpdateSettingsRejectsUnknownTimeZone(t *testing.T) {
+	fixture := newFixture(t)
+	fixture.newPomodoroService(t, "Europe/Berlin")
+	users := service.NewUserService(fixture.repositories, fixture.clock, newMetrics())
+
+	if _, err := users.UpdateSettings(fixture.ctx, fixture.userID, service.UserSettingsInput{TimeZone: "Mars/Olympus"}); err == nil {
+		t.Fatal("update settings with an unknown time zone succeeded")
+	}
+	user, err := fixture.repositories.Users.GetByID(fixture.ctx, fixture.userID)
+	if err != nil || user.TimeZone != "Europe/Berlin" {
+		t.Fatalf("user after the rejected update is %+v, %v, want the time zone unchanged", user, err)
+	}
+}
+
+func TestElapsedFocusLeavesRestartedTimerRunning(t *testing.T) {
+	fixture := newFixture(t)
+	pomodoro := fixture.newPomodoroService(t, "UTC")
+	task := fixture.newTask(t, "Interrupted")
+	session, err := pomodoro.Start(fixture.ctx, task.ID, fixture.userID, service.PomodoroInput{FocusMinutes: 25})
+	if err != nil {
+		t.Fatalf("start pomodoro failed: %v", err)
+	}
+
+	stopped := fixture.clock.Advance(10 * time.Minute)
+	if err := fixture.tasks.Stop(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	restarted := fixture.clock.Advance(5 * time.Minute)
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("restart failed: %v", err)
+	}
+	fixture.clock.Advance(time.Hour)
+	if err := pomodoro.CompleteElapsed(fixture.ctx); err != nil {
+		t.Fatalf("complete elapsed sessions failed: %v", err)
+	}
+
+	sessions, err := fixture.repositories.PomodoroSessions.GetFilteredSessions(
+		fixture.ctx,
+		[]gormquery.FilterGroup{gormquery.NewFilterGroup(gormquery.NewFilter("id", "=", session.ID))},
+		nil,
+	)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("get pomodoro session returned %v, %v", sessions, err)
+	}
+	if sessions[0].Status != model.PomodoroCancelled || !sessions[0].EndTime.Equal(stopped) {
+		t.Fatalf("session is %s until %v, want cancelled at %v", sessions[0].Status, sessions[0].EndTime, stopped)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusWorkingOn)
+	timeRecords := fixture.timeRecordsOf(t, task.ID)
+	if len(timeRecords) != 2 {
+		t.Fatalf("task has %d time records, want 2", len(timeRecords))
+	}
+	for _, timeRecord := range timeRecords {
+		if timeRecord.StartTime.Equal(restarted) && timeRecord.EndTime != nil {
+			t.Fatalf("restarted time record was closed at %v", timeRecord.EndTime)
+		}
+	}
+}
@@ -2,39 +2,49 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"gorm.io/gorm"
 	"strings"
-	"time"
 
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
 	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
 	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrProjectDeleteFailed = errors.New("failed to delete project")
-	ErrProjectCreateFailed = errors.New("failed to create project")
-	ErrProjectUpdateFailed = errors.New("failed to update project")
-	ErrProjectGetFailed    = errors.New("failed to get project(s)")
-	ErrProjectInvalidInput = errors.New("invalid input")
+	ErrProjectDeleteFailed = apperror.Internal(apperror.CodeInternal, "failed to delete project")
+	ErrProjectCreateFailed = apperror.Internal(apperror.CodeInternal, "failed to create project")
+	ErrProjectUpdateFailed = apperror.Internal(apperror.CodeInternal, "failed to update project")
+	ErrProjectGetFailed    = apperror.Internal(apperror.CodeInternal, "failed to get project(s)")
+	ErrProjectInvalidInput = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrProjectNameTaken    = apperror.Conflict("project_name_taken", "project with this name already exists for this user")
 )
 
 type ProjectInput struct {
-	Name string `json:"name"`
+	Name string `json:"name" normalize:"trim" validate:"required,max=100,name"`
 }
 
 type ProjectService struct {
 	projectRepo repository.ProjectRepository
+	outbox      *Outbox
+	audit       *auditRecorder
+	clock       clock.Clock
 }
 
 const projectServiceLogPrefix = "ProjectService"
 
-func NewProjectService() *ProjectService {
+func NewProjectService(repositories *repository.Repositories, outbox *Outbox, clock clock.Clock) *ProjectService {
 	return &ProjectService{
-		projectRepo: repository.NewProjectRepository(),
+		projectRepo: repositories.Projects,
+		outbox:      outbox,
+		audit:       newAuditRecorder(repositories, clock),
+		clock:       clock,
 	}
 }
 
@@ -42,6 +52,12 @@ func (projectService *ProjectService) Create(ctx context.Context, userID string,
 	*model.Project,
 	error,
 ) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Create")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("user_id", "=", userID),
@@ -55,32 +71,55 @@ func (projectService *ProjectService) Create(ctx context.Context, userID string,
 		return nil, err
 	}
 	if len(existing) > 0 {
-		return nil, errors.New(
-			fmt.Sprintf("%s project with this name already exists for this user", projectServiceLogPrefix),
-		)
+		return nil, fmt.Errorf("%s: %w", projectServiceLogPrefix, ErrProjectNameTaken)
 	}
 
 	project := &model.Project{
 		Name:      input.Name,
 		UserID:    uuid.MustParse(userID),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: projectService.clock.Now(),
+		UpdatedAt: projectService.clock.Now(),
 	}
 
-	err = projectService.projectRepo.Create(ctx, project)
+	err = projectService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := projectService.projectRepo.Create(ctx, project); err != nil {
+			return err
+		}
+		return projectService.record(ctx, event.ProjectCreated, nil, project)
+	})
 	return project, err
 }
 
 func (projectService *ProjectService) GetAllByUser(ctx context.Context, userID string) ([]model.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetAllByUser")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	return projectService.projectRepo.GetFilteredProjects(ctx, filters, gormquery.QueryOptions{})
+}
+
+// GetAllByIDs returns the user's projects among ids, in no particular order
+func (projectService *ProjectService) GetAllByIDs(ctx context.Context, userID string, ids []uint64) ([]model.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetAllByIDs")
+	defer span.End()
+
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("id", "IN", ids),
 		),
 	}
 	return projectService.projectRepo.GetFilteredProjects(ctx, filters, gormquery.QueryOptions{})
 }
 
 func (projectService *ProjectService) GetByID(ctx context.Context, id string, userID string) (*model.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetByID")
+	defer span.End()
+
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("id", "=", id),
@@ -97,17 +136,68 @@ func (projectService *ProjectService) GetByID(ctx context.Context, id string, us
 	return &projects[0], nil
 }
 
-func (projectService *ProjectService) Rename(ctx context.Context, id string, userID string, newName string) error {
-	_, err := projectService.GetByID(ctx, id, userID)
+func (projectService *ProjectService) Rename(ctx context.Context, id string, userID string, input ProjectInput) error {
+	ctx, span := tracing.Start(ctx, "ProjectService.Rename")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return err
+	}
+	project, err := projectService.GetByID(ctx, id, userID)
 	if err != nil {
 		return err
 	}
+	before := *project
 
 	// FIXME check name duplicite
-	updates := map[string]interface{}{"name": newName}
-	return projectService.projectRepo.Update(ctx, id, updates)
+	now := projectService.clock.Now()
+	updates := map[string]interface{}{"name": input.Name, "updated_at": now}
+	return projectService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := projectService.projectRepo.Update(ctx, id, updates); err != nil {
+			return err
+		}
+		project.Name = input.Name
+		project.UpdatedAt = now
+		return projectService.record(ctx, event.ProjectUpdated, &before, project)
+	})
 }
 
-func (projectService *ProjectService) Delete(ctx context.Context, projectID string) error {
-	return projectService.projectRepo.DeleteByID(ctx, projectID)
+func (projectService *ProjectService) Delete(ctx context.Context, projectID string, userID string) error {
+	ctx, span := tracing.Start(ctx, "ProjectService.Delete")
+	defer span.End()
+
+	project, err := projectService.GetByID(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	return projectService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := projectService.projectRepo.DeleteByID(ctx, projectID); err != nil {
+			return err
+		}
+		return projectService.record(ctx, event.ProjectDeleted, project, nil)
+	})
+}
+
+// record stores the event and the audit log of a mutation, before is nil on create and after is nil on delete
+func (projectService *ProjectService) record(
+	ctx context.Context,
+	eventType event.Type,
+	before *model.Project,
+	after *model.Project,
+) error {
+	action := model.AuditUpdate
+	project := after
+	switch {
+	case before == nil:
+		action = model.AuditCreate
+	case after == nil:
+		action = model.AuditDelete
+		project = before
+	}
+
+	published := event.NewAt(eventType, project.UserID.String(), project, projectService.clock.Now())
+	if err := projectService.outbox.record(ctx, aggregateProject, project.ID, published); err != nil {
+		return err
+	}
+	return projectService.audit.record(ctx, action, model.AuditEntityProject, project.ID, project.UserID, before, after)
 }
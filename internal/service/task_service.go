@@ -2,58 +2,81 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
 	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
 	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
-	ErrTaskDeleteFailed       = errors.New("failed to delete task")
-	ErrTaskCreateFailed       = errors.New("failed to create task")
-	ErrTaskUpdateFailed       = errors.New("failed to update task")
-	ErrTaskStartFailed        = errors.New("failed to start task")
-	ErrTaskStopFailed         = errors.New("failed to stop task")
-	ErrTaskGetFailed          = errors.New("failed to get task(s)")
-	ErrTaskInvalidInput       = errors.New("invalid input")
-	ErrTaskInvalidInputStatus = errors.New("invalid input status")
-	ErrTaskHasInvalidStatus   = errors.New("task has invalid status for this action")
+	ErrTaskDeleteFailed       = apperror.Internal(apperror.CodeInternal, "failed to delete task")
+	ErrTaskCreateFailed       = apperror.Internal(apperror.CodeInternal, "failed to create task")
+	ErrTaskUpdateFailed       = apperror.Internal(apperror.CodeInternal, "failed to update task")
+	ErrTaskStartFailed        = apperror.Internal(apperror.CodeInternal, "failed to start task")
+	ErrTaskStopFailed         = apperror.Internal(apperror.CodeInternal, "failed to stop task")
+	ErrTaskGetFailed          = apperror.Internal(apperror.CodeInternal, "failed to get task(s)")
+	ErrTaskInvalidInput       = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrTaskInvalidInputStatus = apperror.BadRequest("invalid_task_status", "invalid input status")
+	ErrTaskHasInvalidStatus   = apperror.Conflict("task_status_conflict", "task has invalid status for this action")
+	ErrTaskNameTaken          = apperror.Conflict("task_name_taken", "combination of task name and project should be unique")
 )
 
 type TaskService struct {
 	repo              repository.TaskRepository
 	timeRecordService *TimeRecordService
+	outbox            *Outbox
+	audit             *auditRecorder
+	clock             clock.Clock
 }
 
 type CreateTaskInput struct {
-	Name      string   `json:"name" binding:"required"`
+	Name      string   `json:"name" normalize:"trim" validate:"required,max=200,name"`
 	ProjectID uint64   `json:"project_id,omitempty"`
-	Tags      []string `json:"tags"`
-	Status    string   `json:"status"`
+	Tags      []string `json:"tags" normalize:"trim" validate:"max=20,dive,max=32,tag"`
+	Status    string   `json:"status" validate:"omitempty,task_status"`
 }
 
 type UpdateTaskInput struct {
-	Name      *string   `json:"name"`
+	Name      *string   `json:"name" normalize:"trim" validate:"omitnil,min=1,max=200,name"`
 	ProjectID *uint64   `json:"project_id"`
-	Tags      *[]string `json:"tags"`
-	Status    *string   `json:"status"`
+	Tags      *[]string `json:"tags" normalize:"trim" validate:"omitnil,max=20,dive,max=32,tag"`
+	Status    *string   `json:"status" validate:"omitnil,task_status"`
 }
 
 const taskServiceLogPrefix = "TaskService"
 
-func NewTaskService() *TaskService {
+func NewTaskService(
+	repositories *repository.Repositories,
+	outbox *Outbox,
+	clock clock.Clock,
+	timeRecordService *TimeRecordService,
+) *TaskService {
 	return &TaskService{
-		repo:              repository.NewTaskRepository(),
-		timeRecordService: NewTimeRecordService(),
+		repo:              repositories.Tasks,
+		timeRecordService: timeRecordService,
+		outbox:            outbox,
+		audit:             newAuditRecorder(repositories, clock),
+		clock:             clock,
 	}
 }
 
 func (taskService *TaskService) Create(ctx context.Context, userID string, input CreateTaskInput) (*model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Create")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
 	var status model.TaskStatus
 	if input.Status != "" {
 		status = model.TaskStatus(input.Status)
@@ -66,23 +89,35 @@ func (taskService *TaskService) Create(ctx context.Context, userID string, input
 		Name:      input.Name,
 		Tags:      input.Tags,
 		Status:    status,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: taskService.clock.Now(),
+		UpdatedAt: taskService.clock.Now(),
 	}
 	exists, err := taskService.checkExisting(ctx, task.UserID, task.ProjectID, task.Name)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New(
-			fmt.Sprintf("%s: combination of task name and project should be unique", taskServiceLogPrefix),
-		)
+		return nil, fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskNameTaken)
 	}
-	err = taskService.repo.Create(ctx, task)
-	return task, err
+	err = taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := taskService.repo.Create(ctx, task); err != nil {
+			return err
+		}
+		if err := taskService.record(ctx, event.TaskCreated, task); err != nil {
+			return err
+		}
+		return taskService.audit.record(ctx, model.AuditCreate, model.AuditEntityTask, task.ID, task.UserID, nil, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 func (taskService *TaskService) GetAllByUser(ctx context.Context, userID string) ([]model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllByUser")
+	defer span.End()
+
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("user_id", "=", userID),
@@ -92,6 +127,9 @@ func (taskService *TaskService) GetAllByUser(ctx context.Context, userID string)
 }
 
 func (taskService *TaskService) GetAllActiveByUser(ctx context.Context, userID string) ([]model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllActiveByUser")
+	defer span.End()
+
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("user_id", "=", userID),
@@ -105,7 +143,43 @@ func (taskService *TaskService) GetAllActiveByUser(ctx context.Context, userID s
 	return taskService.repo.GetFilteredTasks(ctx, filters, nil)
 }
 
+// GetAllByIDs returns the user's tasks among ids, in no particular order
+func (taskService *TaskService) GetAllByIDs(ctx context.Context, userID string, ids []uint64) ([]model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllByIDs")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("id", "IN", ids),
+		),
+	}
+	return taskService.repo.GetFilteredTasks(ctx, filters, nil)
+}
+
+// GetAllByProjects returns the user's tasks of the given projects
+func (taskService *TaskService) GetAllByProjects(
+	ctx context.Context,
+	userID string,
+	projectIDs []uint64,
+) ([]model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllByProjects")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("project_id", "IN", projectIDs),
+		),
+	}
+	options := &gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "ASC"}}}
+	return taskService.repo.GetFilteredTasks(ctx, filters, options)
+}
+
 func (taskService *TaskService) GetByID(ctx context.Context, taskID uint64, userID string) (*model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetByID")
+	defer span.End()
+
 	filters := []gormquery.FilterGroup{
 		gormquery.NewFilterGroup(
 			gormquery.NewFilter("id", "=", taskID),
@@ -121,10 +195,17 @@ func (taskService *TaskService) Update(
 	userID string,
 	input UpdateTaskInput,
 ) (*model.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Update")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
 	task, err := taskService.GetByID(ctx, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
+	before := *task
 
 	if input.Name != nil && *input.Name != task.Name {
 		task.Name = *input.Name
@@ -139,37 +220,50 @@ func (taskService *TaskService) Update(
 	}
 
 	if input.Status != nil && string(task.Status) != *input.Status {
-		if !model.IsValidTaskStatus(*input.Status) {
-			return nil, fmt.Errorf("%s: invalid input status", taskServiceLogPrefix)
-		}
 		task.Status = model.TaskStatus(*input.Status)
 	}
 
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = taskService.clock.Now()
 
 	exists, err := taskService.checkExisting(ctx, task.UserID, task.ProjectID, task.Name)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New(
-			fmt.Sprintf("%s: combination of task name and project should be unique", taskServiceLogPrefix),
-		)
+		return nil, fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskNameTaken)
 	}
-	err = taskService.repo.Update(ctx, task)
-
-	return task, err
+	err = taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		return taskService.updateAndRecord(ctx, event.TaskUpdated, &before, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 func (taskService *TaskService) Delete(ctx context.Context, taskID uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Delete")
+	defer span.End()
+
 	task, err := taskService.GetByID(ctx, taskID, userID)
 	if err != nil {
 		return err
 	}
-	return taskService.repo.Delete(ctx, task)
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := taskService.repo.Delete(ctx, task); err != nil {
+			return err
+		}
+		if err := taskService.record(ctx, event.TaskDeleted, task); err != nil {
+			return err
+		}
+		return taskService.audit.record(ctx, model.AuditDelete, model.AuditEntityTask, task.ID, task.UserID, task, nil)
+	})
 }
 
 func (taskService *TaskService) Start(ctx context.Context, taskID uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Start")
+	defer span.End()
+
 	task, err := taskService.GetByID(ctx, taskID, userID)
 	if err != nil {
 		return err
@@ -177,16 +271,29 @@ func (taskService *TaskService) Start(ctx context.Context, taskID uint64, userID
 	if !checkIfTaskIsNotClosed(task) || !checkIfTaskIsNotWorkingOn(task) {
 		return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
 	}
+	before := *task
 	task.Status = model.StatusWorkingOn
-	task.UpdatedAt = time.Now()
-	_, err = taskService.timeRecordService.Create(ctx, userID, taskID)
-	if err != nil {
-		return err
-	}
-	return taskService.repo.Update(ctx, task)
+	task.UpdatedAt = taskService.clock.Now()
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if _, err := taskService.timeRecordService.Create(ctx, userID, taskID); err != nil {
+			return err
+		}
+		return taskService.updateAndRecord(ctx, event.TaskStarted, &before, task)
+	})
 }
 
 func (taskService *TaskService) Stop(ctx context.Context, taskID uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Stop")
+	defer span.End()
+
+	return taskService.StopAt(ctx, taskID, userID, taskService.clock.Now())
+}
+
+// StopAt stops the task and closes its active time record with the given end time.
+func (taskService *TaskService) StopAt(ctx context.Context, taskID uint64, userID string, endTime time.Time) error {
+	ctx, span := tracing.Start(ctx, "TaskService.StopAt")
+	defer span.End()
+
 	task, err := taskService.GetByID(ctx, taskID, userID)
 	if err != nil {
 		return err
@@ -194,39 +301,51 @@ func (taskService *TaskService) Stop(ctx context.Context, taskID uint64, userID
 	if !checkIfTaskIsNotClosed(task) || !checkIfTaskIsNotOpened(task) {
 		return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
 	}
+	before := *task
 	task.Status = model.StatusOpened
-	task.UpdatedAt = time.Now()
-	err = taskService.timeRecordService.CloseByTaskID(ctx, taskID)
-	if err != nil {
-		return err
-	}
-	return taskService.repo.Update(ctx, task)
+	task.UpdatedAt = taskService.clock.Now()
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := taskService.timeRecordService.CloseByTaskIDAt(ctx, taskID, endTime); err != nil {
+			return err
+		}
+		return taskService.updateAndRecord(ctx, event.TaskStopped, &before, task)
+	})
 }
 
 func (taskService *TaskService) StopAll(ctx context.Context, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.StopAll")
+	defer span.End()
+
 	tasks, err := taskService.GetAllActiveByUser(ctx, userID)
 	if err != nil {
 		return err
 	}
-	for _, task := range tasks {
-		if !checkIfTaskIsNotClosed(&task) {
-			return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
-		}
-		task.Status = model.StatusOpened
-		task.UpdatedAt = time.Now()
-		err := taskService.timeRecordService.CloseByTaskID(ctx, task.ID)
-		if err != nil {
-			return err
+	span.SetAttributes(attribute.Int("tasks.count", len(tasks)))
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		for _, task := range tasks {
+			if !checkIfTaskIsNotClosed(&task) {
+				return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
+			}
+			before := task
+			task.Status = model.StatusOpened
+			task.UpdatedAt = taskService.clock.Now()
+			err := taskService.timeRecordService.CloseByTaskID(ctx, task.ID)
+			if err != nil {
+				return err
+			}
+			err = taskService.updateAndRecord(ctx, event.TaskStopped, &before, &task)
+			if err != nil {
+				return err
+			}
 		}
-		err = taskService.repo.Update(ctx, &task)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+		return nil
+	})
 }
 
 func (taskService *TaskService) Close(ctx context.Context, id uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Close")
+	defer span.End()
+
 	task, err := taskService.GetByID(ctx, id, userID)
 	if err != nil {
 		return err
@@ -234,14 +353,150 @@ func (taskService *TaskService) Close(ctx context.Context, id uint64, userID str
 	if !checkIfTaskIsNotClosed(task) || !checkIfTaskIsNotOpened(task) {
 		return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
 	}
+	before := *task
 	task.Status = model.StatusClosed
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = taskService.clock.Now()
 
-	err = taskService.timeRecordService.CloseByTaskID(ctx, task.ID)
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := taskService.timeRecordService.CloseByTaskID(ctx, task.ID); err != nil {
+			return err
+		}
+		return taskService.updateAndRecord(ctx, event.TaskClosed, &before, task)
+	})
+}
+
+// EditTimeRecord edits a time record of the user and keeps the status of the affected tasks in line with it
+func (taskService *TaskService) EditTimeRecord(
+	ctx context.Context,
+	timeRecordID uint64,
+	userID string,
+	input UpdateTimeRecordInput,
+) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.EditTimeRecord")
+	defer span.End()
+
+	var timeRecord *model.TimeRecord
+	err := taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		current, err := taskService.timeRecordService.GetByIDForUser(ctx, timeRecordID, userID)
+		if err != nil {
+			return err
+		}
+		timeRecord, err = taskService.timeRecordService.Update(ctx, timeRecordID, userID, input)
+		if err != nil {
+			return err
+		}
+		return taskService.syncStatusWithTimeRecords(ctx, userID, current.TaskID, timeRecord.TaskID)
+	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return timeRecord, nil
+}
+
+func (taskService *TaskService) DeleteTimeRecord(ctx context.Context, timeRecordID uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.DeleteTimeRecord")
+	defer span.End()
+
+	return taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		timeRecord, err := taskService.timeRecordService.GetByIDForUser(ctx, timeRecordID, userID)
+		if err != nil {
+			return err
+		}
+		if err := taskService.timeRecordService.Delete(ctx, timeRecordID, userID); err != nil {
+			return err
+		}
+		return taskService.syncStatusWithTimeRecords(ctx, userID, timeRecord.TaskID)
+	})
+}
+
+// RestoreTimeRecord restores a time record to one of its versions, see TimeRecordService.Restore
+func (taskService *TaskService) RestoreTimeRecord(
+	ctx context.Context,
+	timeRecordID uint64,
+	userID string,
+	input RestoreTimeRecordInput,
+) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.RestoreTimeRecord")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	return taskService.restoreTimeRecord(ctx, userID, func(ctx context.Context) (*model.TimeRecord, *model.TimeRecord, error) {
+		return taskService.timeRecordService.Restore(ctx, timeRecordID, userID, input.Version)
+	})
+}
+
+// UndoTimeRecordAction reverts the last stop, edit or delete of a time record, see TimeRecordService.Undo
+func (taskService *TaskService) UndoTimeRecordAction(ctx context.Context, userID string) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.UndoTimeRecordAction")
+	defer span.End()
+
+	return taskService.restoreTimeRecord(ctx, userID, func(ctx context.Context) (*model.TimeRecord, *model.TimeRecord, error) {
+		return taskService.timeRecordService.Undo(ctx, userID)
+	})
+}
+
+func (taskService *TaskService) restoreTimeRecord(
+	ctx context.Context,
+	userID string,
+	restore func(ctx context.Context) (*model.TimeRecord, *model.TimeRecord, error),
+) (*model.TimeRecord, error) {
+	var restored *model.TimeRecord
+	err := taskService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		previous, timeRecord, err := restore(ctx)
+		if err != nil {
+			return err
+		}
+		restored = timeRecord
+		taskIDs := []uint64{timeRecord.TaskID}
+		if previous != nil {
+			taskIDs = append(taskIDs, previous.TaskID)
+		}
+		return taskService.syncStatusWithTimeRecords(ctx, userID, taskIDs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// syncStatusWithTimeRecords marks tasks with an active time record as worked on and tasks that lost their
+// active time record as opened. Closed tasks keep their status, an active time record on one is rejected.
+func (taskService *TaskService) syncStatusWithTimeRecords(ctx context.Context, userID string, taskIDs ...uint64) error {
+	for _, taskID := range taskIDs {
+		task, err := taskService.GetByID(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+		active, err := taskService.timeRecordService.GetActiveByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		before := *task
+		var eventType event.Type
+		switch {
+		case task.Status == model.StatusClosed:
+			if active != nil {
+				return fmt.Errorf("%s: %w", taskServiceLogPrefix, ErrTaskHasInvalidStatus)
+			}
+			continue
+		case active != nil && task.Status != model.StatusWorkingOn:
+			task.Status = model.StatusWorkingOn
+			eventType = event.TaskStarted
+		case active == nil && task.Status == model.StatusWorkingOn:
+			task.Status = model.StatusOpened
+			eventType = event.TaskStopped
+		default:
+			continue
+		}
+		task.UpdatedAt = taskService.clock.Now()
+		if err := taskService.updateAndRecord(ctx, eventType, &before, task); err != nil {
+			return err
+		}
 	}
-	return taskService.repo.Update(ctx, task)
+	return nil
 }
 
 func (taskService *TaskService) checkExisting(
@@ -266,6 +521,26 @@ func (taskService *TaskService) checkExisting(
 	return len(tasks) > 0, nil
 }
 
+func (taskService *TaskService) updateAndRecord(
+	ctx context.Context,
+	eventType event.Type,
+	before *model.Task,
+	task *model.Task,
+) error {
+	if err := taskService.repo.Update(ctx, task); err != nil {
+		return err
+	}
+	if err := taskService.record(ctx, eventType, task); err != nil {
+		return err
+	}
+	return taskService.audit.record(ctx, model.AuditUpdate, model.AuditEntityTask, task.ID, task.UserID, before, task)
+}
+
+func (taskService *TaskService) record(ctx context.Context, eventType event.Type, task *model.Task) error {
+	published := event.NewAt(eventType, task.UserID.String(), task, taskService.clock.Now())
+	return taskService.outbox.record(ctx, aggregateTask, task.ID, published)
+}
+
 func checkIfTaskIsNotClosed(task *model.Task) bool {
 	return task.Status != model.StatusClosed
 }
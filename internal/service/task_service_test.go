@@ -0,0 +1,394 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/memory"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var started = time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+// newMetrics records on a registry of the test, so services built by several tests do not collide
+func newMetrics() *metrics.Metrics {
+	return metrics.New(prometheus.NewRegistry(), logs.Get())
+}
+
+type fixture struct {
+	ctx          context.Context
+	clock        *clock.Fake
+	repositories *repository.Repositories
+	tasks        *service.TaskService
+	timeRecords  *service.TimeRecordService
+	userID       string
+	project      *model.Project
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	fakeClock := clock.NewFake(started)
+	repositories := memory.NewRepositories()
+	outbox := service.NewOutbox(repositories, fakeClock)
+	timeRecords := service.NewTimeRecordService(repositories, outbox, fakeClock)
+	userID := uuid.NewString()
+	ctx := requestctx.WithActor(context.Background(), userID)
+
+	project, err := service.NewProjectService(repositories, outbox, fakeClock).
+		Create(ctx, userID, service.ProjectInput{Name: "Timekeeper"})
+	if err != nil {
+		t.Fatalf("create project failed: %v", err)
+	}
+	return &fixture{
+		ctx:          ctx,
+		clock:        fakeClock,
+		repositories: repositories,
+		tasks:        service.NewTaskService(repositories, outbox, fakeClock, timeRecords),
+		timeRecords:  timeRecords,
+		userID:       userID,
+		project:      project,
+	}
+}
+
+// createUser stores the user of the fixture with the given time zone
+func (fixture *fixture) createUser(t *testing.T, timeZone string) {
+	t.Helper()
+	user := &model.User{
+		ID:        uuid.MustParse(fixture.userID),
+		Email:     "user@example.com",
+		Password:  "hash",
+		TimeZone:  model.DefaultTimeZone,
+		CreatedAt: started,
+		UpdatedAt: started,
+	}
+	if err := fixture.repositories.Users.Create(fixture.ctx, user); err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	users := service.NewUserService(fixture.repositories, fixture.clock, newMetrics())
+	if _, err := users.UpdateSettings(fixture.ctx, fixture.userID, service.UserSettingsInput{TimeZone: timeZone}); err != nil {
+		t.Fatalf("update settings failed: %v", err)
+	}
+}
+
+func (fixture *fixture) newTask(t *testing.T, name string) *model.Task {
+	t.Helper()
+	task, err := fixture.tasks.Create(fixture.ctx, fixture.userID, service.CreateTaskInput{
+		Name:      name,
+		ProjectID: fixture.project.ID,
+	})
+	if err != nil {
+		t.Fatalf("create task failed: %v", err)
+	}
+	return task
+}
+
+func (fixture *fixture) task(t *testing.T, id uint64) *model.Task {
+	t.Helper()
+	task, err := fixture.tasks.GetByID(fixture.ctx, id, fixture.userID)
+	if err != nil {
+		t.Fatalf("get task failed: %v", err)
+	}
+	return task
+}
+
+func (fixture *fixture) timeRecordsOf(t *testing.T, taskID uint64) []model.TimeRecord {
+	t.Helper()
+	timeRecords, err := fixture.timeRecords.GetByTaskID(fixture.ctx, taskID)
+	if err != nil {
+		t.Fatalf("get time records failed: %v", err)
+	}
+	return *timeRecords
+}
+
+func (fixture *fixture) versionsOf(t *testing.T, timeRecordID uint64) []model.VersionOperation {
+	t.Helper()
+	versions, err := fixture.repositories.TimeRecordVersions.GetFilteredVersions(
+		fixture.ctx,
+		[]gormquery.FilterGroup{gormquery.NewFilterGroup(gormquery.NewFilter("time_record_id", "=", timeRecordID))},
+		&gormquery.QueryOptions{OrderBy: []gormquery.OrderOption{{Field: "version", Direction: "ASC"}}},
+	)
+	if err != nil {
+		t.Fatalf("get time record versions failed: %v", err)
+	}
+	operations := make([]model.VersionOperation, 0, len(versions))
+	for _, version := range versions {
+		operations = append(operations, version.Operation)
+	}
+	return operations
+}
+
+func expectStatus(t *testing.T, task *model.Task, status model.TaskStatus) {
+	t.Helper()
+	if task.Status != status {
+		t.Fatalf("task status is %q, want %q", task.Status, status)
+	}
+}
+
+func expectClosedAt(t *testing.T, timeRecord model.TimeRecord, end time.Time) {
+	t.Helper()
+	if !timeRecord.IsClosed || timeRecord.EndTime == nil || !timeRecord.EndTime.Equal(end) {
+		t.Fatalf("time record is closed %t at %v, want closed at %v", timeRecord.IsClosed, timeRecord.EndTime, end)
+	}
+}
+
+func TestStartAndStopTask(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Write tests")
+
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	running := fixture.task(t, task.ID)
+	expectStatus(t, running, model.StatusWorkingOn)
+	if !running.UpdatedAt.Equal(started) {
+		t.Fatalf("task updated at %v, want %v", running.UpdatedAt, started)
+	}
+	timeRecords := fixture.timeRecordsOf(t, task.ID)
+	if len(timeRecords) != 1 || !timeRecords[0].StartTime.Equal(started) || timeRecords[0].EndTime != nil {
+		t.Fatalf("time records after start are %+v, want one open record started at %v", timeRecords, started)
+	}
+	if !timeRecords[0].CreatedAt.Equal(started) || !timeRecords[0].UpdatedAt.Equal(started) {
+		t.Fatalf("time record created at %v and updated at %v, want %v", timeRecords[0].CreatedAt, timeRecords[0].UpdatedAt, started)
+	}
+	if active, err := fixture.timeRecords.CountRunning(fixture.ctx); err != nil || active != 1 {
+		t.Fatalf("running time records are %d, %v, want 1", active, err)
+	}
+
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); !errors.Is(err, service.ErrTaskHasInvalidStatus) {
+		t.Fatalf("second start returned %v, want %v", err, service.ErrTaskHasInvalidStatus)
+	}
+
+	stopped := fixture.clock.Advance(25 * time.Minute)
+	if err := fixture.tasks.Stop(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusOpened)
+	timeRecords = fixture.timeRecordsOf(t, task.ID)
+	expectClosedAt(t, timeRecords[0], stopped)
+	if duration := timeRecords[0].EndTime.Sub(timeRecords[0].StartTime); duration != 25*time.Minute {
+		t.Fatalf("tracked %v, want 25m", duration)
+	}
+	if operations := fixture.versionsOf(t, timeRecords[0].ID); len(operations) != 2 ||
+		operations[0] != model.VersionCreate || operations[1] != model.VersionStop {
+		t.Fatalf("time record versions are %v, want create and stop", operations)
+	}
+
+	if err := fixture.tasks.Stop(fixture.ctx, task.ID, fixture.userID); !errors.Is(err, service.ErrTaskHasInvalidStatus) {
+		t.Fatalf("second stop returned %v, want %v", err, service.ErrTaskHasInvalidStatus)
+	}
+}
+
+func TestStopTaskAtGivenTime(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Forgot to stop")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	fixture.clock.Advance(8 * time.Hour)
+	end := started.Add(90 * time.Minute)
+	if err := fixture.tasks.StopAt(fixture.ctx, task.ID, fixture.userID, end); err != nil {
+		t.Fatalf("stop at failed: %v", err)
+	}
+	expectClosedAt(t, fixture.timeRecordsOf(t, task.ID)[0], end)
+	if updated := fixture.task(t, task.ID).UpdatedAt; !updated.Equal(started.Add(8 * time.Hour)) {
+		t.Fatalf("task updated at %v, want the time it was stopped", updated)
+	}
+}
+
+func TestStopTaskBeforeItStarted(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Back to the future")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	err := fixture.tasks.StopAt(fixture.ctx, task.ID, fixture.userID, started.Add(-time.Minute))
+	if !errors.Is(err, service.ErrTimeRecordInvalidRange) {
+		t.Fatalf("stop before the start returned %v, want %v", err, service.ErrTimeRecordInvalidRange)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusWorkingOn)
+	if timeRecord := fixture.timeRecordsOf(t, task.ID)[0]; timeRecord.EndTime != nil {
+		t.Fatalf("time record was closed at %v", timeRecord.EndTime)
+	}
+}
+
+func TestStopAllTasks(t *testing.T) {
+	fixture := newFixture(t)
+	first := fixture.newTask(t, "First")
+	second := fixture.newTask(t, "Second")
+	idle := fixture.newTask(t, "Idle")
+	if err := fixture.tasks.Start(fixture.ctx, first.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	fixture.clock.Advance(10 * time.Minute)
+	if err := fixture.tasks.Start(fixture.ctx, second.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	stopped := fixture.clock.Advance(5 * time.Minute)
+	if err := fixture.tasks.StopAll(fixture.ctx, fixture.userID); err != nil {
+		t.Fatalf("stop all failed: %v", err)
+	}
+	for _, task := range []*model.Task{first, second} {
+		expectStatus(t, fixture.task(t, task.ID), model.StatusOpened)
+		expectClosedAt(t, fixture.timeRecordsOf(t, task.ID)[0], stopped)
+	}
+	expectStatus(t, fixture.task(t, idle.ID), model.StatusOpened)
+	if timeRecords := fixture.timeRecordsOf(t, idle.ID); len(timeRecords) != 0 {
+		t.Fatalf("idle task has time records %+v", timeRecords)
+	}
+}
+
+func TestCloseTask(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Finish feature")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	closed := fixture.clock.Advance(time.Hour)
+	if err := fixture.tasks.Close(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusClosed)
+	expectClosedAt(t, fixture.timeRecordsOf(t, task.ID)[0], closed)
+
+	for name, action := range map[string]func(context.Context, uint64, string) error{
+		"start": fixture.tasks.Start,
+		"stop":  fixture.tasks.Stop,
+		"close": fixture.tasks.Close,
+	} {
+		if err := action(fixture.ctx, task.ID, fixture.userID); !errors.Is(err, service.ErrTaskHasInvalidStatus) {
+			t.Fatalf("%s of a closed task returned %v, want %v", name, err, service.ErrTaskHasInvalidStatus)
+		}
+	}
+}
+
+func TestCloseTaskThatIsNotRunning(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Never started")
+
+	if err := fixture.tasks.Close(fixture.ctx, task.ID, fixture.userID); !errors.Is(err, service.ErrTaskHasInvalidStatus) {
+		t.Fatalf("close of an opened task returned %v, want %v", err, service.ErrTaskHasInvalidStatus)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusOpened)
+}
+
+func TestStartTaskOfAnotherUser(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Private")
+
+	err := fixture.tasks.Start(fixture.ctx, task.ID, uuid.NewString())
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("start of another user's task returned %v, want a not found error", err)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusOpened)
+}
+
+func TestUndoStopWithinWindow(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Undo me")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	fixture.clock.Advance(20 * time.Minute)
+	if err := fixture.tasks.Stop(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	fixture.clock.Advance(4 * time.Minute)
+	restored, err := fixture.tasks.UndoTimeRecordAction(fixture.ctx, fixture.userID)
+	if err != nil {
+		t.Fatalf("undo within the window failed: %v", err)
+	}
+	if restored.IsClosed || restored.EndTime != nil || !restored.StartTime.Equal(started) {
+		t.Fatalf("undone time record is %+v, want it running since %v", restored, started)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusWorkingOn)
+}
+
+func TestUndoStopAfterWindow(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Too late")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	stopped := fixture.clock.Advance(20 * time.Minute)
+	if err := fixture.tasks.Stop(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	fixture.clock.Advance(6 * time.Minute)
+	_, err := fixture.tasks.UndoTimeRecordAction(fixture.ctx, fixture.userID)
+	if !errors.Is(err, service.ErrTimeRecordNothingToUndo) {
+		t.Fatalf("undo after the window returned %v, want %v", err, service.ErrTimeRecordNothingToUndo)
+	}
+	expectClosedAt(t, fixture.timeRecordsOf(t, task.ID)[0], stopped)
+}
+
+func TestUndoCloseOfTaskIsRejected(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Closed for good")
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	closed := fixture.clock.Advance(time.Hour)
+	if err := fixture.tasks.Close(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	// closing stopped the time record, undoing that stop would leave a closed task running
+	fixture.clock.Advance(time.Minute)
+	_, err := fixture.tasks.UndoTimeRecordAction(fixture.ctx, fixture.userID)
+	if !errors.Is(err, service.ErrTaskHasInvalidStatus) {
+		t.Fatalf("undo of the stop of a closed task returned %v, want %v", err, service.ErrTaskHasInvalidStatus)
+	}
+	expectStatus(t, fixture.task(t, task.ID), model.StatusClosed)
+	expectClosedAt(t, fixture.timeRecordsOf(t, task.ID)[0], closed)
+}
+
+func TestEventsUseClock(t *testing.T) {
+	fixture := newFixture(t)
+	task := fixture.newTask(t, "Evented")
+	startedAt := started.Add(time.Hour)
+	fixture.clock.Set(startedAt)
+	if err := fixture.tasks.Start(fixture.ctx, task.ID, fixture.userID); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	// only the oldest pending message of the task is claimed at a time, the rest follow once it is dispatched
+	var messages []model.OutboxMessage
+	for {
+		claimed, err := fixture.repositories.Outbox.ClaimPending(fixture.ctx, startedAt, startedAt, 100)
+		if err != nil {
+			t.Fatalf("claim outbox messages failed: %v", err)
+		}
+		if len(claimed) == 0 {
+			break
+		}
+		for i := range claimed {
+			messages = append(messages, claimed[i])
+			claimed[i].DispatchedAt = &startedAt
+			if err := fixture.repositories.Outbox.Update(fixture.ctx, &claimed[i]); err != nil {
+				t.Fatalf("update outbox message failed: %v", err)
+			}
+		}
+	}
+	last := messages[len(messages)-1]
+	if last.EventType != "task.started" || !last.OccurredAt.Equal(startedAt) || !last.CreatedAt.Equal(startedAt) {
+		t.Fatalf("last outbox message is %s at %v, want task.started at %v", last.EventType, last.OccurredAt, startedAt)
+	}
+}
@@ -4,28 +4,93 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
 	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
 	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"time"
 )
 
+var (
+	ErrTimeRecordCreateFailed    = apperror.Internal(apperror.CodeInternal, "failed to create time record")
+	ErrTimeRecordGetFailed       = apperror.Internal(apperror.CodeInternal, "failed to get time record(s)")
+	ErrTimeRecordUpdateFailed    = apperror.Internal(apperror.CodeInternal, "failed to update time record")
+	ErrTimeRecordDeleteFailed    = apperror.Internal(apperror.CodeInternal, "failed to delete time record")
+	ErrTimeRecordRestoreFailed   = apperror.Internal(apperror.CodeInternal, "failed to restore time record")
+	ErrTimeRecordInvalidInput    = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrTimeRecordInvalidRange    = apperror.Unprocessable("invalid_time_range", "end time must not be before start time")
+	ErrTimeRecordAlreadyActive   = apperror.Conflict("time_record_already_active", "task already has an active time record")
+	ErrTimeRecordVersionNotFound = apperror.NotFound("time_record_version_not_found", "time record version not found")
+	ErrTimeRecordNothingToUndo   = apperror.Conflict("nothing_to_undo", "nothing to undo")
+)
+
 type TimeRecordService struct {
-	repo repository.TimeRecordRepository
+	repo        repository.TimeRecordRepository
+	versionRepo repository.TimeRecordVersionRepository
+	taskRepo    repository.TaskRepository
+	userRepo    repository.UserRepository
+	outbox      *Outbox
+	audit       *auditRecorder
+	clock       clock.Clock
 }
 
-const timeRecordServiceErrorPrefix = "TimeRecordService"
+const (
+	timeRecordServiceErrorPrefix = "TimeRecordService"
+	// timeRecordUndoWindow is how long the last stop, edit or delete can be undone
+	timeRecordUndoWindow = 5 * time.Minute
+)
+
+// CreateTimeRecordInput is a manual entry for work that was not tracked with start and stop
+type CreateTimeRecordInput struct {
+	TaskID      uint64    `json:"task_id" validate:"required"`
+	StartTime   time.Time `json:"start_time" validate:"required"`
+	EndTime     time.Time `json:"end_time" validate:"required"`
+	Description string    `json:"description" normalize:"trim" validate:"max=1000"`
+}
+
+// TimeRecordFilter narrows the time record list, the time range applies to the start time.
+// FromDate and ToDate are days (YYYY-MM-DD) in the user's time zone, ToDate included, they replace From and To.
+type TimeRecordFilter struct {
+	TaskID   *uint64
+	From     *time.Time
+	To       *time.Time
+	FromDate string
+	ToDate   string
+}
 
 type UpdateTimeRecordInput struct {
-	TaskID    *uint64
-	StartTime *time.Time
-	EndTime   *time.Time
-	IsClosed  *bool
+	TaskID      *uint64    `json:"task_id"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	IsClosed    *bool      `json:"is_closed"`
+	Description *string    `json:"description" normalize:"trim" validate:"omitnil,max=1000"`
 }
 
-func NewTimeRecordService() *TimeRecordService {
-	return &TimeRecordService{repo: repository.NewTimeRecordRepository()}
+type RestoreTimeRecordInput struct {
+	Version int `json:"version" validate:"required,min=1"`
+}
+
+func NewTimeRecordService(
+	repositories *repository.Repositories,
+	outbox *Outbox,
+	clock clock.Clock,
+) *TimeRecordService {
+	return &TimeRecordService{
+		repo:        repositories.TimeRecords,
+		versionRepo: repositories.TimeRecordVersions,
+		taskRepo:    repositories.Tasks,
+		userRepo:    repositories.Users,
+		outbox:      outbox,
+		audit:       newAuditRecorder(repositories, clock),
+		clock:       clock,
+	}
 }
 
 func (timeRecordService *TimeRecordService) Create(
@@ -33,64 +98,278 @@ func (timeRecordService *TimeRecordService) Create(
 	userID string,
 	taskID uint64,
 ) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.Create")
+	defer span.End()
 
+	now := timeRecordService.clock.Now()
 	timeRecord := &model.TimeRecord{
 		UserID:    uuid.MustParse(userID),
 		TaskID:    taskID,
-		StartTime: time.Now(),
+		StartTime: now,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	err := timeRecordService.createTimeRecordValidate(ctx, timeRecord)
 	if err != nil {
 		return nil, err
 	}
 
-	err = timeRecordService.repo.Create(ctx, timeRecord)
-	return timeRecord, err
+	err = timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := timeRecordService.repo.Create(ctx, timeRecord); err != nil {
+			return err
+		}
+		return timeRecordService.record(ctx, event.TimeRecordCreated, model.VersionCreate, nil, timeRecord)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timeRecord, nil
+}
+
+// CreateManual stores a closed time record for the given period
+func (timeRecordService *TimeRecordService) CreateManual(
+	ctx context.Context,
+	userID string,
+	input CreateTimeRecordInput,
+) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.CreateManual")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	endTime := input.EndTime
+	timeRecord := &model.TimeRecord{
+		UserID:      uuid.MustParse(userID),
+		TaskID:      input.TaskID,
+		StartTime:   input.StartTime,
+		EndTime:     &endTime,
+		IsClosed:    true,
+		Description: input.Description,
+		CreatedAt:   timeRecordService.clock.Now(),
+		UpdatedAt:   timeRecordService.clock.Now(),
+	}
+	if err := timeRecordService.updateTimeRecordValidate(ctx, userID, timeRecord); err != nil {
+		return nil, err
+	}
+
+	err := timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := timeRecordService.repo.Create(ctx, timeRecord); err != nil {
+			return err
+		}
+		return timeRecordService.record(ctx, event.TimeRecordCreated, model.VersionCreate, nil, timeRecord)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timeRecord, nil
 }
 
 func (timeRecordService *TimeRecordService) GetByID(ctx context.Context, id uint64) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetByID")
+	defer span.End()
+
 	return timeRecordService.repo.GetByID(ctx, id)
 }
 
+// GetByIDForUser returns the time record only when it belongs to the user
+func (timeRecordService *TimeRecordService) GetByIDForUser(
+	ctx context.Context,
+	id uint64,
+	userID string,
+) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetByIDForUser")
+	defer span.End()
+
+	timeRecord, err := timeRecordService.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if timeRecord.UserID.String() != userID {
+		return nil, fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, gorm.ErrRecordNotFound)
+	}
+	return timeRecord, nil
+}
+
+// CountRunning counts the time records of all users that are not stopped yet
+func (timeRecordService *TimeRecordService) CountRunning(ctx context.Context) (int64, error) {
+	return timeRecordService.repo.CountActive(ctx)
+}
+
 func (timeRecordService *TimeRecordService) GetByTaskID(ctx context.Context, taskID uint64) (*[]model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetByTaskID")
+	defer span.End()
+
 	return timeRecordService.repo.GetByTaskID(ctx, taskID)
 }
 
+// GetAllByUser returns the user's time records matching the filter, newest first
+func (timeRecordService *TimeRecordService) GetAllByUser(
+	ctx context.Context,
+	userID string,
+	filter TimeRecordFilter,
+) (*[]model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetAllByUser")
+	defer span.End()
+
+	filter, err := timeRecordService.resolveDates(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	conditions := []gormquery.Filter{gormquery.NewFilter("user_id", "=", userID)}
+	if filter.TaskID != nil {
+		conditions = append(conditions, gormquery.NewFilter("task_id", "=", *filter.TaskID))
+	}
+	return timeRecordService.getFiltered(ctx, conditions, filter)
+}
+
+// GetAllByTasks returns the user's time records of the given tasks within the time range of the filter,
+// newest first. The task id of the filter is ignored.
+func (timeRecordService *TimeRecordService) GetAllByTasks(
+	ctx context.Context,
+	userID string,
+	taskIDs []uint64,
+	filter TimeRecordFilter,
+) (*[]model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetAllByTasks")
+	defer span.End()
+
+	filter, err := timeRecordService.resolveDates(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	conditions := []gormquery.Filter{
+		gormquery.NewFilter("user_id", "=", userID),
+		gormquery.NewFilter("task_id", "IN", taskIDs),
+	}
+	return timeRecordService.getFiltered(ctx, conditions, filter)
+}
+
+// resolveDates sets the time range of the filter to the midnights of its dates in the user's time zone
+func (timeRecordService *TimeRecordService) resolveDates(
+	ctx context.Context,
+	userID string,
+	filter TimeRecordFilter,
+) (TimeRecordFilter, error) {
+	if filter.FromDate == "" && filter.ToDate == "" {
+		return filter, nil
+	}
+	if (filter.FromDate != "" && filter.From != nil) || (filter.ToDate != "" && filter.To != nil) {
+		return filter, fmt.Errorf("%s range end given as a time and a date: %w", timeRecordServiceErrorPrefix, ErrTimeRecordInvalidInput)
+	}
+	user, err := timeRecordService.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return filter, err
+	}
+	location := user.Location()
+	if filter.FromDate != "" {
+		from, err := time.ParseInLocation(time.DateOnly, filter.FromDate, location)
+		if err != nil {
+			return filter, ErrTimeRecordInvalidInput.Wrap(err)
+		}
+		filter.From = &from
+	}
+	if filter.ToDate != "" {
+		to, err := time.ParseInLocation(time.DateOnly, filter.ToDate, location)
+		if err != nil {
+			return filter, ErrTimeRecordInvalidInput.Wrap(err)
+		}
+		// the whole to date is included, the range ends at the next midnight
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+func (timeRecordService *TimeRecordService) getFiltered(
+	ctx context.Context,
+	conditions []gormquery.Filter,
+	filter TimeRecordFilter,
+) (*[]model.TimeRecord, error) {
+	if filter.From != nil {
+		conditions = append(conditions, gormquery.NewFilter("start_time", ">=", *filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, gormquery.NewFilter("start_time", "<", *filter.To))
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "start_time", Direction: "DESC"}},
+	}
+	filters := []gormquery.FilterGroup{gormquery.NewFilterGroup(conditions...)}
+	return timeRecordService.repo.GetFilteredTimeRecords(ctx, filters, options)
+}
+
 func (timeRecordService *TimeRecordService) Update(
 	ctx context.Context,
 	id uint64,
+	userID string,
 	input UpdateTimeRecordInput,
 ) (*model.TimeRecord, error) {
-	timeRecord, err := timeRecordService.GetByID(ctx, id)
+	ctx, span := tracing.Start(ctx, "TimeRecordService.Update")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	timeRecord, err := timeRecordService.GetByIDForUser(ctx, id, userID)
 	if err != nil {
 		return nil, err
 	}
+	before := *timeRecord
 
 	if input.TaskID != nil && *input.TaskID != timeRecord.TaskID {
 		timeRecord.TaskID = *input.TaskID
 	}
 
-	if input.StartTime != nil && *input.StartTime != timeRecord.StartTime {
+	if input.StartTime != nil && !input.StartTime.Equal(timeRecord.StartTime) {
 		timeRecord.StartTime = *input.StartTime
 	}
 
-	if input.EndTime != nil && *input.EndTime != *timeRecord.EndTime {
+	if input.EndTime != nil && (timeRecord.EndTime == nil || !input.EndTime.Equal(*timeRecord.EndTime)) {
 		timeRecord.EndTime = input.EndTime
+		timeRecord.IsClosed = true
 	}
 
 	if input.IsClosed != nil && *input.IsClosed != timeRecord.IsClosed {
 		timeRecord.IsClosed = *input.IsClosed
+		if !timeRecord.IsClosed {
+			timeRecord.EndTime = nil
+		}
 	}
 
-	timeRecord.UpdatedAt = time.Now()
-	// TODO: implement extended validators
+	if input.Description != nil {
+		timeRecord.Description = *input.Description
+	}
 
-	err = timeRecordService.repo.Update(ctx, timeRecord)
+	timeRecord.UpdatedAt = timeRecordService.clock.Now()
+	if err := timeRecordService.updateTimeRecordValidate(ctx, userID, timeRecord); err != nil {
+		return nil, err
+	}
 
-	return timeRecord, err
+	err = timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := timeRecordService.repo.Update(ctx, timeRecord); err != nil {
+			return err
+		}
+		return timeRecordService.record(ctx, event.TimeRecordUpdated, model.VersionEdit, &before, timeRecord)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timeRecord, nil
 }
 
 func (timeRecordService *TimeRecordService) CloseByTaskID(ctx context.Context, taskID uint64) error {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.CloseByTaskID")
+	defer span.End()
+
+	return timeRecordService.CloseByTaskIDAt(ctx, taskID, timeRecordService.clock.Now())
+}
+
+// CloseByTaskIDAt closes the active time record of the task with the given end time.
+func (timeRecordService *TimeRecordService) CloseByTaskIDAt(ctx context.Context, taskID uint64, endTime time.Time) error {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.CloseByTaskIDAt")
+	defer span.End()
+
 	searchResult, err := timeRecordService.getActiveTimeRecordsByTaskId(ctx, taskID)
 	if err != nil {
 		return err
@@ -100,22 +379,190 @@ func (timeRecordService *TimeRecordService) CloseByTaskID(ctx context.Context, t
 			fmt.Sprintf("%s: task has more that one active time record", timeRecordServiceErrorPrefix),
 		)
 	}
-	for _, timeRecord := range *searchResult {
-		now := time.Now()
-		timeRecord.EndTime = &now
-		timeRecord.IsClosed = true
-		timeRecord.UpdatedAt = now
-		err = timeRecordService.repo.Update(ctx, &timeRecord)
+	return timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		for _, timeRecord := range *searchResult {
+			if endTime.Before(timeRecord.StartTime) {
+				return fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordInvalidRange)
+			}
+			before := timeRecord
+			end := endTime
+			timeRecord.EndTime = &end
+			timeRecord.IsClosed = true
+			timeRecord.UpdatedAt = timeRecordService.clock.Now()
+			if err := timeRecordService.repo.Update(ctx, &timeRecord); err != nil {
+				return err
+			}
+			err := timeRecordService.record(ctx, event.TimeRecordUpdated, model.VersionStop, &before, &timeRecord)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetActiveByTaskID returns the open time record of the task or nil when the task is not running.
+func (timeRecordService *TimeRecordService) GetActiveByTaskID(ctx context.Context, taskID uint64) (*model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.GetActiveByTaskID")
+	defer span.End()
+
+	searchResult, err := timeRecordService.getActiveTimeRecordsByTaskId(ctx, taskID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if len(*searchResult) == 0 {
+		return nil, nil
+	}
+	return &(*searchResult)[0], nil
 }
 
-func (timeRecordService *TimeRecordService) Delete(ctx context.Context, id uint64) error {
-	timeRecord, err := timeRecordService.GetByID(ctx, id)
+func (timeRecordService *TimeRecordService) Delete(ctx context.Context, id uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.Delete")
+	defer span.End()
+
+	timeRecord, err := timeRecordService.GetByIDForUser(ctx, id, userID)
 	if err != nil {
 		return err
 	}
-	return timeRecordService.repo.Delete(ctx, timeRecord)
+	return timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if err := timeRecordService.repo.Delete(ctx, timeRecord); err != nil {
+			return err
+		}
+		return timeRecordService.record(ctx, event.TimeRecordDeleted, model.VersionDelete, timeRecord, nil)
+	})
+}
+
+// History returns every version of the time record, oldest first
+func (timeRecordService *TimeRecordService) History(
+	ctx context.Context,
+	id uint64,
+	userID string,
+) ([]model.TimeRecordVersion, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.History")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("time_record_id", "=", id),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "version", Direction: "ASC"}},
+	}
+	versions, err := timeRecordService.versionRepo.GetFilteredVersions(ctx, filters, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, gorm.ErrRecordNotFound)
+	}
+	return versions, nil
+}
+
+// Restore brings the time record back to the state of the given version, recreating it when it was deleted.
+// It returns the record before the restore, nil when it was deleted, and the restored record.
+func (timeRecordService *TimeRecordService) Restore(
+	ctx context.Context,
+	id uint64,
+	userID string,
+	versionNumber int,
+) (*model.TimeRecord, *model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.Restore")
+	defer span.End()
+
+	return timeRecordService.restore(ctx, id, userID, versionNumber, model.VersionRestore)
+}
+
+// Undo reverts the last stop, edit or delete the user made in the last few minutes
+func (timeRecordService *TimeRecordService) Undo(
+	ctx context.Context,
+	userID string,
+) (*model.TimeRecord, *model.TimeRecord, error) {
+	ctx, span := tracing.Start(ctx, "TimeRecordService.Undo")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("actor_id", "=", userID),
+		),
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "DESC"}},
+		Limit:   gormquery.IntPtr(1),
+	}
+	versions, err := timeRecordService.versionRepo.GetFilteredVersions(ctx, filters, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordNothingToUndo)
+	}
+	last := versions[0]
+	if !last.Operation.IsUndoable() || last.Version < 2 ||
+		timeRecordService.clock.Now().Sub(last.CreatedAt) > timeRecordUndoWindow {
+		return nil, nil, fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordNothingToUndo)
+	}
+	return timeRecordService.restore(ctx, last.TimeRecordID, userID, last.Version-1, model.VersionUndo)
+}
+
+func (timeRecordService *TimeRecordService) restore(
+	ctx context.Context,
+	id uint64,
+	userID string,
+	versionNumber int,
+	operation model.VersionOperation,
+) (*model.TimeRecord, *model.TimeRecord, error) {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("time_record_id", "=", id),
+			gormquery.NewFilter("user_id", "=", userID),
+			gormquery.NewFilter("version", "=", versionNumber),
+		),
+	}
+	versions, err := timeRecordService.versionRepo.GetFilteredVersions(ctx, filters, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordVersionNotFound)
+	}
+	if versions[0].Deleted {
+		return nil, nil, fmt.Errorf("%s cannot restore a deleted version: %w", timeRecordServiceErrorPrefix, ErrTimeRecordInvalidInput)
+	}
+
+	current, err := timeRecordService.GetByIDForUser(ctx, id, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+
+	restored := &model.TimeRecord{CreatedAt: timeRecordService.clock.Now()}
+	if current != nil {
+		*restored = *current
+	}
+	versions[0].Apply(restored)
+	restored.UpdatedAt = timeRecordService.clock.Now()
+	if err := timeRecordService.updateTimeRecordValidate(ctx, userID, restored); err != nil {
+		return nil, nil, err
+	}
+
+	err = timeRecordService.outbox.withTransaction(ctx, func(ctx context.Context) error {
+		if current == nil {
+			if err := timeRecordService.repo.Create(ctx, restored); err != nil {
+				return err
+			}
+			return timeRecordService.record(ctx, event.TimeRecordCreated, operation, nil, restored)
+		}
+		if err := timeRecordService.repo.Update(ctx, restored); err != nil {
+			return err
+		}
+		return timeRecordService.record(ctx, event.TimeRecordUpdated, operation, current, restored)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, restored, nil
 }
 
 func (timeRecordService *TimeRecordService) createTimeRecordValidate(
@@ -127,9 +574,45 @@ func (timeRecordService *TimeRecordService) createTimeRecordValidate(
 		return err
 	}
 	if len(*searchResult) > 0 {
-		return errors.New(
-			fmt.Sprintf("%s: task also has active time record", timeRecordServiceErrorPrefix),
-		)
+		return fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordAlreadyActive)
+	}
+	return nil
+}
+
+// updateTimeRecordValidate checks an edited or restored time record before it is saved
+func (timeRecordService *TimeRecordService) updateTimeRecordValidate(
+	ctx context.Context,
+	userID string,
+	timeRecord *model.TimeRecord,
+) error {
+	if timeRecord.IsClosed && timeRecord.EndTime == nil {
+		return fmt.Errorf("%s closed time record needs an end time: %w", timeRecordServiceErrorPrefix, ErrTimeRecordInvalidInput)
+	}
+	if timeRecord.EndTime != nil && timeRecord.EndTime.Before(timeRecord.StartTime) {
+		return fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordInvalidRange)
+	}
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("id", "=", timeRecord.TaskID),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	if _, err := timeRecordService.taskRepo.GetByID(ctx, filters); err != nil {
+		return err
+	}
+
+	if timeRecord.IsClosed {
+		return nil
+	}
+	active, err := timeRecordService.getActiveTimeRecordsByTaskId(ctx, timeRecord.TaskID)
+	if err != nil {
+		return err
+	}
+	for _, other := range *active {
+		if other.ID != timeRecord.ID {
+			return fmt.Errorf("%s: %w", timeRecordServiceErrorPrefix, ErrTimeRecordAlreadyActive)
+		}
 	}
 	return nil
 }
@@ -146,3 +629,97 @@ func (timeRecordService *TimeRecordService) getActiveTimeRecordsByTaskId(
 	}
 	return timeRecordService.repo.GetFilteredTimeRecords(ctx, filters, nil)
 }
+
+// record stores the event, the audit log and the new version of a mutation,
+// before is nil on create and after is nil on delete
+func (timeRecordService *TimeRecordService) record(
+	ctx context.Context,
+	eventType event.Type,
+	operation model.VersionOperation,
+	before *model.TimeRecord,
+	after *model.TimeRecord,
+) error {
+	action := model.AuditUpdate
+	timeRecord := after
+	switch {
+	case before == nil:
+		action = model.AuditCreate
+	case after == nil:
+		action = model.AuditDelete
+		timeRecord = before
+	}
+
+	published := event.NewAt(eventType, timeRecord.UserID.String(), timeRecord, timeRecordService.clock.Now())
+	if err := timeRecordService.outbox.record(ctx, aggregateTimeRecord, timeRecord.ID, published); err != nil {
+		return err
+	}
+	err := timeRecordService.audit.record(
+		ctx,
+		action,
+		model.AuditEntityTimeRecord,
+		timeRecord.ID,
+		timeRecord.UserID,
+		before,
+		after,
+	)
+	if err != nil {
+		return err
+	}
+	return timeRecordService.recordVersion(ctx, operation, before, after)
+}
+
+func (timeRecordService *TimeRecordService) recordVersion(
+	ctx context.Context,
+	operation model.VersionOperation,
+	before *model.TimeRecord,
+	after *model.TimeRecord,
+) error {
+	timeRecord, deleted := after, false
+	if after == nil {
+		timeRecord, deleted = before, true
+	}
+
+	latest, err := timeRecordService.versionRepo.GetLatestVersionNumber(ctx, timeRecord.ID)
+	if err != nil {
+		return err
+	}
+	// records created before versioning get their previous state as the first version
+	if latest == 0 && before != nil {
+		baseline := timeRecordService.newVersion(ctx, before, model.VersionCreate, 1, false)
+		baseline.ActorID = nil
+		baseline.CreatedAt = before.CreatedAt
+		if err := timeRecordService.versionRepo.Create(ctx, baseline); err != nil {
+			return err
+		}
+		latest = 1
+	}
+
+	version := timeRecordService.newVersion(ctx, timeRecord, operation, latest+1, deleted)
+	return timeRecordService.versionRepo.Create(ctx, version)
+}
+
+func (timeRecordService *TimeRecordService) newVersion(
+	ctx context.Context,
+	timeRecord *model.TimeRecord,
+	operation model.VersionOperation,
+	number int,
+	deleted bool,
+) *model.TimeRecordVersion {
+	version := &model.TimeRecordVersion{
+		TimeRecordID: timeRecord.ID,
+		Version:      number,
+		UserID:       timeRecord.UserID,
+		Operation:    operation,
+		TaskID:       timeRecord.TaskID,
+		StartTime:    timeRecord.StartTime,
+		EndTime:      timeRecord.EndTime,
+		IsClosed:     timeRecord.IsClosed,
+		Description:  timeRecord.Description,
+		Deleted:      deleted,
+		CreatedAt:    timeRecordService.clock.Now(),
+	}
+	if actorID, err := uuid.Parse(requestctx.FromContext(ctx).ActorID); err == nil {
+		version.ActorID = &actorID
+	}
+	return version
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+)
+
+// manualRecords stores a ten minute record of a new task for each start and returns the task id
+func (fixture *fixture) manualRecords(t *testing.T, starts ...time.Time) uint64 {
+	t.Helper()
+	task := fixture.newTask(t, "Report")
+	for _, start := range starts {
+		_, err := fixture.timeRecords.CreateManual(fixture.ctx, fixture.userID, service.CreateTimeRecordInput{
+			TaskID:    task.ID,
+			StartTime: start,
+			EndTime:   start.Add(10 * time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("create time record at %s failed: %v", start, err)
+		}
+	}
+	return task.ID
+}
+
+// expectStarts lists the user's time records matching filter and compares their start times, newest first
+func (fixture *fixture) expectStarts(t *testing.T, filter service.TimeRecordFilter, want ...time.Time) {
+	t.Helper()
+	timeRecords, err := fixture.timeRecords.GetAllByUser(fixture.ctx, fixture.userID, filter)
+	if err != nil {
+		t.Fatalf("list time records failed: %v", err)
+	}
+	got := make([]time.Time, 0, len(*timeRecords))
+	for _, timeRecord := range *timeRecords {
+		got = append(got, timeRecord.StartTime.UTC())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%+v: got records started at %v, want %v", filter, got, want)
+	}
+	for index := range want {
+		if !got[index].Equal(want[index]) {
+			t.Fatalf("%+v: got records started at %v, want %v", filter, got, want)
+		}
+	}
+}
+
+func TestListTimeRecordsByDatesOfUserTimeZone(t *testing.T) {
+	fixture := newFixture(t)
+	fixture.createUser(t, "Europe/Berlin")
+	fixture.clock.Set(time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC))
+
+	// Berlin is one hour ahead of UTC in March
+	lateOnNinth := time.Date(2025, time.March, 9, 22, 30, 0, 0, time.UTC)
+	earlyOnTenth := time.Date(2025, time.March, 9, 23, 30, 0, 0, time.UTC)
+	lateOnTenth := time.Date(2025, time.March, 10, 22, 30, 0, 0, time.UTC)
+	earlyOnEleventh := time.Date(2025, time.March, 10, 23, 30, 0, 0, time.UTC)
+	fixture.manualRecords(t, lateOnNinth, earlyOnTenth, lateOnTenth, earlyOnEleventh)
+
+	fixture.expectStarts(t, service.TimeRecordFilter{FromDate: "2025-03-10", ToDate: "2025-03-10"}, lateOnTenth, earlyOnTenth)
+	fixture.expectStarts(t, service.TimeRecordFilter{FromDate: "2025-03-11"}, earlyOnEleventh)
+	fixture.expectStarts(t, service.TimeRecordFilter{ToDate: "2025-03-09"}, lateOnNinth)
+	// a date bound and a time bound of different ends combine
+	fixture.expectStarts(t, service.TimeRecordFilter{FromDate: "2025-03-10", To: &lateOnTenth}, earlyOnTenth)
+}
+
+func TestListTimeRecordsByDatesAcrossDaylightSavingChange(t *testing.T) {
+	fixture := newFixture(t)
+	fixture.createUser(t, "America/New_York")
+	fixture.clock.Set(time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC))
+
+	// New York moves from UTC-5 to UTC-4 on March 9, 2025, so March 8 ends at 05:00 UTC and March 9 at 04:00 UTC
+	lateOnEighth := time.Date(2025, time.March, 9, 4, 50, 0, 0, time.UTC)
+	earlyOnNinth := time.Date(2025, time.March, 9, 5, 0, 0, 0, time.UTC)
+	lateOnNinth := time.Date(2025, time.March, 10, 3, 50, 0, 0, time.UTC)
+	earlyOnTenth := time.Date(2025, time.March, 10, 4, 0, 0, 0, time.UTC)
+	fixture.manualRecords(t, lateOnEighth, earlyOnNinth, lateOnNinth, earlyOnTenth)
+
+	fixture.expectStarts(t, service.TimeRecordFilter{FromDate: "2025-03-09", ToDate: "2025-03-09"}, lateOnNinth, earlyOnNinth)
+}
+
+func TestListTimeRecordsByDatesOfTasks(t *testing.T) {
+	fixture := newFixture(t)
+	fixture.createUser(t, "Europe/Berlin")
+	fixture.clock.Set(time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC))
+	earlyOnTenth := time.Date(2025, time.March, 9, 23, 30, 0, 0, time.UTC)
+	taskID := fixture.manualRecords(t, earlyOnTenth.Add(-time.Hour), earlyOnTenth)
+
+	timeRecords, err := fixture.timeRecords.GetAllByTasks(fixture.ctx, fixture.userID, []uint64{taskID},
+		service.TimeRecordFilter{FromDate: "2025-03-10"})
+	if err != nil {
+		t.Fatalf("list time records of tasks failed: %v", err)
+	}
+	if len(*timeRecords) != 1 || !(*timeRecords)[0].StartTime.Equal(earlyOnTenth) {
+		t.Fatalf("got %+v, want only the record started at %s", *timeRecords, earlyOnTenth)
+	}
+}
+
+func TestListTimeRecordsByInvalidDates(t *testing.T) {
+	fixture := newFixture(t)
+	fixture.createUser(t, "Europe/Berlin")
+	from := started
+
+	for _, filter := range []service.TimeRecordFilter{
+		{FromDate: "10.03.2025"},
+		{ToDate: "2025-02-30"},
+		{From: &from, FromDate: "2025-03-10"},
+		{To: &from, ToDate: "2025-03-10"},
+	} {
+		if _, err := fixture.timeRecords.GetAllByUser(fixture.ctx, fixture.userID, filter); !errors.Is(err, service.ErrTimeRecordInvalidInput) {
+			t.Errorf("%+v: got %v, want %v", filter, err, service.ErrTimeRecordInvalidInput)
+		}
+	}
+}
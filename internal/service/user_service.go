@@ -3,11 +3,15 @@ package service
 import (
 	"context"
 	"fmt"
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/requestctx"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
 	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
 	"github.com/google/uuid"
 	"gitlab.com/tozd/go/errors"
-	"time"
 
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
 	"github.com/advanced-coder-com/go-timekeeper/internal/model"
 	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
 	"golang.org/x/crypto/bcrypt"
@@ -15,53 +19,76 @@ import (
 
 // User related errors
 var (
-	ErrUserUnauthorized         = errors.New("user is unauthorized")
-	ErrUserTokenInvalid         = errors.New("invalid or expired token")
-	ErrUserMissingAuthHeader    = errors.New("missing authorization header")
-	ErrUserInvalidAuthHeader    = errors.New("invalid authorization header")
-	ErrUserMissingJWTSecret     = errors.New("missing JWT_SECRET")
-	ErrUserInvalidInput         = errors.New("invalid input")
-	ErrUserSignInFailed         = errors.New("user sign in failed")
-	ErrUserSignUpFailed         = errors.New("user sign up failed")
-	ErrGetUserFailed            = errors.New("cannot get user with provided credentials")
-	ErrUserDeleteFailed         = errors.New("cannot delete user")
-	ErrUserChangePasswordFailed = errors.New("changing password failed")
+	ErrUserUnauthorized         = apperror.Unauthorized(apperror.CodeUnauthorized, "user is unauthorized")
+	ErrUserTokenInvalid         = apperror.Unauthorized("invalid_token", "invalid or expired token")
+	ErrUserMissingAuthHeader    = apperror.Unauthorized("missing_authorization", "missing authorization header")
+	ErrUserInvalidAuthHeader    = apperror.Unauthorized("invalid_authorization", "invalid authorization header")
+	ErrUserMissingJWTSecret     = apperror.Internal(apperror.CodeInternal, "missing JWT_SECRET")
+	ErrUserInvalidInput         = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrUserSignInFailed         = apperror.Unauthorized("sign_in_failed", "user sign in failed")
+	ErrUserSignUpFailed         = apperror.Internal(apperror.CodeInternal, "user sign up failed")
+	ErrGetUserFailed            = apperror.Internal(apperror.CodeInternal, "cannot get user with provided credentials")
+	ErrUserDeleteFailed         = apperror.Internal(apperror.CodeInternal, "cannot delete user")
+	ErrUserChangePasswordFailed = apperror.Internal(apperror.CodeInternal, "changing password failed")
+	ErrUserUpdateSettingsFailed = apperror.Internal(apperror.CodeInternal, "updating settings failed")
+	ErrUserEmailTaken           = apperror.Conflict("email_taken", "user with this email already exists")
+	ErrUserEmailNotFound        = apperror.Unauthorized("unknown_email", "User with provided email does not exist")
 )
 
 // UserInput Input for user API routes
 type UserInput struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" normalize:"trim" validate:"required,max=254,email"`
+	Password string `json:"password" validate:"required,max=72,password"`
 }
 
 // ChangePasswordInput Input for changing password
 type ChangePasswordInput struct {
-	OldPassword string `json:"old_password"`
-	NewPassword string `json:"new_password"`
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=72,password,nefield=OldPassword"`
+}
+
+// UserSettingsInput Input for changing the preferences of the user
+type UserSettingsInput struct {
+	TimeZone string `json:"time_zone" normalize:"trim" validate:"required,timezone"`
 }
 
 type UserService struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	transactor repository.Transactor
+	audit      *auditRecorder
+	clock      clock.Clock
+	metrics    *metrics.Metrics
 }
 
 const userServiceLogPrefix = "UserService"
 
-func NewUserService() *UserService {
-	repo := repository.NewUserRepository()
-	return &UserService{repo: repo}
+func NewUserService(repositories *repository.Repositories, clock clock.Clock, metrics *metrics.Metrics) *UserService {
+	return &UserService{
+		repo:       repositories.Users,
+		transactor: repositories.Transactor,
+		audit:      newAuditRecorder(repositories, clock),
+		clock:      clock,
+		metrics:    metrics,
+	}
 }
 
 func (userService *UserService) Signup(ctx context.Context, input UserInput) (*model.User, error) {
-	err := input.validateUserInput()
-	if err != nil {
-		return nil, WrapPublicMessage(err, err.Error())
+	ctx, span := tracing.Start(ctx, "UserService.Signup")
+	defer span.End()
+
+	user, err := userService.signup(ctx, input)
+	userService.metrics.ObserveSignup(err)
+	tracing.RecordError(span, err)
+	return user, err
+}
+
+func (userService *UserService) signup(ctx context.Context, input UserInput) (*model.User, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
 	}
 	existing, _ := userService.repo.GetByEmail(ctx, input.Email)
 	if existing != nil {
-		message := fmt.Sprintf("User with email %s already exists", input.Email)
-
-		err = errors.New(message)
-		return nil, WrapPublicMessage(err, message)
+		return nil, ErrUserEmailTaken.WithMessage(fmt.Sprintf("User with email %s already exists", input.Email))
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
@@ -69,38 +96,59 @@ func (userService *UserService) Signup(ctx context.Context, input UserInput) (*m
 		return nil, errors.Errorf("%v", err)
 	}
 
+	now := userService.clock.Now()
 	user := &model.User{
 		ID:        uuid.New(),
 		Email:     input.Email,
 		Password:  string(hashedPassword),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	if err := userService.repo.Create(ctx, user); err != nil {
+		TimeZone:  model.DefaultTimeZone,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// the new user is the actor of their own signup
+	ctx = requestctx.WithActor(ctx, user.ID.String())
+	err = userService.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := userService.repo.Create(ctx, user); err != nil {
+			return err
+		}
+		return userService.audit.record(ctx, model.AuditCreate, model.AuditEntityUser, user.ID, user.ID, nil, user)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
 func (userService *UserService) Signin(ctx context.Context, input UserInput) (*model.User, error) {
-	err := input.validateUserInput()
-	if err != nil {
-		return nil, err
+	ctx, span := tracing.Start(ctx, "UserService.Signin")
+	defer span.End()
+
+	user, err := userService.signin(ctx, input)
+	userService.metrics.ObserveSignin(err)
+	tracing.RecordError(span, err)
+	return user, err
+}
 
+func (userService *UserService) signin(ctx context.Context, input UserInput) (*model.User, error) {
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
 	}
 	user, err := userService.repo.GetByEmail(ctx, input.Email)
 	if err != nil {
-		return nil, WrapPublicMessage(err, "User with provided email does not exist")
+		return nil, ErrUserEmailNotFound.Wrap(err)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
-		return nil, errors.Errorf("%v", err)
+		return nil, ErrUserSignInFailed.Wrap(err)
 	}
 	return user, nil
 }
 
 func (userService *UserService) GetUser(ctx context.Context, userId string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
 	user, err := userService.repo.GetByID(ctx, userId)
 	if err != nil {
 		return nil, err
@@ -109,13 +157,11 @@ func (userService *UserService) GetUser(ctx context.Context, userId string) (*mo
 }
 
 func (userService *UserService) ChangePassword(ctx context.Context, userID string, input ChangePasswordInput) error {
-	if input.OldPassword == "" || input.NewPassword == "" {
-		err := errors.New("both old and new passwords are required")
-		return WrapPublicMessage(err, err.Error())
-	}
-	if input.OldPassword == input.NewPassword {
-		err := errors.New("Old password must not be same as a new one")
-		return WrapPublicMessage(err, err.Error())
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return err
 	}
 
 	user, err := userService.repo.GetByID(ctx, userID)
@@ -124,7 +170,7 @@ func (userService *UserService) ChangePassword(ctx context.Context, userID strin
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.OldPassword)); err != nil {
-		return errors.Errorf("%v", err)
+		return apperror.Validation(apperror.FieldError{Field: "old_password", Message: "does not match"}).Wrap(err)
 	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.DefaultCost)
@@ -132,33 +178,64 @@ func (userService *UserService) ChangePassword(ctx context.Context, userID strin
 		return errors.Errorf("%v", err)
 	}
 
+	before := *user
 	user.Password = string(hashed)
-	user.UpdatedAt = time.Now()
-
-	return userService.repo.Update(ctx, user)
+	user.UpdatedAt = userService.clock.Now()
+
+	return userService.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := userService.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		return userService.audit.record(ctx, model.AuditUpdate, model.AuditEntityUser, user.ID, user.ID, &before, user)
+	})
 }
 
-func (userService *UserService) Delete(ctx context.Context, userId string) error {
-	user, err := userService.repo.GetByID(ctx, userId)
-	if err != nil {
-		return err
+// UpdateSettings stores the preferences of the user, the time zone decides where their days start
+func (userService *UserService) UpdateSettings(
+	ctx context.Context,
+	userID string,
+	input UserSettingsInput,
+) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateSettings")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
 	}
-	err = userService.repo.Delete(ctx, user)
+
+	user, err := userService.repo.GetByID(ctx, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
-}
 
-// validation of user input
-func (input *UserInput) validateUserInput() error {
-	err := validator.ValidateEmail(input.Email)
+	before := *user
+	user.TimeZone = input.TimeZone
+	user.UpdatedAt = userService.clock.Now()
+
+	err = userService.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := userService.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		return userService.audit.record(ctx, model.AuditUpdate, model.AuditEntityUser, user.ID, user.ID, &before, user)
+	})
 	if err != nil {
-		return errors.New("invalid email")
+		return nil, err
 	}
-	err = validator.ValidatePassword(input.Password)
+	return user, nil
+}
+
+func (userService *UserService) Delete(ctx context.Context, userId string) error {
+	ctx, span := tracing.Start(ctx, "UserService.Delete")
+	defer span.End()
+
+	user, err := userService.repo.GetByID(ctx, userId)
 	if err != nil {
-		return errors.Errorf("%v", err)
+		return err
 	}
-	return nil
+	return userService.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := userService.repo.Delete(ctx, user); err != nil {
+			return err
+		}
+		return userService.audit.record(ctx, model.AuditDelete, model.AuditEntityUser, user.ID, user.ID, user, nil)
+	})
 }
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const webhookRequestTimeout = 10 * time.Second
+
+// newWebhookClient returns the client deliveries are sent with. Unless allowPrivateNetworks is set it refuses
+// to connect to addresses that are not public, the check runs on the resolved address of every connection so
+// a DNS name cannot be rebound to an internal host after the URL was validated. Redirects are not followed,
+// a 3xx response counts as a failed attempt.
+func newWebhookClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect on our behalf and bypass the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookRequestTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// rejectPrivateAddress is a net.Dialer Control function, it runs after name resolution for every address dialled
+func rejectPrivateAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddress(ip) {
+		return fmt.Errorf("webhook address %s is not public", ip)
+	}
+	return nil
+}
+
+// nonPublicPrefixes are the special purpose ranges the netip predicates do not cover: "this network",
+// carrier-grade NAT, IETF protocol assignments and NAT64, which reaches IPv4 hosts through a local translator
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublicAddress reports whether ip is routable on the internet: not loopback, private, link-local,
+// multicast, unspecified or in nonPublicPrefixes. IPv4 addresses mapped to IPv6 are checked as IPv4.
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// isPublicURL rejects URLs naming a host that is not public outright, localhost or an IP literal.
+// Other names are only known once resolved, the webhook client checks them when it connects.
+func isPublicURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return isPublicAddress(ip)
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+func TestWebhookClientRefusesPrivateAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	response, err := newWebhookClient(false).Post(receiver.URL, "application/json", nil)
+	if err == nil {
+		_ = response.Body.Close()
+		t.Fatalf("delivery to %s succeeded, want it refused", receiver.URL)
+	}
+	if !strings.Contains(err.Error(), "is not public") {
+		t.Fatalf("delivery failed with %v, want the address check", err)
+	}
+
+	response, err = newWebhookClient(true).Post(receiver.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("delivery with private networks allowed failed: %v", err)
+	}
+	_ = response.Body.Close()
+}
+
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	response, err := newWebhookClient(true).Post(redirect.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("delivery failed: %v", err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusTemporaryRedirect || followed {
+		t.Fatalf("got status %d and followed %t, want the redirect returned unfollowed", response.StatusCode, followed)
+	}
+}
+
+func TestIsPublicURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/hook":         true,
+		"https://93.184.216.34/hook":       true,
+		"https://[2606:4700::1111]/hook":   true,
+		"http://localhost:8080/hook":       false,
+		"http://api.localhost/hook":        false,
+		"http://127.0.0.1/hook":            false,
+		"http://10.0.0.5/hook":             false,
+		"http://172.16.0.1/hook":           false,
+		"http://192.168.1.1/hook":          false,
+		"http://169.254.169.254/metadata":  false,
+		"http://0.0.0.0/hook":              false,
+		"http://[::1]/hook":                false,
+		"http://[fe80::1]/hook":            false,
+		"http://[fd00::1]/hook":            false,
+		"http://[::ffff:127.0.0.1]/hook":   false,
+		"http://0.1.2.3/hook":              false,
+		"http://100.64.0.1/hook":           false,
+		"http://100.127.255.254/hook":      false,
+		"http://100.128.0.1/hook":          true,
+		"http://192.0.0.8/hook":            false,
+		"http://192.0.2.1/hook":            true,
+		"http://[64:ff9b::a00:1]/hook":     false,
+		"http://[64:ff9b::5db8:d822]/hook": false,
+	}
+	for rawURL, want := range cases {
+		if got := isPublicURL(rawURL); got != want {
+			t.Errorf("isPublicURL(%q) = %t, want %t", rawURL, got, want)
+		}
+	}
+	if isPublicAddress(netip.Addr{}) {
+		t.Error("the zero address is public")
+	}
+}
+
+func TestWebhookServiceRejectsPrivateURLs(t *testing.T) {
+	webhookService := NewWebhookService(&repository.Repositories{}, config.Webhooks{}, clock.Real())
+	userID := "6f1c1a5e-0000-4000-8000-00000000000a"
+	privateURL := "http://169.254.169.254/latest/meta-data"
+
+	_, err := webhookService.Create(context.Background(), userID, WebhookInput{
+		URL:        privateURL,
+		EventTypes: []string{"task.created"},
+	})
+	if !errors.Is(err, ErrWebhookURLNotPublic) {
+		t.Fatalf("Create returned %v, want %v", err, ErrWebhookURLNotPublic)
+	}
+	_, err = webhookService.Update(context.Background(), 1, userID, UpdateWebhookInput{URL: &privateURL})
+	if !errors.Is(err, ErrWebhookURLNotPublic) {
+		t.Fatalf("Update returned %v, want %v", err, ErrWebhookURLNotPublic)
+	}
+}
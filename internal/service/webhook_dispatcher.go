@@ -0,0 +1,293 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/metrics"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+)
+
+const (
+	WebhookEventHeader     = "X-Timekeeper-Event"
+	WebhookDeliveryHeader  = "X-Timekeeper-Delivery"
+	WebhookTimestampHeader = "X-Timekeeper-Timestamp"
+	WebhookSignatureHeader = "X-Timekeeper-Signature"
+
+	webhookDispatcherLogPrefix = "WebhookDispatcher"
+	webhookBatchSize           = 50
+	webhookWorkers             = 8
+	webhookResponseBodyLimit   = 64 << 10
+)
+
+// WebhookDispatcher turns relayed events into persisted deliveries and sends them
+// to subscribers with exponential backoff until they succeed or run out of attempts.
+// Several instances may run at once, each delivery is leased to one of them while it is sent.
+type WebhookDispatcher struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	wake             chan struct{}
+	client           *http.Client
+	clock            clock.Clock
+	logger           logs.Logger
+	metrics          *metrics.Metrics
+
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	PollInterval time.Duration
+	// Lease is how long a claimed delivery is hidden from other dispatchers, it has to outlast sending a batch
+	Lease time.Duration
+}
+
+func NewWebhookDispatcher(
+	repositories *repository.Repositories,
+	cfg config.Webhooks,
+	clock clock.Clock,
+	logger logs.Logger,
+	metrics *metrics.Metrics,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscriptionRepo: repositories.WebhookSubscriptions,
+		deliveryRepo:     repositories.WebhookDeliveries,
+		wake:             make(chan struct{}, 1),
+		client:           newWebhookClient(cfg.AllowPrivateNetworks),
+		clock:            clock,
+		logger:           logger.With("component", webhookDispatcherLogPrefix),
+		metrics:          metrics,
+		MaxAttempts:      8,
+		BaseBackoff:      10 * time.Second,
+		MaxBackoff:       time.Hour,
+		PollInterval:     2 * time.Second,
+		Lease:            5 * time.Minute,
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled, it returns once the run in progress is done
+func (dispatcher *WebhookDispatcher) Run(ctx context.Context) {
+	ctx = logs.WithContext(ctx, dispatcher.logger)
+	ticker := time.NewTicker(dispatcher.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-dispatcher.wake:
+		}
+		// a run in progress is finished on shutdown, cancelling it would count as failed deliveries
+		started := time.Now()
+		err := dispatcher.DeliverDue(context.WithoutCancel(ctx))
+		dispatcher.metrics.ObserveJob(metrics.JobWebhookDelivery, started, err)
+		if err != nil {
+			dispatcher.logger.Error("deliver due webhooks failed", "error", err)
+		}
+	}
+}
+
+// Enqueue stores a pending delivery for every active subscription of the event owner that wants the event.
+// It is an OutboxHandler: a redelivered event does not create a second delivery for the same subscription.
+func (dispatcher *WebhookDispatcher) Enqueue(ctx context.Context, published event.Event) error {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", published.UserID),
+			gormquery.NewFilter("is_active", "=", true),
+		),
+	}
+	subscriptions, err := dispatcher.subscriptionRepo.GetFilteredSubscriptions(ctx, filters, nil)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(string(published.Type)) {
+			continue
+		}
+		enqueued, err := dispatcher.isEnqueued(ctx, subscription.ID, published.ID)
+		if err != nil {
+			return err
+		}
+		if enqueued {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(published)
+			if err != nil {
+				return fmt.Errorf("%s marshal event failed: %w", webhookDispatcherLogPrefix, err)
+			}
+		}
+		delivery := &model.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			UserID:         subscription.UserID,
+			EventID:        published.ID,
+			EventType:      string(published.Type),
+			Payload:        payload,
+			Status:         model.DeliveryPending,
+			NextAttemptAt:  dispatcher.clock.Now(),
+			CreatedAt:      dispatcher.clock.Now(),
+			UpdatedAt:      dispatcher.clock.Now(),
+		}
+		if err := dispatcher.deliveryRepo.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case dispatcher.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// DeliverDue claims a batch of the pending deliveries whose next attempt is due and sends them
+func (dispatcher *WebhookDispatcher) DeliverDue(ctx context.Context) error {
+	now := dispatcher.clock.Now()
+	deliveries, err := dispatcher.deliveryRepo.ClaimDue(ctx, now, now.Add(dispatcher.Lease), webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	workers := make(chan struct{}, webhookWorkers)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func(delivery *model.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			if err := dispatcher.deliver(ctx, delivery); err != nil {
+				dispatcher.logger.Warn("webhook delivery failed", "delivery_id", delivery.ID, "error", err)
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+	return nil
+}
+
+func (dispatcher *WebhookDispatcher) isEnqueued(ctx context.Context, subscriptionID uint64, eventID string) (bool, error) {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("subscription_id", "=", subscriptionID),
+			gormquery.NewFilter("event_id", "=", eventID),
+		),
+	}
+	options := &gormquery.QueryOptions{Limit: gormquery.IntPtr(1)}
+	deliveries, err := dispatcher.deliveryRepo.GetFilteredDeliveries(ctx, filters, options)
+	if err != nil {
+		return false, err
+	}
+	return len(deliveries) > 0, nil
+}
+
+func (dispatcher *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) error {
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("id", "=", delivery.SubscriptionID),
+		),
+	}
+	subscription, err := dispatcher.subscriptionRepo.GetByID(ctx, filters)
+	if err == nil && !subscription.IsActive {
+		// deliveries queued before the subscription was deactivated are given up without being sent
+		message := "subscription is inactive"
+		delivery.Status = model.DeliveryFailed
+		delivery.LastError = &message
+		delivery.UpdatedAt = dispatcher.clock.Now()
+		return dispatcher.deliveryRepo.Update(ctx, delivery)
+	}
+
+	// a failed lookup of the subscription counts as a failed attempt, so it is retried with backoff as well
+	var responseCode int
+	if err == nil {
+		responseCode, err = dispatcher.send(ctx, subscription, delivery)
+		dispatcher.metrics.ObserveWebhookDelivery(err)
+	}
+	now := dispatcher.clock.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	if responseCode != 0 {
+		delivery.LastResponseCode = &responseCode
+	}
+	if err == nil {
+		delivery.Status = model.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	} else {
+		message := err.Error()
+		delivery.LastError = &message
+		if delivery.Attempts >= dispatcher.MaxAttempts {
+			delivery.Status = model.DeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(dispatcher.backoff(delivery.Attempts))
+		}
+	}
+	return dispatcher.deliveryRepo.Update(ctx, delivery)
+}
+
+func (dispatcher *WebhookDispatcher) send(
+	ctx context.Context,
+	subscription *model.WebhookSubscription,
+	delivery *model.WebhookDelivery,
+) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := dispatcher.clock.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GoTimekeeper-Webhook/1.0")
+	request.Header.Set(WebhookEventHeader, delivery.EventType)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := dispatcher.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseBodyLimit))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// backoff doubles the delay after every failed attempt up to MaxBackoff
+func (dispatcher *WebhookDispatcher) backoff(attempts int) time.Duration {
+	delay := dispatcher.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= dispatcher.MaxBackoff {
+			return dispatcher.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// SignWebhookPayload returns the signature header value: sha256=hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// Receivers recompute it with the shared secret and the X-Timekeeper-Timestamp header.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
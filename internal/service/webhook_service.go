@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/gormquery"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"github.com/advanced-coder-com/go-timekeeper/internal/tracing"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookCreateFailed    = apperror.Internal(apperror.CodeInternal, "failed to create webhook")
+	ErrWebhookUpdateFailed    = apperror.Internal(apperror.CodeInternal, "failed to update webhook")
+	ErrWebhookDeleteFailed    = apperror.Internal(apperror.CodeInternal, "failed to delete webhook")
+	ErrWebhookGetFailed       = apperror.Internal(apperror.CodeInternal, "failed to get webhook(s)")
+	ErrWebhookRedeliverFailed = apperror.Internal(apperror.CodeInternal, "failed to redeliver webhook")
+	ErrWebhookInvalidInput    = apperror.BadRequest(apperror.CodeInvalidInput, "invalid input")
+	ErrWebhookURLNotPublic    = apperror.BadRequest(apperror.CodeInvalidInput, "webhook url must point to a public address")
+)
+
+// WebhookInput Input for creating a webhook subscription, an empty secret is generated
+type WebhookInput struct {
+	URL        string   `json:"url" normalize:"trim" validate:"required,max=2048,http_url"`
+	Secret     string   `json:"secret" validate:"max=256"`
+	EventTypes []string `json:"event_types" normalize:"trim" validate:"required,min=1,max=20,dive,event_type"`
+}
+
+type UpdateWebhookInput struct {
+	URL        *string   `json:"url" normalize:"trim" validate:"omitnil,min=1,max=2048,http_url"`
+	Secret     *string   `json:"secret" validate:"omitnil,min=1,max=256"`
+	EventTypes *[]string `json:"event_types" normalize:"trim" validate:"omitnil,min=1,max=20,dive,event_type"`
+	IsActive   *bool     `json:"is_active"`
+}
+
+// CreatedWebhook is returned once on creation, it is the only response exposing the signing secret
+type CreatedWebhook struct {
+	model.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+type WebhookService struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	clock            clock.Clock
+	// allowPrivateNetworks accepts URLs of loopback and private hosts, see config.Webhooks
+	allowPrivateNetworks bool
+}
+
+const (
+	webhookServiceLogPrefix = "WebhookService"
+	webhookSecretBytes      = 32
+)
+
+func NewWebhookService(
+	repositories *repository.Repositories,
+	cfg config.Webhooks,
+	clock clock.Clock,
+) *WebhookService {
+	return &WebhookService{
+		subscriptionRepo:     repositories.WebhookSubscriptions,
+		deliveryRepo:         repositories.WebhookDeliveries,
+		clock:                clock,
+		allowPrivateNetworks: cfg.AllowPrivateNetworks,
+	}
+}
+
+func (webhookService *WebhookService) Create(
+	ctx context.Context,
+	userID string,
+	input WebhookInput,
+) (*CreatedWebhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if err := webhookService.checkURL(input.URL); err != nil {
+		return nil, err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := &model.WebhookSubscription{
+		UserID:     uuid.MustParse(userID),
+		URL:        input.URL,
+		Secret:     secret,
+		EventTypes: input.EventTypes,
+		IsActive:   true,
+		CreatedAt:  webhookService.clock.Now(),
+		UpdatedAt:  webhookService.clock.Now(),
+	}
+	if err := webhookService.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return &CreatedWebhook{WebhookSubscription: *subscription, Secret: secret}, nil
+}
+
+func (webhookService *WebhookService) GetAllByUser(ctx context.Context, userID string) ([]model.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetAllByUser")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	return webhookService.subscriptionRepo.GetFilteredSubscriptions(ctx, filters, nil)
+}
+
+func (webhookService *WebhookService) GetByID(
+	ctx context.Context,
+	id uint64,
+	userID string,
+) (*model.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetByID")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("id", "=", id),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	return webhookService.subscriptionRepo.GetByID(ctx, filters)
+}
+
+func (webhookService *WebhookService) Update(
+	ctx context.Context,
+	id uint64,
+	userID string,
+	input UpdateWebhookInput,
+) (*model.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Update")
+	defer span.End()
+
+	if err := validator.Struct(&input); err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if err := webhookService.checkURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	subscription, err := webhookService.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		subscription.URL = *input.URL
+	}
+	if input.Secret != nil {
+		subscription.Secret = *input.Secret
+	}
+	if input.EventTypes != nil {
+		subscription.EventTypes = *input.EventTypes
+	}
+	if input.IsActive != nil {
+		subscription.IsActive = *input.IsActive
+	}
+	subscription.UpdatedAt = webhookService.clock.Now()
+
+	if err := webhookService.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (webhookService *WebhookService) Delete(ctx context.Context, id uint64, userID string) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	subscription, err := webhookService.GetByID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	return webhookService.subscriptionRepo.Delete(ctx, subscription)
+}
+
+// GetDeliveries returns the delivery log of a subscription, newest first
+func (webhookService *WebhookService) GetDeliveries(
+	ctx context.Context,
+	subscriptionID uint64,
+	userID string,
+) ([]model.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetDeliveries")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("subscription_id", "=", subscriptionID),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	options := &gormquery.QueryOptions{
+		OrderBy: []gormquery.OrderOption{{Field: "id", Direction: "DESC"}},
+	}
+	return webhookService.deliveryRepo.GetFilteredDeliveries(ctx, filters, options)
+}
+
+// Redeliver queues a new delivery of the payload of an earlier one, the original log entry is kept untouched
+func (webhookService *WebhookService) Redeliver(
+	ctx context.Context,
+	deliveryID uint64,
+	userID string,
+) (*model.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	defer span.End()
+
+	filters := []gormquery.FilterGroup{
+		gormquery.NewFilterGroup(
+			gormquery.NewFilter("id", "=", deliveryID),
+			gormquery.NewFilter("user_id", "=", userID),
+		),
+	}
+	original, err := webhookService.deliveryRepo.GetByID(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &model.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		UserID:         original.UserID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         model.DeliveryPending,
+		NextAttemptAt:  webhookService.clock.Now(),
+		CreatedAt:      webhookService.clock.Now(),
+		UpdatedAt:      webhookService.clock.Now(),
+	}
+	if err := webhookService.deliveryRepo.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// checkURL rejects URLs that visibly point into the private network, the dispatcher checks the resolved address as well
+func (webhookService *WebhookService) checkURL(rawURL string) error {
+	if webhookService.allowPrivateNetworks || isPublicURL(rawURL) {
+		return nil
+	}
+	return ErrWebhookURLNotPublic
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("%s generate secret failed: %w", webhookServiceLogPrefix, err)
+	}
+	return hex.EncodeToString(secret), nil
+}
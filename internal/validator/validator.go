@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	playground "github.com/go-playground/validator/v10"
+)
+
+// Input structs declare their rules in `validate` tags (go-playground/validator syntax plus the
+// custom rules below) and string fields tagged `normalize:"trim"` are trimmed before validation.
+const (
+	validateTag  = "validate"
+	normalizeTag = "normalize"
+)
+
+var (
+	validate  = newValidate()
+	tagFormat = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_.\-]*$`)
+)
+
+func newValidate() *playground.Validate {
+	engine := playground.New()
+	engine.SetTagName(validateTag)
+	engine.RegisterTagNameFunc(jsonName)
+
+	mustRegister(engine, "password", func(field playground.FieldLevel) bool {
+		return ValidatePassword(field.Field().String()) == nil
+	})
+	mustRegister(engine, "name", func(field playground.FieldLevel) bool {
+		return isName(field.Field().String())
+	})
+	mustRegister(engine, "tag", func(field playground.FieldLevel) bool {
+		return tagFormat.MatchString(field.Field().String())
+	})
+	mustRegister(engine, "task_status", func(field playground.FieldLevel) bool {
+		return model.IsValidTaskStatus(field.Field().String())
+	})
+	mustRegister(engine, "http_url", func(field playground.FieldLevel) bool {
+		parsed, err := url.Parse(field.Field().String())
+		return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+	})
+	mustRegister(engine, "event_type", func(field playground.FieldLevel) bool {
+		return event.IsValidType(field.Field().String())
+	})
+	return engine
+}
+
+func mustRegister(engine *playground.Validate, tag string, rule playground.Func) {
+	if err := engine.RegisterValidation(tag, rule); err != nil {
+		panic(err)
+	}
+}
+
+// Struct normalizes and validates the struct input points to,
+// all violations are returned at once as an apperror validation error
+func Struct(input any) error {
+	normalize(reflect.ValueOf(input))
+
+	err := validate.Struct(input)
+	var violations playground.ValidationErrors
+	if !errors.As(err, &violations) {
+		return err
+	}
+
+	fields := make([]apperror.FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fields = append(fields, apperror.FieldError{
+			Field:   fieldName(violation),
+			Message: message(violation),
+		})
+	}
+	return apperror.Validation(fields...)
+}
+
+// normalize trims the string, *string, []string and *[]string fields tagged normalize:"trim"
+func normalize(value reflect.Value) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structField := value.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		if structField.Tag.Get(normalizeTag) == "trim" {
+			trim(field)
+			continue
+		}
+		if structField.Anonymous {
+			normalize(field.Addr())
+		}
+	}
+}
+
+func trim(field reflect.Value) {
+	switch field.Kind() {
+	case reflect.Pointer:
+		if !field.IsNil() {
+			trim(field.Elem())
+		}
+	case reflect.String:
+		field.SetString(strings.TrimSpace(field.String()))
+	case reflect.Slice:
+		for i := 0; i < field.Len(); i++ {
+			trim(field.Index(i))
+		}
+	}
+}
+
+// isName accepts printable text without line breaks, the rule of all user given names
+func isName(value string) bool {
+	for _, char := range value {
+		if !unicode.IsPrint(char) {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+// fieldName is the JSON path of the violation without the struct name, e.g. tags[2]
+func fieldName(violation playground.FieldError) string {
+	_, path, found := strings.Cut(violation.Namespace(), ".")
+	if !found {
+		return violation.Field()
+	}
+	return path
+}
+
+func message(violation playground.FieldError) string {
+	kind := violation.Kind()
+	switch violation.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if kind == reflect.String && violation.Param() == "1" {
+			return "must not be empty"
+		}
+		return sizeMessage("at least", violation.Param(), kind)
+	case "max":
+		return sizeMessage("at most", violation.Param(), kind)
+	case "email":
+		return "must be a valid email address"
+	case "http_url":
+		return "must be an absolute http or https url"
+	case "event_type":
+		return fmt.Sprintf("unknown event type %q", violation.Value())
+	case "nefield":
+		return "must differ from " + jsonFieldOf(violation.Param())
+	case "password":
+		return ValidatePassword(fmt.Sprint(violation.Value())).Error()
+	case "name":
+		return "must not contain line breaks or control characters"
+	case "tag":
+		return "must start with a letter or digit and contain only letters, digits, '_', '.' and '-'"
+	case "task_status":
+		return fmt.Sprintf("must be one of %q, %q or %q", model.StatusOpened, model.StatusWorkingOn, model.StatusClosed)
+	case "timezone":
+		return "must be an IANA time zone such as \"Europe/Berlin\""
+	default:
+		return "is invalid"
+	}
+}
+
+func sizeMessage(bound string, param string, kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("must be %s %s characters long", bound, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("must have %s %s items", bound, param)
+	default:
+		return fmt.Sprintf("must be %s %s", bound, param)
+	}
+}
+
+// jsonFieldOf converts the Go field name of a cross-field rule to its JSON name
+func jsonFieldOf(goName string) string {
+	var snake strings.Builder
+	for i, char := range goName {
+		if unicode.IsUpper(char) {
+			if i > 0 {
+				snake.WriteByte('_')
+			}
+			char = unicode.ToLower(char)
+		}
+		snake.WriteRune(char)
+	}
+	return snake.String()
+}
@@ -0,0 +1,172 @@
+package validator_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/validator"
+)
+
+type Embedded struct {
+	Description string `json:"description" normalize:"trim" validate:"max=5"`
+}
+
+type label struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type input struct {
+	Embedded
+	Password  string    `json:"password" validate:"omitempty,password"`
+	Name      string    `json:"name" normalize:"trim" validate:"omitempty,min=1,name"`
+	Tags      *[]string `json:"tags" normalize:"trim" validate:"omitnil,max=3,dive,tag"`
+	Status    string    `json:"status" validate:"omitempty,task_status"`
+	URL       string    `json:"url" validate:"omitempty,http_url"`
+	EventType string    `json:"event_type" validate:"omitempty,event_type"`
+	TimeZone  string    `json:"time_zone" validate:"omitempty,timezone"`
+	Label     *label    `json:"label" validate:"omitnil"`
+	Labels    []label   `json:"labels" validate:"dive"`
+	Ignored   string    `json:"-" validate:"omitempty,email"`
+}
+
+func tags(values ...string) *[]string {
+	return &values
+}
+
+func TestStructRules(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  input
+		fields []string
+	}{
+		{"empty input", input{}, nil},
+		{"valid input", input{
+			Password:  "P@ssw0rd",
+			Name:      "Write tests",
+			Tags:      tags("go", "v1.2", "back-end_2"),
+			Status:    "Opened",
+			URL:       "https://example.com/hook",
+			EventType: "task.started",
+			TimeZone:  "Europe/Berlin",
+			Label:     &label{Text: "first"},
+			Labels:    []label{{Text: "second"}},
+		}, nil},
+		{"weak password", input{Password: "password"}, []string{"password"}},
+		{"name with a line break", input{Name: "two\nlines"}, []string{"name"}},
+		{"tag starting with a dash", input{Tags: tags("go", "ok", "-bad")}, []string{"tags[2]"}},
+		{"tag with a space", input{Tags: tags("two words")}, []string{"tags[0]"}},
+		{"too many tags", input{Tags: tags("a", "b", "c", "d")}, []string{"tags"}},
+		{"unknown status", input{Status: "Paused"}, []string{"status"}},
+		{"ftp url", input{URL: "ftp://example.com/hook"}, []string{"url"}},
+		{"relative url", input{URL: "/hook"}, []string{"url"}},
+		{"unknown event type", input{EventType: "task.paused"}, []string{"event_type"}},
+		{"unknown time zone", input{TimeZone: "Mars/Olympus"}, []string{"time_zone"}},
+		{"nested field", input{Label: &label{}}, []string{"label.text"}},
+		{"indexed nested field", input{Labels: []label{{Text: "ok"}, {}}}, []string{"labels[1].text"}},
+		{"every violation at once", input{Password: "short", Status: "Paused", TimeZone: "Nowhere"},
+			[]string{"password", "status", "time_zone"}},
+		{"field without a json name", input{Ignored: "not an email"}, []string{"Ignored"}},
+	}
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.Struct(&testCase.input)
+			if testCase.fields == nil {
+				if err != nil {
+					t.Fatalf("valid input was rejected: %v", err)
+				}
+				return
+			}
+			var validation *apperror.Error
+			if !errors.As(err, &validation) || validation.Code != apperror.CodeValidationFailed {
+				t.Fatalf("got %v, want a validation error", err)
+			}
+			fields := make([]string, 0, len(validation.Fields))
+			for _, field := range validation.Fields {
+				if field.Message == "" {
+					t.Errorf("%s has no message", field.Field)
+				}
+				fields = append(fields, field.Field)
+			}
+			if !slices.Equal(fields, testCase.fields) {
+				t.Fatalf("got field errors %v, want %v", validation.Fields, testCase.fields)
+			}
+		})
+	}
+}
+
+func TestStructMessages(t *testing.T) {
+	cases := []struct {
+		input   input
+		message string
+	}{
+		{input{Password: "password"}, "password must include at least one uppercase letter"},
+		{input{Name: "tab\there"}, "must not contain line breaks or control characters"},
+		{input{Tags: tags("-bad")}, "must start with a letter or digit"},
+		{input{Tags: tags("a", "b", "c", "d")}, "must have at most 3 items"},
+		{input{Status: "Paused"}, `must be one of "Opened"`},
+		{input{URL: "mailto:me@example.com"}, "must be an absolute http or https url"},
+		{input{EventType: "task.paused"}, `unknown event type "task.paused"`},
+		{input{TimeZone: "Mars/Olympus"}, "must be an IANA time zone"},
+		{input{Embedded: Embedded{Description: "too long"}}, "must be at most 5 characters long"},
+		{input{Label: &label{}}, "is required"},
+	}
+	for _, testCase := range cases {
+		var validation *apperror.Error
+		if err := validator.Struct(&testCase.input); !errors.As(err, &validation) || len(validation.Fields) != 1 {
+			t.Fatalf("%+v: got %v, want one field error", testCase.input, err)
+		}
+		if message := validation.Fields[0].Message; !strings.Contains(message, testCase.message) {
+			t.Errorf("%s: got message %q, want it to contain %q", validation.Fields[0].Field, message, testCase.message)
+		}
+	}
+}
+
+func TestStructNeFieldUsesJSONNames(t *testing.T) {
+	passwords := struct {
+		OldPassword string `json:"old_password" validate:"required"`
+		NewPassword string `json:"new_password" validate:"required,nefield=OldPassword"`
+	}{OldPassword: "P@ssw0rd", NewPassword: "P@ssw0rd"}
+
+	var validation *apperror.Error
+	if err := validator.Struct(&passwords); !errors.As(err, &validation) || len(validation.Fields) != 1 {
+		t.Fatalf("got %v, want one field error", err)
+	}
+	if field := validation.Fields[0]; field.Field != "new_password" || field.Message != "must differ from old_password" {
+		t.Fatalf("got %+v, want new_password to differ from old_password", field)
+	}
+}
+
+func TestStructTrimsTaggedFields(t *testing.T) {
+	value := input{
+		Embedded: Embedded{Description: "  four  "},
+		Name:     "  Write tests\t",
+		Tags:     tags(" go ", "\tv1\n"),
+		Status:   " Opened ",
+	}
+	err := validator.Struct(&value)
+
+	if value.Name != "Write tests" || value.Description != "four" || !slices.Equal(*value.Tags, []string{"go", "v1"}) {
+		t.Fatalf("tagged fields are %q, %q and %q, want them trimmed", value.Name, value.Description, *value.Tags)
+	}
+	// fields without the normalize tag are validated as sent
+	if value.Status != " Opened " {
+		t.Fatalf("untagged status was changed to %q", value.Status)
+	}
+	var validation *apperror.Error
+	if !errors.As(err, &validation) || len(validation.Fields) != 1 || validation.Fields[0].Field != "status" {
+		t.Fatalf("got %v, want only the untrimmed status rejected", err)
+	}
+}
+
+func TestStructTrimsBeforeValidating(t *testing.T) {
+	blank := struct {
+		Name string `json:"name" normalize:"trim" validate:"required"`
+	}{Name: " \t "}
+	var validation *apperror.Error
+	if err := validator.Struct(&blank); !errors.As(err, &validation) || validation.Fields[0].Field != "name" {
+		t.Fatalf("got %v, want the blank name rejected as missing", err)
+	}
+}
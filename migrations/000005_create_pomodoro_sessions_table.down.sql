@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pomodoro_sessions;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS pomodoro_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    time_record_id BIGINT REFERENCES time_records(id) ON DELETE SET NULL,
+    phase VARCHAR(20) NOT NULL CHECK (phase IN ('Focus', 'Short break', 'Long break')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('Running', 'Completed', 'Cancelled')),
+    planned_seconds INTEGER NOT NULL CHECK (planned_seconds > 0),
+    focus_seconds INTEGER NOT NULL,
+    short_break_seconds INTEGER NOT NULL,
+    long_break_seconds INTEGER NOT NULL,
+    long_break_every INTEGER NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_pomodoro_sessions_user_id_status ON pomodoro_sessions(user_id, status);
+CREATE INDEX idx_pomodoro_sessions_task_id ON pomodoro_sessions(task_id);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    event_id TEXT NOT NULL UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    user_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    dispatched_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(id) WHERE dispatched_at IS NULL;
+CREATE INDEX idx_outbox_messages_dispatched_at ON outbox_messages(dispatched_at);
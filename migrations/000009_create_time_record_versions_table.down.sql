@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS time_record_versions;
+ALTER TABLE time_records DROP COLUMN IF EXISTS description;
@@ -0,0 +1,20 @@
+ALTER TABLE time_records ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS time_record_versions (
+    id BIGSERIAL PRIMARY KEY,
+    time_record_id BIGINT NOT NULL,
+    version INTEGER NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID,
+    operation VARCHAR(20) NOT NULL CHECK (operation IN ('create', 'edit', 'stop', 'delete', 'restore', 'undo')),
+    task_id BIGINT NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP,
+    is_closed BOOLEAN NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (time_record_id, version)
+    );
+
+CREATE INDEX idx_time_record_versions_user_id ON time_record_versions(user_id, id);
@@ -0,0 +1,64 @@
+-- Timestamps written so far are wall clock times of the server, they are read in the zone of the session
+-- setting timekeeper.legacy_time_zone. The migrate subcommand and DB_AUTO_MIGRATE set it from
+-- DB_LEGACY_TIME_ZONE or -legacy-time-zone, other tools can pass it as a connection option
+-- (options=-ctimekeeper.legacy_time_zone=Europe/Berlin), it falls back to UTC.
+-- audit_logs stays append-only, its row trigger does not fire when ALTER TABLE rewrites the table.
+
+SELECT set_config(
+    'timekeeper.legacy_time_zone',
+    COALESCE(NULLIF(current_setting('timekeeper.legacy_time_zone', true), ''), 'UTC'),
+    false
+);
+
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE time_records
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE pomodoro_sessions
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ USING next_attempt_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN delivered_at TYPE TIMESTAMPTZ USING delivered_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE outbox_messages
+    ALTER COLUMN occurred_at TYPE TIMESTAMPTZ USING occurred_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN dispatched_at TYPE TIMESTAMPTZ USING dispatched_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE audit_logs
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE time_record_versions
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE api_keys
+    ALTER COLUMN last_used_at TYPE TIMESTAMPTZ USING last_used_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('timekeeper.legacy_time_zone');
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,10 @@
+-- messages that ran out of attempts were marked dispatched before
+UPDATE outbox_messages SET dispatched_at = failed_at WHERE failed_at IS NOT NULL AND dispatched_at IS NULL;
+
+DROP INDEX IF EXISTS idx_outbox_messages_pending_aggregate;
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(id) WHERE dispatched_at IS NULL;
+
+ALTER TABLE outbox_messages
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS next_attempt_at;
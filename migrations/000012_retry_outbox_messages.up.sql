@@ -0,0 +1,12 @@
+-- failed messages wait for next_attempt_at before they are retried, messages that ran out of attempts are
+-- kept with failed_at set instead of being marked dispatched. Dispatchers claim a message by moving
+-- next_attempt_at past the time it takes to relay it.
+ALTER TABLE outbox_messages
+    ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN failed_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(next_attempt_at, id)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_messages_pending_aggregate ON outbox_messages(aggregate_type, aggregate_id, id)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations of the database, db.NewMigrator applies them.
+package migrations
+
+import "embed"
+
+// Files are the numbered up and down migrations in the golang-migrate format, NNNNNN_name.up.sql and .down.sql
+//
+//go:embed *.sql
+var Files embed.FS
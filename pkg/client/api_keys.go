@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateAPIKey creates a key for scripts and integrations, the response is the only one holding the key
+func (client *Client) CreateAPIKey(ctx context.Context, input APIKeyInput) (*CreatedAPIKey, error) {
+	var apiKey CreatedAPIKey
+	if err := client.do(ctx, http.MethodPost, "/api/api-keys/create", nil, input, &apiKey); err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (client *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var apiKeys []APIKey
+	if err := client.do(ctx, http.MethodGet, "/api/api-keys/list", nil, nil, &apiKeys); err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (client *Client) DeleteAPIKey(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodDelete, idPath("/api/api-keys/delete", id), nil, nil, nil)
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListAuditLogs returns the audit log of the caller, newest first
+func (client *Client) ListAuditLogs(ctx context.Context, filter AuditFilter) ([]AuditLog, error) {
+	query := url.Values{}
+	if filter.EntityType != "" {
+		query.Set("entity_type", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query.Set("entity_id", filter.EntityID)
+	}
+	if filter.From != nil {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if filter.To != nil {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var auditLogs []AuditLog
+	if err := client.do(ctx, http.MethodGet, "/api/audit/list", query, nil, &auditLogs); err != nil {
+		return nil, err
+	}
+	return auditLogs, nil
+}
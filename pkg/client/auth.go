@@ -0,0 +1,56 @@
+package client
+
+import "net/http"
+
+// Authenticator adds credentials to the headers of every request
+type Authenticator interface {
+	Authenticate(header http.Header)
+}
+
+// BearerToken authenticates with a JWT returned by Signin or Signup
+type BearerToken string
+
+func (token BearerToken) Authenticate(header http.Header) {
+	header.Set("Authorization", "Bearer "+string(token))
+}
+
+// APIKeyAuth authenticates with a key created by CreateAPIKey
+type APIKeyAuth string
+
+func (key APIKeyAuth) Authenticate(header http.Header) {
+	header.Set(apiKeyHeader, string(key))
+}
+
+// WithAuth sets the authenticator used for every request
+func WithAuth(authenticator Authenticator) Option {
+	return func(client *Client) {
+		client.auth = authenticator
+	}
+}
+
+// WithToken authenticates requests with a JWT returned by Signin or Signup
+func WithToken(token string) Option {
+	return WithAuth(tokenAuth(token))
+}
+
+// WithAPIKey authenticates requests with an API key
+func WithAPIKey(key string) Option {
+	return WithAuth(APIKeyAuth(key))
+}
+
+// SetAuth replaces the authenticator, nil sends unauthenticated requests
+func (client *Client) SetAuth(authenticator Authenticator) {
+	client.auth = authenticator
+}
+
+func (client *Client) SetToken(token string) {
+	client.auth = tokenAuth(token)
+}
+
+// tokenAuth keeps an empty token unauthenticated, it is what a signed out config holds
+func tokenAuth(token string) Authenticator {
+	if token == "" {
+		return nil
+	}
+	return BearerToken(token)
+}
@@ -0,0 +1,138 @@
+// Package client is a Go client for the GoTimekeeper REST API.
+//
+// Request and response types mirror the JSON of the API, errors returned for
+// non 2xx responses are *APIError values that match ErrNotFound, ErrConflict
+// and the other sentinels with errors.Is:
+//
+//	tk := client.New("http://localhost:8080", client.WithAPIKey(os.Getenv("TK_API_KEY")))
+//	task, err := tk.GetTask(ctx, 42)
+//	if errors.Is(err, client.ErrNotFound) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Version of the client, sent in the User-Agent header
+const Version = "1.0.0"
+
+const (
+	defaultTimeout = 30 * time.Second
+	userAgent      = "go-timekeeper-client/" + Version
+	apiKeyHeader   = "X-API-Key"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	auth       Authenticator
+}
+
+type Option func(client *Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. http://localhost:8080
+func New(baseURL string, options ...Option) *Client {
+	client := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client
+}
+
+// do sends body as JSON and decodes the response into out, both may be nil
+func (client *Client) do(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+	endpoint := client.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("timekeeper: marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	client.setHeaders(request.Header)
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if err := checkResponse(response); err != nil {
+		return err
+	}
+
+	if out == nil || response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("timekeeper: decode response: %w", err)
+	}
+	return nil
+}
+
+func (client *Client) setHeaders(header http.Header) {
+	header.Set("User-Agent", userAgent)
+	if client.auth != nil {
+		client.auth.Authenticate(header)
+	}
+}
+
+// checkResponse turns a non 2xx response into an *APIError
+func checkResponse(response *http.Response) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	apiError := &APIError{StatusCode: response.StatusCode}
+	var errorBody struct {
+		Error  string         `json:"error"`
+		Code   ErrorCode      `json:"code"`
+		Fields []FieldError   `json:"fields"`
+		Errors []GraphQLError `json:"errors"`
+	}
+	if json.NewDecoder(response.Body).Decode(&errorBody) == nil {
+		apiError.Message = errorBody.Error
+		apiError.Code = errorBody.Code
+		apiError.Fields = errorBody.Fields
+		if apiError.Message == "" && len(errorBody.Errors) > 0 {
+			apiError.Message = errorBody.Errors[0].Message
+		}
+	}
+	return apiError
+}
+
+func idPath(prefix string, id uint64) string {
+	return fmt.Sprintf("%s/%d", prefix, id)
+}
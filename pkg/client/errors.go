@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors to match an APIError against with errors.Is, e.g. errors.Is(err, client.ErrNotFound)
+var (
+	ErrBadRequest    = errors.New("bad request")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrNotFound      = errors.New("not found")
+	ErrConflict      = errors.New("conflict")
+	ErrUnprocessable = errors.New("unprocessable entity")
+	ErrServer        = errors.New("server error")
+)
+
+// APIError is returned for every non 2xx response, Message is the "error" field of the body
+// or the first message of a rejected GraphQL request. Code is the machine readable error code
+// such as "not_found" or "time_record_already_active", Fields the invalid fields of a 422 response.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Code       ErrorCode
+	Fields     []FieldError
+}
+
+func (apiError *APIError) Error() string {
+	if apiError.Message == "" {
+		return fmt.Sprintf("timekeeper: %d %s", apiError.StatusCode, http.StatusText(apiError.StatusCode))
+	}
+	if len(apiError.Fields) == 0 {
+		return fmt.Sprintf("timekeeper: %d %s", apiError.StatusCode, apiError.Message)
+	}
+	fields := make([]string, 0, len(apiError.Fields))
+	for _, field := range apiError.Fields {
+		fields = append(fields, field.Field+": "+field.Message)
+	}
+	return fmt.Sprintf("timekeeper: %d %s (%s)", apiError.StatusCode, apiError.Message, strings.Join(fields, ", "))
+}
+
+// Is maps the status code to one of the sentinel errors
+func (apiError *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return apiError.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return apiError.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return apiError.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return apiError.StatusCode == http.StatusConflict
+	case ErrUnprocessable:
+		return apiError.StatusCode == http.StatusUnprocessableEntity
+	case ErrServer:
+		return apiError.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventStream delivers the domain events of the authenticated user until it is closed,
+// the server goes away or the context passed when opening it is done
+type EventStream struct {
+	next  func() (Event, error)
+	close func() error
+}
+
+// Next blocks until the next event arrives, it returns io.EOF once the server ends the stream
+func (stream *EventStream) Next() (Event, error) {
+	return stream.next()
+}
+
+func (stream *EventStream) Close() error {
+	return stream.close()
+}
+
+// Events opens the Server-Sent Events stream
+func (client *Client) Events(ctx context.Context) (*EventStream, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, client.baseURL+"/api/events/stream", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	client.setHeaders(request.Header)
+
+	// the stream is long lived, the timeout of the http client would cut it
+	streamClient := *client.httpClient
+	streamClient.Timeout = 0
+	response, err := streamClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(response); err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(response.Body)
+	return &EventStream{
+		next: func() (Event, error) {
+			return readServerSentEvent(reader)
+		},
+		close: response.Body.Close,
+	}, nil
+}
+
+// EventsWebSocket opens the WebSocket stream
+func (client *Client) EventsWebSocket(ctx context.Context) (*EventStream, error) {
+	endpoint := "ws" + strings.TrimPrefix(client.baseURL, "http") + "/api/events/ws"
+	header := http.Header{}
+	client.setHeaders(header)
+
+	connection, response, err := websocket.DefaultDialer.DialContext(ctx, endpoint, header)
+	if err != nil {
+		if response != nil {
+			defer response.Body.Close()
+			if apiError := checkResponse(response); apiError != nil {
+				return nil, apiError
+			}
+		}
+		return nil, err
+	}
+
+	// the connection is not bound to ctx once established, close it when ctx is done
+	stop := context.AfterFunc(ctx, func() { connection.Close() })
+	return &EventStream{
+		next: func() (Event, error) {
+			var message Event
+			if err := connection.ReadJSON(&message); err != nil {
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					return Event{}, io.EOF
+				}
+				return Event{}, err
+			}
+			return message, nil
+		},
+		close: func() error {
+			stop()
+			return connection.Close()
+		},
+	}, nil
+}
+
+// readServerSentEvent reads lines up to the blank line ending an event, comments are keep-alives
+func readServerSentEvent(reader *bufio.Reader) (Event, error) {
+	var data bytes.Buffer
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" {
+				return Event{}, io.EOF
+			}
+			if err != io.EOF {
+				return Event{}, err
+			}
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var message Event
+			if err := json.Unmarshal(data.Bytes(), &message); err != nil {
+				return Event{}, fmt.Errorf("timekeeper: decode event: %w", err)
+			}
+			return message, nil
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError is an error of a single field, Extensions["code"] is NOT_FOUND, BAD_USER_INPUT, CONFLICT or INTERNAL
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors are the field errors of an executed GraphQL request
+type GraphQLErrors []GraphQLError
+
+func (errors GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(errors))
+	for _, err := range errors {
+		messages = append(messages, err.Message)
+	}
+	return "timekeeper: graphql: " + strings.Join(messages, "; ")
+}
+
+// GraphQL runs a query or mutation and decodes its data into out. A request rejected before execution
+// is an *APIError with status 400. Field errors are returned as GraphQLErrors after decoding the data
+// of the other fields.
+func (client *Client) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	body := map[string]any{"query": query}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := client.do(ctx, http.MethodPost, "/api/graphql", nil, body, &response); err != nil {
+		return err
+	}
+	if out != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return fmt.Errorf("timekeeper: decode graphql data: %w", err)
+		}
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// StartPomodoro starts a focus session on a task, zero fields of input fall back to the server defaults
+func (client *Client) StartPomodoro(ctx context.Context, taskID uint64, input PomodoroInput) (*PomodoroSession, error) {
+	var session PomodoroSession
+	if err := client.do(ctx, http.MethodPost, idPath("/api/pomodoro/start", taskID), nil, input, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (client *Client) StopPomodoro(ctx context.Context) (*PomodoroSession, error) {
+	var session PomodoroSession
+	if err := client.do(ctx, http.MethodPost, "/api/pomodoro/stop", nil, nil, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (client *Client) PomodoroState(ctx context.Context) (*PomodoroState, error) {
+	var state PomodoroState
+	if err := client.do(ctx, http.MethodGet, "/api/pomodoro/state", nil, nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// PomodoroStats returns the completed focus sessions of the calendar day of date, which runs from
+// midnight to midnight in the time zone of the user
+func (client *Client) PomodoroStats(ctx context.Context, date time.Time) (*PomodoroDayStats, error) {
+	query := url.Values{}
+	query.Set("date", date.Format(time.DateOnly))
+
+	var stats PomodoroDayStats
+	if err := client.do(ctx, http.MethodGet, "/api/pomodoro/stats", query, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (client *Client) CreateProject(ctx context.Context, input ProjectInput) (*Project, error) {
+	var project Project
+	if err := client.do(ctx, http.MethodPost, "/api/projects/create", nil, input, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (client *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if err := client.do(ctx, http.MethodGet, "/api/projects/list", nil, nil, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (client *Client) GetProject(ctx context.Context, id uint64) (*Project, error) {
+	var project Project
+	if err := client.do(ctx, http.MethodGet, idPath("/api/projects/detail", id), nil, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (client *Client) RenameProject(ctx context.Context, id uint64, input ProjectInput) error {
+	return client.do(ctx, http.MethodPatch, idPath("/api/projects/update", id), nil, input, nil)
+}
+
+func (client *Client) DeleteProject(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodDelete, idPath("/api/projects/delete", id), nil, nil, nil)
+}
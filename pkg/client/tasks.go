@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (client *Client) CreateTask(ctx context.Context, input CreateTaskInput) (*Task, error) {
+	var task Task
+	if err := client.do(ctx, http.MethodPost, "/api/tasks/create", nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (client *Client) ListTasks(ctx context.Context) ([]Task, error) {
+	var tasks []Task
+	if err := client.do(ctx, http.MethodGet, "/api/tasks/list-all", nil, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListActiveTasks returns opened tasks and tasks being worked on
+func (client *Client) ListActiveTasks(ctx context.Context) ([]Task, error) {
+	var tasks []Task
+	if err := client.do(ctx, http.MethodGet, "/api/tasks/list-active", nil, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (client *Client) GetTask(ctx context.Context, id uint64) (*Task, error) {
+	var task Task
+	if err := client.do(ctx, http.MethodGet, idPath("/api/tasks/detail", id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (client *Client) UpdateTask(ctx context.Context, id uint64, input UpdateTaskInput) (*Task, error) {
+	var task Task
+	if err := client.do(ctx, http.MethodPatch, idPath("/api/tasks/update", id), nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (client *Client) DeleteTask(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodDelete, idPath("/api/tasks/delete", id), nil, nil, nil)
+}
+
+func (client *Client) StartTask(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodGet, idPath("/api/tasks/start", id), nil, nil, nil)
+}
+
+func (client *Client) StopTask(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodGet, idPath("/api/tasks/stop", id), nil, nil, nil)
+}
+
+func (client *Client) StopAllTasks(ctx context.Context) error {
+	return client.do(ctx, http.MethodGet, "/api/tasks/stop-all", nil, nil, nil)
+}
+
+func (client *Client) CloseTask(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodGet, idPath("/api/tasks/close", id), nil, nil, nil)
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CreateTimeRecord adds a manual entry
+func (client *Client) CreateTimeRecord(ctx context.Context, input CreateTimeRecordInput) (*TimeRecord, error) {
+	var timeRecord TimeRecord
+	if err := client.do(ctx, http.MethodPost, "/api/time-records/create", nil, input, &timeRecord); err != nil {
+		return nil, err
+	}
+	return &timeRecord, nil
+}
+
+func (client *Client) ListTimeRecords(ctx context.Context, filter TimeRecordFilter) ([]TimeRecord, error) {
+	query := url.Values{}
+	if filter.TaskID != nil {
+		query.Set("task_id", strconv.FormatUint(*filter.TaskID, 10))
+	}
+	if filter.From != nil {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if filter.To != nil {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+	if filter.FromDate != "" {
+		query.Set("from_date", filter.FromDate)
+	}
+	if filter.ToDate != "" {
+		query.Set("to_date", filter.ToDate)
+	}
+
+	var timeRecords []TimeRecord
+	if err := client.do(ctx, http.MethodGet, "/api/time-records/list", query, nil, &timeRecords); err != nil {
+		return nil, err
+	}
+	return timeRecords, nil
+}
+
+func (client *Client) GetTimeRecord(ctx context.Context, id uint64) (*TimeRecord, error) {
+	var timeRecord TimeRecord
+	if err := client.do(ctx, http.MethodGet, idPath("/api/time-records/detail", id), nil, nil, &timeRecord); err != nil {
+		return nil, err
+	}
+	return &timeRecord, nil
+}
+
+func (client *Client) UpdateTimeRecord(ctx context.Context, id uint64, input UpdateTimeRecordInput) (*TimeRecord, error) {
+	var timeRecord TimeRecord
+	if err := client.do(ctx, http.MethodPatch, idPath("/api/time-records/update", id), nil, input, &timeRecord); err != nil {
+		return nil, err
+	}
+	return &timeRecord, nil
+}
+
+func (client *Client) DeleteTimeRecord(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodDelete, idPath("/api/time-records/delete", id), nil, nil, nil)
+}
+
+// TimeRecordHistory returns every version of a time record, oldest first
+func (client *Client) TimeRecordHistory(ctx context.Context, id uint64) ([]TimeRecordVersion, error) {
+	var versions []TimeRecordVersion
+	if err := client.do(ctx, http.MethodGet, idPath("/api/time-records/history", id), nil, nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// RestoreTimeRecord brings a time record back to one of its versions, deleted records included
+func (client *Client) RestoreTimeRecord(ctx context.Context, id uint64, version int) (*TimeRecord, error) {
+	var timeRecord TimeRecord
+	input := RestoreTimeRecordInput{Version: version}
+	if err := client.do(ctx, http.MethodPost, idPath("/api/time-records/restore", id), nil, input, &timeRecord); err != nil {
+		return nil, err
+	}
+	return &timeRecord, nil
+}
+
+// UndoTimeRecord reverts the latest edit, stop or delete made by the caller in the last minutes
+func (client *Client) UndoTimeRecord(ctx context.Context) (*TimeRecord, error) {
+	var timeRecord TimeRecord
+	if err := client.do(ctx, http.MethodPost, "/api/time-records/undo", nil, nil, &timeRecord); err != nil {
+		return nil, err
+	}
+	return &timeRecord, nil
+}
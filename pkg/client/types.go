@@ -0,0 +1,324 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The types below mirror the JSON of the REST API. They are declared here rather than taken
+// from the server packages so the client only depends on the API, types_test.go keeps them in sync.
+
+type TaskStatus string
+
+const (
+	StatusOpened    TaskStatus = "Opened"
+	StatusWorkingOn TaskStatus = "Working on"
+	StatusClosed    TaskStatus = "Closed"
+)
+
+type Project struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Task struct {
+	ID        uint64     `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ProjectID uint64     `json:"project_id,omitempty"`
+	Name      string     `json:"name"`
+	Tags      []string   `json:"tags,omitempty"`
+	Status    TaskStatus `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type TimeRecord struct {
+	ID          uint64     `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	TaskID      uint64     `json:"task_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	IsClosed    bool       `json:"is_closed"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TimeRecordVersion is the state of a time record after one change, Operation is one of
+// create, edit, stop, delete, restore and undo
+type TimeRecordVersion struct {
+	ID           uint64     `json:"id"`
+	TimeRecordID uint64     `json:"time_record_id"`
+	Version      int        `json:"version"`
+	UserID       uuid.UUID  `json:"user_id"`
+	ActorID      *uuid.UUID `json:"actor_id,omitempty"`
+	Operation    string     `json:"operation"`
+	TaskID       uint64     `json:"task_id"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	IsClosed     bool       `json:"is_closed"`
+	Description  string     `json:"description"`
+	Deleted      bool       `json:"deleted"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// PomodoroSession is a focus interval or break, Phase is "Focus", "Short break" or "Long break"
+// and Status "Running", "Completed" or "Cancelled"
+type PomodoroSession struct {
+	ID                uint64     `json:"id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	TaskID            uint64     `json:"task_id"`
+	TimeRecordID      *uint64    `json:"time_record_id,omitempty"`
+	Phase             string     `json:"phase"`
+	Status            string     `json:"status"`
+	PlannedSeconds    int        `json:"planned_seconds"`
+	FocusSeconds      int        `json:"focus_seconds"`
+	ShortBreakSeconds int        `json:"short_break_seconds"`
+	LongBreakSeconds  int        `json:"long_break_seconds"`
+	LongBreakEvery    int        `json:"long_break_every"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           *time.Time `json:"end_time,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type WebhookSubscription struct {
+	ID         uint64    `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one entry of the delivery log, Status is "Pending", "Succeeded" or "Failed"
+type WebhookDelivery struct {
+	ID               uint64          `json:"id"`
+	SubscriptionID   uint64          `json:"subscription_id"`
+	UserID           uuid.UUID       `json:"user_id"`
+	EventID          string          `json:"event_id"`
+	EventType        string          `json:"event_type"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           string          `json:"status"`
+	Attempts         int             `json:"attempts"`
+	NextAttemptAt    time.Time       `json:"next_attempt_at"`
+	LastResponseCode *int            `json:"last_response_code,omitempty"`
+	LastError        *string         `json:"last_error,omitempty"`
+	DeliveredAt      *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// AuditLog is one mutation, ActorID is empty for changes made by background jobs
+type AuditLog struct {
+	ID         uint64          `json:"id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type APIKey struct {
+	ID         uint64     `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type UserInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordInput struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// UserSettingsInput sets the IANA time zone days are computed in, e.g. "Europe/Berlin"
+type UserSettingsInput struct {
+	TimeZone string `json:"time_zone"`
+}
+
+type ProjectInput struct {
+	Name string `json:"name"`
+}
+
+type CreateTaskInput struct {
+	Name      string   `json:"name"`
+	ProjectID uint64   `json:"project_id,omitempty"`
+	Tags      []string `json:"tags"`
+	Status    string   `json:"status"`
+}
+
+// UpdateTaskInput changes the fields that are not nil
+type UpdateTaskInput struct {
+	Name      *string   `json:"name"`
+	ProjectID *uint64   `json:"project_id"`
+	Tags      *[]string `json:"tags"`
+	Status    *string   `json:"status"`
+}
+
+type CreateTimeRecordInput struct {
+	TaskID      uint64    `json:"task_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Description string    `json:"description"`
+}
+
+// UpdateTimeRecordInput changes the fields that are not nil
+type UpdateTimeRecordInput struct {
+	TaskID      *uint64    `json:"task_id"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	IsClosed    *bool      `json:"is_closed"`
+	Description *string    `json:"description"`
+}
+
+type RestoreTimeRecordInput struct {
+	Version int `json:"version"`
+}
+
+// TimeRecordFilter narrows ListTimeRecords, nil and empty fields are not filtered on.
+// FromDate and ToDate are days (YYYY-MM-DD) in the user's time zone, ToDate included.
+type TimeRecordFilter struct {
+	TaskID   *uint64
+	From     *time.Time
+	To       *time.Time
+	FromDate string
+	ToDate   string
+}
+
+// PomodoroInput sets the lengths of a pomodoro cycle, zero values take the server defaults
+type PomodoroInput struct {
+	FocusMinutes      int `json:"focus_minutes"`
+	ShortBreakMinutes int `json:"short_break_minutes"`
+	LongBreakMinutes  int `json:"long_break_minutes"`
+	LongBreakEvery    int `json:"long_break_every"`
+}
+
+type PomodoroState struct {
+	Active           bool             `json:"active"`
+	Session          *PomodoroSession `json:"session,omitempty"`
+	EndsAt           *time.Time       `json:"ends_at,omitempty"`
+	RemainingSeconds int              `json:"remaining_seconds"`
+	CompletedToday   int              `json:"completed_today"`
+}
+
+type PomodoroTaskStats struct {
+	TaskID       uint64 `json:"task_id"`
+	Completed    int    `json:"completed"`
+	FocusSeconds int    `json:"focus_seconds"`
+}
+
+type PomodoroDayStats struct {
+	Date      string              `json:"date"`
+	Completed int                 `json:"completed"`
+	Tasks     []PomodoroTaskStats `json:"tasks"`
+}
+
+// WebhookInput creates a webhook subscription, an empty Secret is generated by the server
+type WebhookInput struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+// UpdateWebhookInput changes the fields that are not nil
+type UpdateWebhookInput struct {
+	URL        *string   `json:"url"`
+	Secret     *string   `json:"secret"`
+	EventTypes *[]string `json:"event_types"`
+	IsActive   *bool     `json:"is_active"`
+}
+
+// CreatedWebhook is returned once on creation, it is the only response exposing the signing secret
+type CreatedWebhook struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// AuditFilter narrows ListAuditLogs, empty fields are not filtered on
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+type APIKeyInput struct {
+	Name string `json:"name"`
+}
+
+// CreatedAPIKey is returned once on creation, it is the only response exposing the key
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// EventType names a domain event, e.g. "task.started"
+type EventType string
+
+const (
+	ProjectCreated    EventType = "project.created"
+	ProjectUpdated    EventType = "project.updated"
+	ProjectDeleted    EventType = "project.deleted"
+	TaskCreated       EventType = "task.created"
+	TaskUpdated       EventType = "task.updated"
+	TaskDeleted       EventType = "task.deleted"
+	TaskStarted       EventType = "task.started"
+	TaskStopped       EventType = "task.stopped"
+	TaskClosed        EventType = "task.closed"
+	TimeRecordCreated EventType = "time_record.created"
+	TimeRecordUpdated EventType = "time_record.updated"
+	TimeRecordDeleted EventType = "time_record.deleted"
+)
+
+// ErrorCode is the machine readable code of an error response, e.g. "not_found"
+type ErrorCode string
+
+// FieldError is an invalid field of a 422 response
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Session is returned by Signup and Signin
+type Session struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+	Token string    `json:"token"`
+}
+
+// Profile is returned by Profile and UpdateSettings, TimeZone is the IANA zone days are computed in
+type Profile struct {
+	ID       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	TimeZone string    `json:"time_zone"`
+}
+
+// Event is a domain event received from Events or EventsWebSocket, Data is the
+// JSON of the entity the event is about
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	UserID     string          `json:"user_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
@@ -0,0 +1,101 @@
+package client_test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+)
+
+// jsonFields lists the JSON names of the fields of a struct type with their omitempty option,
+// fields of embedded structs are listed as their own like encoding/json does
+func jsonFields(structType reflect.Type) []string {
+	var fields []string
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" {
+			fields = append(fields, jsonFields(field.Type)...)
+			continue
+		}
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(options, "omitempty") {
+			name += ",omitempty"
+		}
+		fields = append(fields, name)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+func TestTypesMatchTheServer(t *testing.T) {
+	pairs := []struct{ server, client any }{
+		{model.Project{}, client.Project{}},
+		{model.Task{}, client.Task{}},
+		{model.TimeRecord{}, client.TimeRecord{}},
+		{model.TimeRecordVersion{}, client.TimeRecordVersion{}},
+		{model.PomodoroSession{}, client.PomodoroSession{}},
+		{model.WebhookSubscription{}, client.WebhookSubscription{}},
+		{model.WebhookDelivery{}, client.WebhookDelivery{}},
+		{model.AuditLog{}, client.AuditLog{}},
+		{model.APIKey{}, client.APIKey{}},
+		{service.UserInput{}, client.UserInput{}},
+		{service.ChangePasswordInput{}, client.ChangePasswordInput{}},
+		{service.UserSettingsInput{}, client.UserSettingsInput{}},
+		{service.ProjectInput{}, client.ProjectInput{}},
+		{service.CreateTaskInput{}, client.CreateTaskInput{}},
+		{service.UpdateTaskInput{}, client.UpdateTaskInput{}},
+		{service.CreateTimeRecordInput{}, client.CreateTimeRecordInput{}},
+		{service.UpdateTimeRecordInput{}, client.UpdateTimeRecordInput{}},
+		{service.RestoreTimeRecordInput{}, client.RestoreTimeRecordInput{}},
+		{service.PomodoroInput{}, client.PomodoroInput{}},
+		{service.PomodoroState{}, client.PomodoroState{}},
+		{service.PomodoroTaskStats{}, client.PomodoroTaskStats{}},
+		{service.PomodoroDayStats{}, client.PomodoroDayStats{}},
+		{service.WebhookInput{}, client.WebhookInput{}},
+		{service.UpdateWebhookInput{}, client.UpdateWebhookInput{}},
+		{service.CreatedWebhook{}, client.CreatedWebhook{}},
+		{service.APIKeyInput{}, client.APIKeyInput{}},
+		{service.CreatedAPIKey{}, client.CreatedAPIKey{}},
+		{apperror.FieldError{}, client.FieldError{}},
+		{event.Event{}, client.Event{}},
+	}
+	for _, pair := range pairs {
+		serverType, clientType := reflect.TypeOf(pair.server), reflect.TypeOf(pair.client)
+		serverFields, clientFields := jsonFields(serverType), jsonFields(clientType)
+		if !slices.Equal(serverFields, clientFields) {
+			t.Errorf("%s has the JSON fields %q, the server type %s has %q", clientType, clientFields, serverType, serverFields)
+		}
+	}
+}
+
+func TestEventTypesMatchTheServer(t *testing.T) {
+	clientTypes := []client.EventType{
+		client.ProjectCreated, client.ProjectUpdated, client.ProjectDeleted,
+		client.TaskCreated, client.TaskUpdated, client.TaskDeleted,
+		client.TaskStarted, client.TaskStopped, client.TaskClosed,
+		client.TimeRecordCreated, client.TimeRecordUpdated, client.TimeRecordDeleted,
+	}
+	if fmt.Sprint(clientTypes) != fmt.Sprint(event.Types) {
+		t.Fatalf("client event types %v, the server publishes %v", clientTypes, event.Types)
+	}
+
+	statuses := []client.TaskStatus{client.StatusOpened, client.StatusWorkingOn, client.StatusClosed}
+	serverStatuses := []model.TaskStatus{model.StatusOpened, model.StatusWorkingOn, model.StatusClosed}
+	if fmt.Sprint(statuses) != fmt.Sprint(serverStatuses) {
+		t.Fatalf("client task statuses %v, the server has %v", statuses, serverStatuses)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Signup creates an account and authenticates the client with the returned token
+func (client *Client) Signup(ctx context.Context, input UserInput) (*Session, error) {
+	var session Session
+	if err := client.do(ctx, http.MethodPost, "/api/user/signup", nil, input, &session); err != nil {
+		return nil, err
+	}
+	client.auth = BearerToken(session.Token)
+	return &session, nil
+}
+
+// Signin authenticates the client with the returned token
+func (client *Client) Signin(ctx context.Context, input UserInput) (*Session, error) {
+	var session Session
+	if err := client.do(ctx, http.MethodPost, "/api/user/signin", nil, input, &session); err != nil {
+		return nil, err
+	}
+	client.auth = BearerToken(session.Token)
+	return &session, nil
+}
+
+func (client *Client) Profile(ctx context.Context) (*Profile, error) {
+	var profile Profile
+	if err := client.do(ctx, http.MethodGet, "/api/user/profile", nil, nil, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (client *Client) ChangePassword(ctx context.Context, input ChangePasswordInput) error {
+	return client.do(ctx, http.MethodPatch, "/api/user/change-password", nil, input, nil)
+}
+
+// UpdateSettings changes the preferences of the user and returns the updated profile
+func (client *Client) UpdateSettings(ctx context.Context, input UserSettingsInput) (*Profile, error) {
+	var profile Profile
+	if err := client.do(ctx, http.MethodPatch, "/api/user/settings", nil, input, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// DeleteCurrentUser deletes the account with all its data
+func (client *Client) DeleteCurrentUser(ctx context.Context) error {
+	return client.do(ctx, http.MethodDelete, "/api/user/delete", nil, nil, nil)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateWebhook subscribes a URL to events, the response is the only one holding the signing secret
+func (client *Client) CreateWebhook(ctx context.Context, input WebhookInput) (*CreatedWebhook, error) {
+	var webhook CreatedWebhook
+	if err := client.do(ctx, http.MethodPost, "/api/webhooks/create", nil, input, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (client *Client) ListWebhooks(ctx context.Context) ([]WebhookSubscription, error) {
+	var webhooks []WebhookSubscription
+	if err := client.do(ctx, http.MethodGet, "/api/webhooks/list", nil, nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (client *Client) GetWebhook(ctx context.Context, id uint64) (*WebhookSubscription, error) {
+	var webhook WebhookSubscription
+	if err := client.do(ctx, http.MethodGet, idPath("/api/webhooks/detail", id), nil, nil, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (client *Client) UpdateWebhook(ctx context.Context, id uint64, input UpdateWebhookInput) (*WebhookSubscription, error) {
+	var webhook WebhookSubscription
+	if err := client.do(ctx, http.MethodPatch, idPath("/api/webhooks/update", id), nil, input, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (client *Client) DeleteWebhook(ctx context.Context, id uint64) error {
+	return client.do(ctx, http.MethodDelete, idPath("/api/webhooks/delete", id), nil, nil, nil)
+}
+
+// WebhookDeliveries returns the delivery log of a webhook, newest first
+func (client *Client) WebhookDeliveries(ctx context.Context, id uint64) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	if err := client.do(ctx, http.MethodGet, idPath("/api/webhooks/deliveries", id), nil, nil, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhook queues the payload of an earlier delivery again
+func (client *Client) RedeliverWebhook(ctx context.Context, deliveryID uint64) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	if err := client.do(ctx, http.MethodPost, idPath("/api/webhooks/redeliver", deliveryID), nil, nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
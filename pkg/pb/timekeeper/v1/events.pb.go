@@ -0,0 +1,258 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: timekeeper/v1/events.proto
+
+package timekeeperv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchTimersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// task_id limits the stream to one task, 0 streams every task.
+	TaskId        uint64 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTimersRequest) Reset() {
+	*x = WatchTimersRequest{}
+	mi := &file_timekeeper_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTimersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTimersRequest) ProtoMessage() {}
+
+func (x *WatchTimersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTimersRequest.ProtoReflect.Descriptor instead.
+func (*WatchTimersRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchTimersRequest) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type TimerEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// task.started, task.stopped, task.closed, time_record.created, time_record.updated or time_record.deleted.
+	Type       string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Subject:
+	//
+	//	*TimerEvent_Task
+	//	*TimerEvent_TimeRecord
+	Subject       isTimerEvent_Subject `protobuf_oneof:"subject"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimerEvent) Reset() {
+	*x = TimerEvent{}
+	mi := &file_timekeeper_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimerEvent) ProtoMessage() {}
+
+func (x *TimerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimerEvent.ProtoReflect.Descriptor instead.
+func (*TimerEvent) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimerEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TimerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TimerEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *TimerEvent) GetSubject() isTimerEvent_Subject {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *TimerEvent) GetTask() *Task {
+	if x != nil {
+		if x, ok := x.Subject.(*TimerEvent_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+func (x *TimerEvent) GetTimeRecord() *TimeRecord {
+	if x != nil {
+		if x, ok := x.Subject.(*TimerEvent_TimeRecord); ok {
+			return x.TimeRecord
+		}
+	}
+	return nil
+}
+
+type isTimerEvent_Subject interface {
+	isTimerEvent_Subject()
+}
+
+type TimerEvent_Task struct {
+	Task *Task `protobuf:"bytes,4,opt,name=task,proto3,oneof"`
+}
+
+type TimerEvent_TimeRecord struct {
+	TimeRecord *TimeRecord `protobuf:"bytes,5,opt,name=time_record,json=timeRecord,proto3,oneof"`
+}
+
+func (*TimerEvent_Task) isTimerEvent_Subject() {}
+
+func (*TimerEvent_TimeRecord) isTimerEvent_Subject() {}
+
+var File_timekeeper_v1_events_proto protoreflect.FileDescriptor
+
+const file_timekeeper_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1atimekeeper/v1/events.proto\x12\rtimekeeper.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19timekeeper/v1/tasks.proto\x1a timekeeper/v1/time_records.proto\"-\n" +
+	"\x12WatchTimersRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\"\xe1\x01\n" +
+	"\n" +
+	"TimerEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12)\n" +
+	"\x04task\x18\x04 \x01(\v2\x13.timekeeper.v1.TaskH\x00R\x04task\x12<\n" +
+	"\vtime_record\x18\x05 \x01(\v2\x19.timekeeper.v1.TimeRecordH\x00R\n" +
+	"timeRecordB\t\n" +
+	"\asubject2]\n" +
+	"\fEventService\x12M\n" +
+	"\vWatchTimers\x12!.timekeeper.v1.WatchTimersRequest\x1a\x19.timekeeper.v1.TimerEvent0\x01BOZMgithub.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1;timekeeperv1b\x06proto3"
+
+var (
+	file_timekeeper_v1_events_proto_rawDescOnce sync.Once
+	file_timekeeper_v1_events_proto_rawDescData []byte
+)
+
+func file_timekeeper_v1_events_proto_rawDescGZIP() []byte {
+	file_timekeeper_v1_events_proto_rawDescOnce.Do(func() {
+		file_timekeeper_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_timekeeper_v1_events_proto_rawDesc), len(file_timekeeper_v1_events_proto_rawDesc)))
+	})
+	return file_timekeeper_v1_events_proto_rawDescData
+}
+
+var file_timekeeper_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_timekeeper_v1_events_proto_goTypes = []any{
+	(*WatchTimersRequest)(nil),    // 0: timekeeper.v1.WatchTimersRequest
+	(*TimerEvent)(nil),            // 1: timekeeper.v1.TimerEvent
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*Task)(nil),                  // 3: timekeeper.v1.Task
+	(*TimeRecord)(nil),            // 4: timekeeper.v1.TimeRecord
+}
+var file_timekeeper_v1_events_proto_depIdxs = []int32{
+	2, // 0: timekeeper.v1.TimerEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	3, // 1: timekeeper.v1.TimerEvent.task:type_name -> timekeeper.v1.Task
+	4, // 2: timekeeper.v1.TimerEvent.time_record:type_name -> timekeeper.v1.TimeRecord
+	0, // 3: timekeeper.v1.EventService.WatchTimers:input_type -> timekeeper.v1.WatchTimersRequest
+	1, // 4: timekeeper.v1.EventService.WatchTimers:output_type -> timekeeper.v1.TimerEvent
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_timekeeper_v1_events_proto_init() }
+func file_timekeeper_v1_events_proto_init() {
+	if File_timekeeper_v1_events_proto != nil {
+		return
+	}
+	file_timekeeper_v1_tasks_proto_init()
+	file_timekeeper_v1_time_records_proto_init()
+	file_timekeeper_v1_events_proto_msgTypes[1].OneofWrappers = []any{
+		(*TimerEvent_Task)(nil),
+		(*TimerEvent_TimeRecord)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_timekeeper_v1_events_proto_rawDesc), len(file_timekeeper_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_timekeeper_v1_events_proto_goTypes,
+		DependencyIndexes: file_timekeeper_v1_events_proto_depIdxs,
+		MessageInfos:      file_timekeeper_v1_events_proto_msgTypes,
+	}.Build()
+	File_timekeeper_v1_events_proto = out.File
+	file_timekeeper_v1_events_proto_goTypes = nil
+	file_timekeeper_v1_events_proto_depIdxs = nil
+}
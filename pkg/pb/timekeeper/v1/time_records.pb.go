@@ -0,0 +1,857 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: timekeeper/v1/time_records.proto
+
+package timekeeperv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TimeRecord struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId    uint64                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// end_time is unset while the record is running.
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsClosed      bool                   `protobuf:"varint,5,opt,name=is_closed,json=isClosed,proto3" json:"is_closed,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRecord) Reset() {
+	*x = TimeRecord{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRecord) ProtoMessage() {}
+
+func (x *TimeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRecord.ProtoReflect.Descriptor instead.
+func (*TimeRecord) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeRecord) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TimeRecord) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TimeRecord) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TimeRecord) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TimeRecord) GetIsClosed() bool {
+	if x != nil {
+		return x.IsClosed
+	}
+	return false
+}
+
+func (x *TimeRecord) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TimeRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TimeRecord) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *TimeRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type TimeRecordVersion struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TimeRecordId uint64                 `protobuf:"varint,2,opt,name=time_record_id,json=timeRecordId,proto3" json:"time_record_id,omitempty"`
+	Version      int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	ActorId      string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	// create, edit, stop, delete, restore or undo.
+	Operation     string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	TaskId        uint64                 `protobuf:"varint,6,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsClosed      bool                   `protobuf:"varint,9,opt,name=is_closed,json=isClosed,proto3" json:"is_closed,omitempty"`
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	Deleted       bool                   `protobuf:"varint,11,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRecordVersion) Reset() {
+	*x = TimeRecordVersion{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRecordVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRecordVersion) ProtoMessage() {}
+
+func (x *TimeRecordVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRecordVersion.ProtoReflect.Descriptor instead.
+func (*TimeRecordVersion) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimeRecordVersion) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TimeRecordVersion) GetTimeRecordId() uint64 {
+	if x != nil {
+		return x.TimeRecordId
+	}
+	return 0
+}
+
+func (x *TimeRecordVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TimeRecordVersion) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *TimeRecordVersion) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *TimeRecordVersion) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TimeRecordVersion) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TimeRecordVersion) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TimeRecordVersion) GetIsClosed() bool {
+	if x != nil {
+		return x.IsClosed
+	}
+	return false
+}
+
+func (x *TimeRecordVersion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TimeRecordVersion) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *TimeRecordVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateTimeRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTimeRecordRequest) Reset() {
+	*x = CreateTimeRecordRequest{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTimeRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTimeRecordRequest) ProtoMessage() {}
+
+func (x *CreateTimeRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTimeRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateTimeRecordRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTimeRecordRequest) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CreateTimeRecordRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateTimeRecordRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CreateTimeRecordRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListTimeRecordsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId *uint64                `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3,oneof" json:"task_id,omitempty"`
+	// from and to bound the start time of the records.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// from_date and to_date are days (YYYY-MM-DD) in the user's time zone, to_date included.
+	// They replace from and to.
+	FromDate      *string `protobuf:"bytes,4,opt,name=from_date,json=fromDate,proto3,oneof" json:"from_date,omitempty"`
+	ToDate        *string `protobuf:"bytes,5,opt,name=to_date,json=toDate,proto3,oneof" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTimeRecordsRequest) Reset() {
+	*x = ListTimeRecordsRequest{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTimeRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTimeRecordsRequest) ProtoMessage() {}
+
+func (x *ListTimeRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTimeRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListTimeRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTimeRecordsRequest) GetTaskId() uint64 {
+	if x != nil && x.TaskId != nil {
+		return *x.TaskId
+	}
+	return 0
+}
+
+func (x *ListTimeRecordsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListTimeRecordsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListTimeRecordsRequest) GetFromDate() string {
+	if x != nil && x.FromDate != nil {
+		return *x.FromDate
+	}
+	return ""
+}
+
+func (x *ListTimeRecordsRequest) GetToDate() string {
+	if x != nil && x.ToDate != nil {
+		return *x.ToDate
+	}
+	return ""
+}
+
+type ListTimeRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeRecords   []*TimeRecord          `protobuf:"bytes,1,rep,name=time_records,json=timeRecords,proto3" json:"time_records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTimeRecordsResponse) Reset() {
+	*x = ListTimeRecordsResponse{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTimeRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTimeRecordsResponse) ProtoMessage() {}
+
+func (x *ListTimeRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTimeRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListTimeRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTimeRecordsResponse) GetTimeRecords() []*TimeRecord {
+	if x != nil {
+		return x.TimeRecords
+	}
+	return nil
+}
+
+type TimeRecordIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRecordIDRequest) Reset() {
+	*x = TimeRecordIDRequest{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRecordIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRecordIDRequest) ProtoMessage() {}
+
+func (x *TimeRecordIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRecordIDRequest.ProtoReflect.Descriptor instead.
+func (*TimeRecordIDRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TimeRecordIDRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateTimeRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        *uint64                `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3,oneof" json:"task_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsClosed      *bool                  `protobuf:"varint,5,opt,name=is_closed,json=isClosed,proto3,oneof" json:"is_closed,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTimeRecordRequest) Reset() {
+	*x = UpdateTimeRecordRequest{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTimeRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTimeRecordRequest) ProtoMessage() {}
+
+func (x *UpdateTimeRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTimeRecordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTimeRecordRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateTimeRecordRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTimeRecordRequest) GetTaskId() uint64 {
+	if x != nil && x.TaskId != nil {
+		return *x.TaskId
+	}
+	return 0
+}
+
+func (x *UpdateTimeRecordRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *UpdateTimeRecordRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *UpdateTimeRecordRequest) GetIsClosed() bool {
+	if x != nil && x.IsClosed != nil {
+		return *x.IsClosed
+	}
+	return false
+}
+
+func (x *UpdateTimeRecordRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type TimeRecordHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*TimeRecordVersion   `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRecordHistory) Reset() {
+	*x = TimeRecordHistory{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRecordHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRecordHistory) ProtoMessage() {}
+
+func (x *TimeRecordHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRecordHistory.ProtoReflect.Descriptor instead.
+func (*TimeRecordHistory) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TimeRecordHistory) GetVersions() []*TimeRecordVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type RestoreTimeRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTimeRecordRequest) Reset() {
+	*x = RestoreTimeRecordRequest{}
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTimeRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTimeRecordRequest) ProtoMessage() {}
+
+func (x *RestoreTimeRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_time_records_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTimeRecordRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTimeRecordRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_time_records_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreTimeRecordRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreTimeRecordRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_timekeeper_v1_time_records_proto protoreflect.FileDescriptor
+
+const file_timekeeper_v1_time_records_proto_rawDesc = "" +
+	"\n" +
+	" timekeeper/v1/time_records.proto\x12\rtimekeeper.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x02\n" +
+	"\n" +
+	"TimeRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x04R\x06taskId\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tis_closed\x18\x05 \x01(\bR\bisClosed\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x17\n" +
+	"\auser_id\x18\t \x01(\tR\x06userId\"\xbb\x03\n" +
+	"\x11TimeRecordVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12$\n" +
+	"\x0etime_record_id\x18\x02 \x01(\x04R\ftimeRecordId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12\x17\n" +
+	"\atask_id\x18\x06 \x01(\x04R\x06taskId\x129\n" +
+	"\n" +
+	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tis_closed\x18\t \x01(\bR\bisClosed\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x18\n" +
+	"\adeleted\x18\v \x01(\bR\adeleted\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc6\x01\n" +
+	"\x17CreateTimeRecordRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xf8\x01\n" +
+	"\x16ListTimeRecordsRequest\x12\x1c\n" +
+	"\atask_id\x18\x01 \x01(\x04H\x00R\x06taskId\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12 \n" +
+	"\tfrom_date\x18\x04 \x01(\tH\x01R\bfromDate\x88\x01\x01\x12\x1c\n" +
+	"\ato_date\x18\x05 \x01(\tH\x02R\x06toDate\x88\x01\x01B\n" +
+	"\n" +
+	"\b_task_idB\f\n" +
+	"\n" +
+	"_from_dateB\n" +
+	"\n" +
+	"\b_to_date\"W\n" +
+	"\x17ListTimeRecordsResponse\x12<\n" +
+	"\ftime_records\x18\x01 \x03(\v2\x19.timekeeper.v1.TimeRecordR\vtimeRecords\"%\n" +
+	"\x13TimeRecordIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\xac\x02\n" +
+	"\x17UpdateTimeRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1c\n" +
+	"\atask_id\x18\x02 \x01(\x04H\x00R\x06taskId\x88\x01\x01\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12 \n" +
+	"\tis_closed\x18\x05 \x01(\bH\x01R\bisClosed\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x02R\vdescription\x88\x01\x01B\n" +
+	"\n" +
+	"\b_task_idB\f\n" +
+	"\n" +
+	"_is_closedB\x0e\n" +
+	"\f_description\"Q\n" +
+	"\x11TimeRecordHistory\x12<\n" +
+	"\bversions\x18\x01 \x03(\v2 .timekeeper.v1.TimeRecordVersionR\bversions\"D\n" +
+	"\x18RestoreTimeRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion2\xbf\x05\n" +
+	"\x11TimeRecordService\x12U\n" +
+	"\x10CreateTimeRecord\x12&.timekeeper.v1.CreateTimeRecordRequest\x1a\x19.timekeeper.v1.TimeRecord\x12`\n" +
+	"\x0fListTimeRecords\x12%.timekeeper.v1.ListTimeRecordsRequest\x1a&.timekeeper.v1.ListTimeRecordsResponse\x12N\n" +
+	"\rGetTimeRecord\x12\".timekeeper.v1.TimeRecordIDRequest\x1a\x19.timekeeper.v1.TimeRecord\x12U\n" +
+	"\x10UpdateTimeRecord\x12&.timekeeper.v1.UpdateTimeRecordRequest\x1a\x19.timekeeper.v1.TimeRecord\x12N\n" +
+	"\x10DeleteTimeRecord\x12\".timekeeper.v1.TimeRecordIDRequest\x1a\x16.google.protobuf.Empty\x12\\\n" +
+	"\x14GetTimeRecordHistory\x12\".timekeeper.v1.TimeRecordIDRequest\x1a .timekeeper.v1.TimeRecordHistory\x12W\n" +
+	"\x11RestoreTimeRecord\x12'.timekeeper.v1.RestoreTimeRecordRequest\x1a\x19.timekeeper.v1.TimeRecord\x12C\n" +
+	"\x0eUndoTimeRecord\x12\x16.google.protobuf.Empty\x1a\x19.timekeeper.v1.TimeRecordBOZMgithub.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1;timekeeperv1b\x06proto3"
+
+var (
+	file_timekeeper_v1_time_records_proto_rawDescOnce sync.Once
+	file_timekeeper_v1_time_records_proto_rawDescData []byte
+)
+
+func file_timekeeper_v1_time_records_proto_rawDescGZIP() []byte {
+	file_timekeeper_v1_time_records_proto_rawDescOnce.Do(func() {
+		file_timekeeper_v1_time_records_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_timekeeper_v1_time_records_proto_rawDesc), len(file_timekeeper_v1_time_records_proto_rawDesc)))
+	})
+	return file_timekeeper_v1_time_records_proto_rawDescData
+}
+
+var file_timekeeper_v1_time_records_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_timekeeper_v1_time_records_proto_goTypes = []any{
+	(*TimeRecord)(nil),               // 0: timekeeper.v1.TimeRecord
+	(*TimeRecordVersion)(nil),        // 1: timekeeper.v1.TimeRecordVersion
+	(*CreateTimeRecordRequest)(nil),  // 2: timekeeper.v1.CreateTimeRecordRequest
+	(*ListTimeRecordsRequest)(nil),   // 3: timekeeper.v1.ListTimeRecordsRequest
+	(*ListTimeRecordsResponse)(nil),  // 4: timekeeper.v1.ListTimeRecordsResponse
+	(*TimeRecordIDRequest)(nil),      // 5: timekeeper.v1.TimeRecordIDRequest
+	(*UpdateTimeRecordRequest)(nil),  // 6: timekeeper.v1.UpdateTimeRecordRequest
+	(*TimeRecordHistory)(nil),        // 7: timekeeper.v1.TimeRecordHistory
+	(*RestoreTimeRecordRequest)(nil), // 8: timekeeper.v1.RestoreTimeRecordRequest
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),            // 10: google.protobuf.Empty
+}
+var file_timekeeper_v1_time_records_proto_depIdxs = []int32{
+	9,  // 0: timekeeper.v1.TimeRecord.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 1: timekeeper.v1.TimeRecord.end_time:type_name -> google.protobuf.Timestamp
+	9,  // 2: timekeeper.v1.TimeRecord.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: timekeeper.v1.TimeRecord.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: timekeeper.v1.TimeRecordVersion.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 5: timekeeper.v1.TimeRecordVersion.end_time:type_name -> google.protobuf.Timestamp
+	9,  // 6: timekeeper.v1.TimeRecordVersion.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: timekeeper.v1.CreateTimeRecordRequest.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 8: timekeeper.v1.CreateTimeRecordRequest.end_time:type_name -> google.protobuf.Timestamp
+	9,  // 9: timekeeper.v1.ListTimeRecordsRequest.from:type_name -> google.protobuf.Timestamp
+	9,  // 10: timekeeper.v1.ListTimeRecordsRequest.to:type_name -> google.protobuf.Timestamp
+	0,  // 11: timekeeper.v1.ListTimeRecordsResponse.time_records:type_name -> timekeeper.v1.TimeRecord
+	9,  // 12: timekeeper.v1.UpdateTimeRecordRequest.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 13: timekeeper.v1.UpdateTimeRecordRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 14: timekeeper.v1.TimeRecordHistory.versions:type_name -> timekeeper.v1.TimeRecordVersion
+	2,  // 15: timekeeper.v1.TimeRecordService.CreateTimeRecord:input_type -> timekeeper.v1.CreateTimeRecordRequest
+	3,  // 16: timekeeper.v1.TimeRecordService.ListTimeRecords:input_type -> timekeeper.v1.ListTimeRecordsRequest
+	5,  // 17: timekeeper.v1.TimeRecordService.GetTimeRecord:input_type -> timekeeper.v1.TimeRecordIDRequest
+	6,  // 18: timekeeper.v1.TimeRecordService.UpdateTimeRecord:input_type -> timekeeper.v1.UpdateTimeRecordRequest
+	5,  // 19: timekeeper.v1.TimeRecordService.DeleteTimeRecord:input_type -> timekeeper.v1.TimeRecordIDRequest
+	5,  // 20: timekeeper.v1.TimeRecordService.GetTimeRecordHistory:input_type -> timekeeper.v1.TimeRecordIDRequest
+	8,  // 21: timekeeper.v1.TimeRecordService.RestoreTimeRecord:input_type -> timekeeper.v1.RestoreTimeRecordRequest
+	10, // 22: timekeeper.v1.TimeRecordService.UndoTimeRecord:input_type -> google.protobuf.Empty
+	0,  // 23: timekeeper.v1.TimeRecordService.CreateTimeRecord:output_type -> timekeeper.v1.TimeRecord
+	4,  // 24: timekeeper.v1.TimeRecordService.ListTimeRecords:output_type -> timekeeper.v1.ListTimeRecordsResponse
+	0,  // 25: timekeeper.v1.TimeRecordService.GetTimeRecord:output_type -> timekeeper.v1.TimeRecord
+	0,  // 26: timekeeper.v1.TimeRecordService.UpdateTimeRecord:output_type -> timekeeper.v1.TimeRecord
+	10, // 27: timekeeper.v1.TimeRecordService.DeleteTimeRecord:output_type -> google.protobuf.Empty
+	7,  // 28: timekeeper.v1.TimeRecordService.GetTimeRecordHistory:output_type -> timekeeper.v1.TimeRecordHistory
+	0,  // 29: timekeeper.v1.TimeRecordService.RestoreTimeRecord:output_type -> timekeeper.v1.TimeRecord
+	0,  // 30: timekeeper.v1.TimeRecordService.UndoTimeRecord:output_type -> timekeeper.v1.TimeRecord
+	23, // [23:31] is the sub-list for method output_type
+	15, // [15:23] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_timekeeper_v1_time_records_proto_init() }
+func file_timekeeper_v1_time_records_proto_init() {
+	if File_timekeeper_v1_time_records_proto != nil {
+		return
+	}
+	file_timekeeper_v1_time_records_proto_msgTypes[3].OneofWrappers = []any{}
+	file_timekeeper_v1_time_records_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_timekeeper_v1_time_records_proto_rawDesc), len(file_timekeeper_v1_time_records_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_timekeeper_v1_time_records_proto_goTypes,
+		DependencyIndexes: file_timekeeper_v1_time_records_proto_depIdxs,
+		MessageInfos:      file_timekeeper_v1_time_records_proto_msgTypes,
+	}.Build()
+	File_timekeeper_v1_time_records_proto = out.File
+	file_timekeeper_v1_time_records_proto_goTypes = nil
+	file_timekeeper_v1_time_records_proto_depIdxs = nil
+}
@@ -0,0 +1,396 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: timekeeper/v1/time_records.proto
+
+package timekeeperv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TimeRecordService_CreateTimeRecord_FullMethodName     = "/timekeeper.v1.TimeRecordService/CreateTimeRecord"
+	TimeRecordService_ListTimeRecords_FullMethodName      = "/timekeeper.v1.TimeRecordService/ListTimeRecords"
+	TimeRecordService_GetTimeRecord_FullMethodName        = "/timekeeper.v1.TimeRecordService/GetTimeRecord"
+	TimeRecordService_UpdateTimeRecord_FullMethodName     = "/timekeeper.v1.TimeRecordService/UpdateTimeRecord"
+	TimeRecordService_DeleteTimeRecord_FullMethodName     = "/timekeeper.v1.TimeRecordService/DeleteTimeRecord"
+	TimeRecordService_GetTimeRecordHistory_FullMethodName = "/timekeeper.v1.TimeRecordService/GetTimeRecordHistory"
+	TimeRecordService_RestoreTimeRecord_FullMethodName    = "/timekeeper.v1.TimeRecordService/RestoreTimeRecord"
+	TimeRecordService_UndoTimeRecord_FullMethodName       = "/timekeeper.v1.TimeRecordService/UndoTimeRecord"
+)
+
+// TimeRecordServiceClient is the client API for TimeRecordService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TimeRecordServiceClient interface {
+	// CreateTimeRecord adds a manual entry for work that was not tracked with start and stop.
+	CreateTimeRecord(ctx context.Context, in *CreateTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error)
+	ListTimeRecords(ctx context.Context, in *ListTimeRecordsRequest, opts ...grpc.CallOption) (*ListTimeRecordsResponse, error)
+	GetTimeRecord(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*TimeRecord, error)
+	// UpdateTimeRecord changes the fields that are set, unset optional fields are kept.
+	UpdateTimeRecord(ctx context.Context, in *UpdateTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error)
+	DeleteTimeRecord(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetTimeRecordHistory returns every version of a time record, oldest first.
+	GetTimeRecordHistory(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*TimeRecordHistory, error)
+	RestoreTimeRecord(ctx context.Context, in *RestoreTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error)
+	// UndoTimeRecord reverts the latest edit, stop or delete made by the caller in the last minutes.
+	UndoTimeRecord(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TimeRecord, error)
+}
+
+type timeRecordServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTimeRecordServiceClient(cc grpc.ClientConnInterface) TimeRecordServiceClient {
+	return &timeRecordServiceClient{cc}
+}
+
+func (c *timeRecordServiceClient) CreateTimeRecord(ctx context.Context, in *CreateTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecord)
+	err := c.cc.Invoke(ctx, TimeRecordService_CreateTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) ListTimeRecords(ctx context.Context, in *ListTimeRecordsRequest, opts ...grpc.CallOption) (*ListTimeRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTimeRecordsResponse)
+	err := c.cc.Invoke(ctx, TimeRecordService_ListTimeRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) GetTimeRecord(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*TimeRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecord)
+	err := c.cc.Invoke(ctx, TimeRecordService_GetTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) UpdateTimeRecord(ctx context.Context, in *UpdateTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecord)
+	err := c.cc.Invoke(ctx, TimeRecordService_UpdateTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) DeleteTimeRecord(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TimeRecordService_DeleteTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) GetTimeRecordHistory(ctx context.Context, in *TimeRecordIDRequest, opts ...grpc.CallOption) (*TimeRecordHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecordHistory)
+	err := c.cc.Invoke(ctx, TimeRecordService_GetTimeRecordHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) RestoreTimeRecord(ctx context.Context, in *RestoreTimeRecordRequest, opts ...grpc.CallOption) (*TimeRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecord)
+	err := c.cc.Invoke(ctx, TimeRecordService_RestoreTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeRecordServiceClient) UndoTimeRecord(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TimeRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeRecord)
+	err := c.cc.Invoke(ctx, TimeRecordService_UndoTimeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TimeRecordServiceServer is the server API for TimeRecordService service.
+// All implementations must embed UnimplementedTimeRecordServiceServer
+// for forward compatibility.
+type TimeRecordServiceServer interface {
+	// CreateTimeRecord adds a manual entry for work that was not tracked with start and stop.
+	CreateTimeRecord(context.Context, *CreateTimeRecordRequest) (*TimeRecord, error)
+	ListTimeRecords(context.Context, *ListTimeRecordsRequest) (*ListTimeRecordsResponse, error)
+	GetTimeRecord(context.Context, *TimeRecordIDRequest) (*TimeRecord, error)
+	// UpdateTimeRecord changes the fields that are set, unset optional fields are kept.
+	UpdateTimeRecord(context.Context, *UpdateTimeRecordRequest) (*TimeRecord, error)
+	DeleteTimeRecord(context.Context, *TimeRecordIDRequest) (*emptypb.Empty, error)
+	// GetTimeRecordHistory returns every version of a time record, oldest first.
+	GetTimeRecordHistory(context.Context, *TimeRecordIDRequest) (*TimeRecordHistory, error)
+	RestoreTimeRecord(context.Context, *RestoreTimeRecordRequest) (*TimeRecord, error)
+	// UndoTimeRecord reverts the latest edit, stop or delete made by the caller in the last minutes.
+	UndoTimeRecord(context.Context, *emptypb.Empty) (*TimeRecord, error)
+	mustEmbedUnimplementedTimeRecordServiceServer()
+}
+
+// UnimplementedTimeRecordServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTimeRecordServiceServer struct{}
+
+func (UnimplementedTimeRecordServiceServer) CreateTimeRecord(context.Context, *CreateTimeRecordRequest) (*TimeRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) ListTimeRecords(context.Context, *ListTimeRecordsRequest) (*ListTimeRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTimeRecords not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) GetTimeRecord(context.Context, *TimeRecordIDRequest) (*TimeRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) UpdateTimeRecord(context.Context, *UpdateTimeRecordRequest) (*TimeRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) DeleteTimeRecord(context.Context, *TimeRecordIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) GetTimeRecordHistory(context.Context, *TimeRecordIDRequest) (*TimeRecordHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeRecordHistory not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) RestoreTimeRecord(context.Context, *RestoreTimeRecordRequest) (*TimeRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) UndoTimeRecord(context.Context, *emptypb.Empty) (*TimeRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoTimeRecord not implemented")
+}
+func (UnimplementedTimeRecordServiceServer) mustEmbedUnimplementedTimeRecordServiceServer() {}
+func (UnimplementedTimeRecordServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeTimeRecordServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TimeRecordServiceServer will
+// result in compilation errors.
+type UnsafeTimeRecordServiceServer interface {
+	mustEmbedUnimplementedTimeRecordServiceServer()
+}
+
+func RegisterTimeRecordServiceServer(s grpc.ServiceRegistrar, srv TimeRecordServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTimeRecordServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TimeRecordService_ServiceDesc, srv)
+}
+
+func _TimeRecordService_CreateTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTimeRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).CreateTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_CreateTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).CreateTimeRecord(ctx, req.(*CreateTimeRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_ListTimeRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTimeRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).ListTimeRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_ListTimeRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).ListTimeRecords(ctx, req.(*ListTimeRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_GetTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRecordIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).GetTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_GetTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).GetTimeRecord(ctx, req.(*TimeRecordIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_UpdateTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTimeRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).UpdateTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_UpdateTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).UpdateTimeRecord(ctx, req.(*UpdateTimeRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_DeleteTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRecordIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).DeleteTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_DeleteTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).DeleteTimeRecord(ctx, req.(*TimeRecordIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_GetTimeRecordHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRecordIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).GetTimeRecordHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_GetTimeRecordHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).GetTimeRecordHistory(ctx, req.(*TimeRecordIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_RestoreTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTimeRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).RestoreTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_RestoreTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).RestoreTimeRecord(ctx, req.(*RestoreTimeRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeRecordService_UndoTimeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeRecordServiceServer).UndoTimeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeRecordService_UndoTimeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeRecordServiceServer).UndoTimeRecord(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TimeRecordService_ServiceDesc is the grpc.ServiceDesc for TimeRecordService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TimeRecordService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "timekeeper.v1.TimeRecordService",
+	HandlerType: (*TimeRecordServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTimeRecord",
+			Handler:    _TimeRecordService_CreateTimeRecord_Handler,
+		},
+		{
+			MethodName: "ListTimeRecords",
+			Handler:    _TimeRecordService_ListTimeRecords_Handler,
+		},
+		{
+			MethodName: "GetTimeRecord",
+			Handler:    _TimeRecordService_GetTimeRecord_Handler,
+		},
+		{
+			MethodName: "UpdateTimeRecord",
+			Handler:    _TimeRecordService_UpdateTimeRecord_Handler,
+		},
+		{
+			MethodName: "DeleteTimeRecord",
+			Handler:    _TimeRecordService_DeleteTimeRecord_Handler,
+		},
+		{
+			MethodName: "GetTimeRecordHistory",
+			Handler:    _TimeRecordService_GetTimeRecordHistory_Handler,
+		},
+		{
+			MethodName: "RestoreTimeRecord",
+			Handler:    _TimeRecordService_RestoreTimeRecord_Handler,
+		},
+		{
+			MethodName: "UndoTimeRecord",
+			Handler:    _TimeRecordService_UndoTimeRecord_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "timekeeper/v1/time_records.proto",
+}
@@ -0,0 +1,328 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: timekeeper/v1/users.proto
+
+package timekeeperv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Credentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	mi := &file_timekeeper_v1_users_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_users_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_users_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Credentials) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Credentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// Session carries the JWT to send as "authorization: Bearer <token>" metadata.
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_timekeeper_v1_users_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_users_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_users_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Session) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Profile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_timekeeper_v1_users_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_users_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_users_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Profile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Profile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword   string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_timekeeper_v1_users_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timekeeper_v1_users_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_timekeeper_v1_users_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+var File_timekeeper_v1_users_proto protoreflect.FileDescriptor
+
+const file_timekeeper_v1_users_proto_rawDesc = "" +
+	"\n" +
+	"\x19timekeeper/v1/users.proto\x12\rtimekeeper.v1\x1a\x1bgoogle/protobuf/empty.proto\"?\n" +
+	"\vCredentials\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"E\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"/\n" +
+	"\aProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"]\n" +
+	"\x15ChangePasswordRequest\x12!\n" +
+	"\fold_password\x18\x01 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword2\xdc\x02\n" +
+	"\vUserService\x12<\n" +
+	"\x06Signup\x12\x1a.timekeeper.v1.Credentials\x1a\x16.timekeeper.v1.Session\x12<\n" +
+	"\x06Signin\x12\x1a.timekeeper.v1.Credentials\x1a\x16.timekeeper.v1.Session\x12<\n" +
+	"\n" +
+	"GetProfile\x12\x16.google.protobuf.Empty\x1a\x16.timekeeper.v1.Profile\x12N\n" +
+	"\x0eChangePassword\x12$.timekeeper.v1.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x11DeleteCurrentUser\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.EmptyBOZMgithub.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1;timekeeperv1b\x06proto3"
+
+var (
+	file_timekeeper_v1_users_proto_rawDescOnce sync.Once
+	file_timekeeper_v1_users_proto_rawDescData []byte
+)
+
+func file_timekeeper_v1_users_proto_rawDescGZIP() []byte {
+	file_timekeeper_v1_users_proto_rawDescOnce.Do(func() {
+		file_timekeeper_v1_users_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_timekeeper_v1_users_proto_rawDesc), len(file_timekeeper_v1_users_proto_rawDesc)))
+	})
+	return file_timekeeper_v1_users_proto_rawDescData
+}
+
+var file_timekeeper_v1_users_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_timekeeper_v1_users_proto_goTypes = []any{
+	(*Credentials)(nil),           // 0: timekeeper.v1.Credentials
+	(*Session)(nil),               // 1: timekeeper.v1.Session
+	(*Profile)(nil),               // 2: timekeeper.v1.Profile
+	(*ChangePasswordRequest)(nil), // 3: timekeeper.v1.ChangePasswordRequest
+	(*emptypb.Empty)(nil),         // 4: google.protobuf.Empty
+}
+var file_timekeeper_v1_users_proto_depIdxs = []int32{
+	0, // 0: timekeeper.v1.UserService.Signup:input_type -> timekeeper.v1.Credentials
+	0, // 1: timekeeper.v1.UserService.Signin:input_type -> timekeeper.v1.Credentials
+	4, // 2: timekeeper.v1.UserService.GetProfile:input_type -> google.protobuf.Empty
+	3, // 3: timekeeper.v1.UserService.ChangePassword:input_type -> timekeeper.v1.ChangePasswordRequest
+	4, // 4: timekeeper.v1.UserService.DeleteCurrentUser:input_type -> google.protobuf.Empty
+	1, // 5: timekeeper.v1.UserService.Signup:output_type -> timekeeper.v1.Session
+	1, // 6: timekeeper.v1.UserService.Signin:output_type -> timekeeper.v1.Session
+	2, // 7: timekeeper.v1.UserService.GetProfile:output_type -> timekeeper.v1.Profile
+	4, // 8: timekeeper.v1.UserService.ChangePassword:output_type -> google.protobuf.Empty
+	4, // 9: timekeeper.v1.UserService.DeleteCurrentUser:output_type -> google.protobuf.Empty
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_timekeeper_v1_users_proto_init() }
+func file_timekeeper_v1_users_proto_init() {
+	if File_timekeeper_v1_users_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_timekeeper_v1_users_proto_rawDesc), len(file_timekeeper_v1_users_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_timekeeper_v1_users_proto_goTypes,
+		DependencyIndexes: file_timekeeper_v1_users_proto_depIdxs,
+		MessageInfos:      file_timekeeper_v1_users_proto_msgTypes,
+	}.Build()
+	File_timekeeper_v1_users_proto = out.File
+	file_timekeeper_v1_users_proto_goTypes = nil
+	file_timekeeper_v1_users_proto_depIdxs = nil
+}
@@ -0,0 +1,118 @@
+package audit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestAuditLogRecordsProjectMutations(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := http.Client{}
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+
+	startedAt := time.Now().Add(-time.Minute)
+	helper.CreateProject(t, &client, server, testingVariables, "Audited Project")
+	projectID := strconv.FormatUint(testingVariables.ProjectID[0], 10)
+
+	renameResp := helper.DoPutchAuth(
+		t,
+		&client,
+		server.URL+"/api/projects/update/"+projectID,
+		map[string]string{"name": "Renamed Project"},
+		testingVariables.AuthToken,
+	)
+	if renameResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Project rename failed: status %d", renameResp.StatusCode)
+	}
+
+	query := url.Values{}
+	query.Set("entity_type", "project")
+	query.Set("entity_id", projectID)
+	query.Set("from", startedAt.UTC().Format(time.RFC3339))
+	auditLogs := helper.GetAuditLogs(t, &client, server, testingVariables, query)
+	if len(auditLogs) != 2 {
+		t.Fatalf("❌ Expected 2 audit logs, got %d", len(auditLogs))
+	}
+
+	update, create := auditLogs[0], auditLogs[1]
+	if create.Action != "create" || update.Action != "update" {
+		t.Fatalf("❌ Unexpected actions: %s, %s", create.Action, update.Action)
+	}
+	if _, ok := update.Changes["name"]; !ok {
+		t.Fatalf("❌ Rename diff does not contain the name: %v", update.Changes)
+	}
+	if update.ActorID == "" || update.RequestID == "" || update.IP == "" {
+		t.Fatalf("❌ Audit log misses request metadata: %+v", update)
+	}
+
+	query.Set("from", time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	if auditLogs := helper.GetAuditLogs(t, &client, server, testingVariables, query); len(auditLogs) != 0 {
+		t.Fatalf("❌ Expected no audit logs after the time range, got %d", len(auditLogs))
+	}
+	t.Logf("✅ Successfully audited project %s", projectID)
+}
+
+func TestAuditLogRecordsSignupWithRequestMetadata(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := http.Client{}
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+
+	query := url.Values{}
+	query.Set("entity_type", "user")
+	auditLogs := helper.GetAuditLogs(t, &client, server, testingVariables, query)
+	if len(auditLogs) != 1 || auditLogs[0].Action != "create" {
+		t.Fatalf("❌ Expected the signup audit log, got %+v", auditLogs)
+	}
+	signup := auditLogs[0]
+	if signup.ActorID != signup.EntityID || signup.RequestID == "" || signup.IP == "" {
+		t.Fatalf("❌ Signup audit log misses request metadata: %+v", signup)
+	}
+}
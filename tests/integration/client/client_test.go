@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestClientWithAPIKey(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx := context.Background()
+	email := "user" + uuid.NewString() + "@example.com"
+
+	sessionClient := client.New(server.URL)
+	if _, err := sessionClient.Signup(ctx, client.UserInput{Email: email, Password: "P@ssw0rd"}); err != nil {
+		t.Fatalf("❌ Failed to sign up user. Email: %s, error: %v", email, err)
+	}
+	createdKey, err := sessionClient.CreateAPIKey(ctx, client.APIKeyInput{Name: "integration test"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create api key: %v", err)
+	}
+	if createdKey.Key == "" || createdKey.Prefix == "" {
+		t.Fatalf("❌ Created api key misses the key or its prefix: %+v", createdKey)
+	}
+
+	keyClient := client.New(server.URL, client.WithAPIKey(createdKey.Key))
+	profile, err := keyClient.Profile(ctx)
+	if err != nil || profile.Email != email {
+		t.Fatalf("❌ Profile with api key failed: %+v, %v", profile, err)
+	}
+
+	project, err := keyClient.CreateProject(ctx, client.ProjectInput{Name: "SDK Project"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create project: %v", err)
+	}
+	task, err := keyClient.CreateTask(ctx, client.CreateTaskInput{Name: "SDK Task", ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("❌ Failed to create task: %v", err)
+	}
+	if err := keyClient.StartTask(ctx, task.ID); err != nil {
+		t.Fatalf("❌ Failed to start task: %v", err)
+	}
+	if err := keyClient.StopTask(ctx, task.ID); err != nil {
+		t.Fatalf("❌ Failed to stop task: %v", err)
+	}
+
+	taskID := task.ID
+	timeRecords, err := keyClient.ListTimeRecords(ctx, client.TimeRecordFilter{TaskID: &taskID})
+	if err != nil || len(timeRecords) != 1 || !timeRecords[0].IsClosed {
+		t.Fatalf("❌ Expected one closed time record, got %+v, %v", timeRecords, err)
+	}
+	history, err := keyClient.TimeRecordHistory(ctx, timeRecords[0].ID)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("❌ Expected create and stop versions, got %+v, %v", history, err)
+	}
+
+	if _, err := keyClient.GetTask(ctx, task.ID+1_000_000); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("❌ Expected ErrNotFound for a missing task, got %v", err)
+	}
+	var apiError *client.APIError
+	if _, err := keyClient.CreateWebhook(ctx, client.WebhookInput{URL: "not a url", EventTypes: []string{"task.created"}}); !errors.As(err, &apiError) || apiError.Message == "" {
+		t.Fatalf("❌ Expected an APIError carrying the server message, got %v", err)
+	}
+
+	apiKeys, err := sessionClient.ListAPIKeys(ctx)
+	if err != nil || len(apiKeys) != 1 || apiKeys[0].LastUsedAt == nil {
+		t.Fatalf("❌ Expected one used api key, got %+v, %v", apiKeys, err)
+	}
+	if err := sessionClient.DeleteAPIKey(ctx, createdKey.ID); err != nil {
+		t.Fatalf("❌ Failed to delete api key: %v", err)
+	}
+	if _, err := keyClient.Profile(ctx); !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("❌ Expected ErrUnauthorized for a deleted api key, got %v", err)
+	}
+
+	if err := sessionClient.DeleteCurrentUser(ctx); err != nil {
+		t.Fatalf("❌ Failed to delete user: %v", err)
+	}
+}
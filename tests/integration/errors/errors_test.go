@@ -0,0 +1,107 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestErrorResponses(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx := context.Background()
+	email := "user" + uuid.NewString() + "@example.com"
+	credentials := client.UserInput{Email: email, Password: "P@ssw0rd"}
+
+	tk := client.New(server.URL)
+	if _, err := tk.Signup(ctx, credentials); err != nil {
+		t.Fatalf("❌ Failed to sign up user. Email: %s, error: %v", email, err)
+	}
+
+	_, err := client.New(server.URL).Signup(ctx, credentials)
+	expectAPIError(t, err, http.StatusConflict, "email_taken")
+
+	_, err = client.New(server.URL).Signup(ctx, client.UserInput{Email: "not an email", Password: "short"})
+	apiError := expectAPIError(t, err, http.StatusUnprocessableEntity, "validation_failed")
+	if len(apiError.Fields) != 2 {
+		t.Fatalf("❌ Expected email and password field errors, got %+v", apiError.Fields)
+	}
+
+	if _, err := tk.CreateProject(ctx, client.ProjectInput{Name: "Errors Project"}); err != nil {
+		t.Fatalf("❌ Failed to create project: %v", err)
+	}
+	_, err = tk.CreateProject(ctx, client.ProjectInput{Name: "Errors Project"})
+	expectAPIError(t, err, http.StatusConflict, "project_name_taken")
+
+	_, err = tk.CreateTask(ctx, client.CreateTaskInput{Name: "  ", Tags: []string{"ok", "-bad"}, Status: "Unknown"})
+	apiError = expectAPIError(t, err, http.StatusUnprocessableEntity, "validation_failed")
+	if !hasFieldErrors(apiError, "name", "tags[1]", "status") {
+		t.Fatalf("❌ Expected name, tags[1] and status field errors, got %+v", apiError.Fields)
+	}
+
+	_, err = tk.GetTask(ctx, 1_000_000_000)
+	expectAPIError(t, err, http.StatusNotFound, "not_found")
+
+	_, err = client.New(server.URL).Profile(ctx)
+	expectAPIError(t, err, http.StatusUnauthorized, "missing_authorization")
+
+	resp, err := http.Post(server.URL+"/api/user/signup", "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatalf("❌ Failed to send malformed signup: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("❌ Failed to decode error body: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest || body.Code != "invalid_input" || body.Error == "" {
+		t.Fatalf("❌ Expected 400 invalid_input for malformed JSON, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+func expectAPIError(t *testing.T, err error, statusCode int, code client.ErrorCode) *client.APIError {
+	t.Helper()
+	var apiError *client.APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("❌ Expected an API error %d %s, got %v", statusCode, code, err)
+	}
+	if apiError.StatusCode != statusCode || apiError.Code != code {
+		t.Fatalf("❌ Expected %d %s, got %d %s: %s", statusCode, code, apiError.StatusCode, apiError.Code, apiError.Message)
+	}
+	return apiError
+}
+
+func hasFieldErrors(apiError *client.APIError, fields ...string) bool {
+	if len(apiError.Fields) != len(fields) {
+		return false
+	}
+	for i, field := range fields {
+		if apiError.Fields[i].Field != field {
+			return false
+		}
+	}
+	return true
+}
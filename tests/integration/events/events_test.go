@@ -0,0 +1,213 @@
+package events_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// quietPeriod is how long a stream has to stay silent to count as receiving nothing
+const quietPeriod = time.Second
+
+func TestEventStreamsDeliverEventsOfTheirUser(t *testing.T) {
+	server := newServer(t)
+	client := http.Client{}
+	owner := signUp(t, &client, server)
+	stranger := signUp(t, &client, server)
+
+	ownerSSE := openSSE(t, server, owner.AuthToken)
+	strangerSSE := openSSE(t, server, stranger.AuthToken)
+	ownerWS := openWebSocket(t, server, owner.AuthToken)
+
+	helper.CreateProject(t, &client, server, owner, "Streamed Project")
+	helper.CreateTask(t, &client, server, owner, 0, "Streamed Task")
+	helper.StartTask(t, &client, server, owner, owner.TaskID[0])
+
+	for name, events := range map[string]<-chan event.Event{"SSE": ownerSSE, "WebSocket": ownerWS} {
+		started := awaitEvent(t, events, event.TaskStarted)
+		if started.UserID == "" || started.ID == "" {
+			t.Fatalf("❌ %s event misses its id or user: %+v", name, started)
+		}
+	}
+
+	timeout := time.After(quietPeriod)
+	for {
+		select {
+		case leaked, ok := <-strangerSSE:
+			if ok {
+				t.Fatalf("❌ Second user received %s of the first user", leaked.Type)
+			}
+			t.Fatal("❌ Stream of the second user ended")
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func TestEventStreamsRejectMissingAndInvalidTokens(t *testing.T) {
+	server := newServer(t)
+	client := http.Client{}
+
+	cases := map[string]string{
+		"missing token":          server.URL + "/api/events/stream",
+		"invalid query token":    server.URL + "/api/events/stream?access_token=invalid",
+		"missing ws token":       server.URL + "/api/events/ws",
+		"invalid ws query token": server.URL + "/api/events/ws?access_token=invalid",
+	}
+	for name, url := range cases {
+		resp := helper.DoGet(t, &client, url)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("❌ %s: expected status 401, got %d", name, resp.StatusCode)
+		}
+	}
+
+	resp := helper.DoGetAuth(t, &client, server.URL+"/api/events/stream", "invalid")
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("❌ Invalid bearer token: expected status 401, got %d", resp.StatusCode)
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/events/ws?access_token=invalid"
+	connection, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err == nil {
+		_ = connection.Close()
+		t.Fatal("❌ WebSocket with an invalid token was accepted")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("❌ WebSocket with an invalid token: expected status 401, got %v", resp)
+	}
+}
+
+// newServer serves the API and relays stored events to the bus until the test ends
+func newServer(t *testing.T) *httptest.Server {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	ctx, cancel := context.WithCancel(context.Background())
+	outboxDispatcher := container.OutboxDispatcher
+	outboxDispatcher.PollInterval = 100 * time.Millisecond
+	go outboxDispatcher.Run(ctx)
+
+	t.Cleanup(func() {
+		cancel()
+		container.Bus.Close()
+		server.Close()
+	})
+	return server
+}
+
+func signUp(t *testing.T, client *http.Client, server *httptest.Server) *helper.TestingContext {
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+	return testingVariables
+}
+
+// openSSE subscribes to /api/events/stream and decodes the data of every event it sends
+func openSSE(t *testing.T, server *httptest.Server, token string) <-chan event.Event {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events/stream", nil)
+	if err != nil {
+		cancel()
+		t.Fatalf("❌ Failed to create SSE request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("❌ SSE request failed: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		_ = resp.Body.Close()
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ SSE stream: expected status 200, got %d", resp.StatusCode)
+	}
+
+	events := make(chan event.Event, event.DefaultBufferSize)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var received event.Event
+			if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &received); err == nil {
+				events <- received
+			}
+		}
+	}()
+	return events
+}
+
+// openWebSocket connects to /api/events/ws with the token in the query and decodes every message
+func openWebSocket(t *testing.T, server *httptest.Server, token string) <-chan event.Event {
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/events/ws?access_token=" + token
+	connection, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("❌ WebSocket connection failed: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+
+	events := make(chan event.Event, event.DefaultBufferSize)
+	go func() {
+		defer close(events)
+		for {
+			var received event.Event
+			if err := connection.ReadJSON(&received); err != nil {
+				return
+			}
+			events <- received
+		}
+	}()
+	return events
+}
+
+// awaitEvent skips events of other types until one of eventType arrives
+func awaitEvent(t *testing.T, events <-chan event.Event, eventType event.Type) event.Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case received, ok := <-events:
+			if !ok {
+				t.Fatalf("❌ Stream ended before %s arrived", eventType)
+			}
+			if received.Type == eventType {
+				return received
+			}
+		case <-timeout:
+			t.Fatalf("❌ %s did not arrive", eventType)
+		}
+	}
+}
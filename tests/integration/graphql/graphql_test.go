@@ -0,0 +1,190 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/pkg/client"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const dashboardQuery = `query Dashboard($from: DateTime) {
+  me {
+    email
+    projects {
+      id
+      name
+      totalSeconds(from: $from)
+      tasks {
+        id
+        name
+        status
+        totalSeconds(from: $from)
+        timeRecords(from: $from) { id durationSeconds isClosed }
+      }
+    }
+  }
+}`
+
+type dashboard struct {
+	Me struct {
+		Email    string `json:"email"`
+		Projects []struct {
+			ID           string `json:"id"`
+			Name         string `json:"name"`
+			TotalSeconds int    `json:"totalSeconds"`
+			Tasks        []struct {
+				ID           string `json:"id"`
+				Name         string `json:"name"`
+				Status       string `json:"status"`
+				TotalSeconds int    `json:"totalSeconds"`
+				TimeRecords  []struct {
+					ID              string `json:"id"`
+					DurationSeconds int    `json:"durationSeconds"`
+				} `json:"timeRecords"`
+			} `json:"tasks"`
+		} `json:"projects"`
+	} `json:"me"`
+}
+
+func TestGraphQLDashboardAndMutations(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx := context.Background()
+	email := "user" + uuid.NewString() + "@example.com"
+	api := client.New(server.URL)
+	if _, err := api.Signup(ctx, client.UserInput{Email: email, Password: "P@ssw0rd"}); err != nil {
+		t.Fatalf("❌ Failed to sign up user. Email: %s, error: %v", email, err)
+	}
+
+	project, err := api.CreateProject(ctx, client.ProjectInput{Name: "GraphQL Project"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create project: %v", err)
+	}
+	projectID := strconv.FormatUint(project.ID, 10)
+
+	var created struct {
+		CreateTask struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"createTask"`
+	}
+	err = api.GraphQL(ctx, `mutation($input: CreateTaskInput!) { createTask(input: $input) { id status } }`,
+		map[string]any{"input": map[string]any{"name": "GraphQL Task", "projectId": projectID, "tags": []string{"gql"}}},
+		&created)
+	if err != nil || created.CreateTask.Status != "OPENED" {
+		t.Fatalf("❌ Failed to create task: %+v, %v", created, err)
+	}
+	taskID := created.CreateTask.ID
+	second, err := api.CreateTask(ctx, client.CreateTaskInput{Name: "Second Task", ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("❌ Failed to create task: %v", err)
+	}
+
+	start := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	for _, id := range []string{taskID, strconv.FormatUint(second.ID, 10)} {
+		err = api.GraphQL(ctx, `mutation($input: CreateTimeRecordInput!) { createTimeRecord(input: $input) { id } }`,
+			map[string]any{"input": map[string]any{
+				"taskId":    id,
+				"startTime": start.Format(time.RFC3339),
+				"endTime":   start.Add(30 * time.Minute).Format(time.RFC3339),
+			}}, nil)
+		if err != nil {
+			t.Fatalf("❌ Failed to create time record: %v", err)
+		}
+	}
+
+	var started struct {
+		StartTask struct {
+			Status string `json:"status"`
+		} `json:"startTask"`
+	}
+	err = api.GraphQL(ctx, `mutation($id: ID!) { startTask(id: $id) { status } }`, map[string]any{"id": taskID}, &started)
+	if err != nil || started.StartTask.Status != "WORKING_ON" {
+		t.Fatalf("❌ Failed to start task: %+v, %v", started, err)
+	}
+	if err := api.GraphQL(ctx, `mutation($id: ID!) { stopTask(id: $id) { id } }`, map[string]any{"id": taskID}, nil); err != nil {
+		t.Fatalf("❌ Failed to stop task: %v", err)
+	}
+
+	var result dashboard
+	err = api.GraphQL(ctx, dashboardQuery, map[string]any{"from": start.Add(-time.Minute).Format(time.RFC3339)}, &result)
+	if err != nil {
+		t.Fatalf("❌ Dashboard query failed: %v", err)
+	}
+	if result.Me.Email != email || len(result.Me.Projects) != 1 {
+		t.Fatalf("❌ Expected one project of %s, got %+v", email, result.Me)
+	}
+	dashboardProject := result.Me.Projects[0]
+	if dashboardProject.ID != projectID || len(dashboardProject.Tasks) != 2 {
+		t.Fatalf("❌ Expected the project with two tasks, got %+v", dashboardProject)
+	}
+	taskTotal := 0
+	for _, task := range dashboardProject.Tasks {
+		taskTotal += task.TotalSeconds
+		recordTotal := 0
+		for _, timeRecord := range task.TimeRecords {
+			recordTotal += timeRecord.DurationSeconds
+		}
+		if recordTotal != task.TotalSeconds {
+			t.Fatalf("❌ Task %s total %d does not match its records %d", task.ID, task.TotalSeconds, recordTotal)
+		}
+	}
+	if taskTotal < 3600 || dashboardProject.TotalSeconds != taskTotal {
+		t.Fatalf("❌ Expected a project total of the task totals (>= 3600), got %d and %d", dashboardProject.TotalSeconds, taskTotal)
+	}
+
+	var missing struct {
+		Task *struct{ ID string } `json:"task"`
+	}
+	if err := api.GraphQL(ctx, `{ task(id: "0") { id } }`, nil, &missing); err != nil || missing.Task != nil {
+		t.Fatalf("❌ Expected null for a missing task, got %+v, %v", missing, err)
+	}
+
+	err = api.GraphQL(ctx, `mutation($input: CreateTimeRecordInput!) { createTimeRecord(input: $input) { id } }`,
+		map[string]any{"input": map[string]any{
+			"taskId":    taskID,
+			"startTime": start.Format(time.RFC3339),
+			"endTime":   start.Add(-time.Minute).Format(time.RFC3339),
+		}}, nil)
+	var fieldErrors client.GraphQLErrors
+	if !errors.As(err, &fieldErrors) || fieldErrors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Fatalf("❌ Expected a BAD_USER_INPUT error for an inverted range, got %v", err)
+	}
+
+	deep := `{ timeRecords { task { project { tasks { timeRecords { task { project { tasks { id } } } } } } } } }`
+	err = api.GraphQL(ctx, deep, nil, nil)
+	if !errors.Is(err, client.ErrBadRequest) || !strings.Contains(err.Error(), "depth") {
+		t.Fatalf("❌ Expected the depth limit to reject the query, got %v", err)
+	}
+
+	oversized := "{ me { email } }" + strings.Repeat(" ", 70<<10)
+	err = api.GraphQL(ctx, oversized, nil, nil)
+	var apiError *client.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("❌ Expected an oversized request to be rejected with 413, got %v", err)
+	}
+
+	if err := api.DeleteCurrentUser(ctx); err != nil {
+		t.Fatalf("❌ Failed to delete user: %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package grpc_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// quietPeriod is how long a stream has to stay silent to count as receiving nothing
+const quietPeriod = time.Second
+
+func TestGRPCWatchTimersStreamsEventsOfTheCaller(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+	container.OutboxDispatcher.PollInterval = 100 * time.Millisecond
+	dispatchCtx, stopDispatching := context.WithCancel(context.Background())
+	t.Cleanup(stopDispatching)
+	go container.OutboxDispatcher.Run(dispatchCtx)
+
+	connection := dial(t, container)
+	users := pb.NewUserServiceClient(connection)
+	tasks := pb.NewTaskServiceClient(connection)
+	events := pb.NewEventServiceClient(connection)
+
+	owner := signUp(t, users)
+	stranger := signUp(t, users)
+
+	first, err := tasks.CreateTask(owner, &pb.CreateTaskRequest{Name: "Watched Task"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create task: %v", err)
+	}
+	second, err := tasks.CreateTask(owner, &pb.CreateTaskRequest{Name: "Other Task"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create task: %v", err)
+	}
+
+	allCtx, cancelAll := context.WithCancel(owner)
+	defer cancelAll()
+	allTimers := watch(t, events, allCtx, 0)
+	firstTimers := watch(t, events, owner, first.GetId())
+	strangerTimers := watch(t, events, stranger, 0)
+
+	if _, err := tasks.StartTask(owner, &pb.TaskIDRequest{Id: first.GetId()}); err != nil {
+		t.Fatalf("❌ Failed to start task: %v", err)
+	}
+	if _, err := tasks.StopTask(owner, &pb.TaskIDRequest{Id: first.GetId()}); err != nil {
+		t.Fatalf("❌ Failed to stop task: %v", err)
+	}
+	if _, err := tasks.StartTask(owner, &pb.TaskIDRequest{Id: second.GetId()}); err != nil {
+		t.Fatalf("❌ Failed to start task: %v", err)
+	}
+
+	// the outbox keeps the order of the events of one task, those of different tasks may interleave
+	streamed := awaitTimerEvents(t, allTimers,
+		timerEventOf(event.TaskStarted, first.GetId()),
+		timerEventOf(event.TaskStopped, first.GetId()),
+		timerEventOf(event.TaskStarted, second.GetId()),
+	)
+	started := slices.Index(streamed, timerEventOf(event.TaskStarted, first.GetId()))
+	if started > slices.Index(streamed, timerEventOf(event.TaskStopped, first.GetId())) {
+		t.Fatalf("❌ Events of the first task were streamed out of order: %v", streamed)
+	}
+
+	streamed = awaitTimerEvents(t, firstTimers,
+		timerEventOf(event.TimeRecordCreated, first.GetId()),
+		timerEventOf(event.TaskStarted, first.GetId()),
+		timerEventOf(event.TimeRecordUpdated, first.GetId()),
+		timerEventOf(event.TaskStopped, first.GetId()),
+	)
+	for _, streamedEvent := range streamed {
+		if streamedEvent.taskID != first.GetId() {
+			t.Fatalf("❌ Stream of task %d received %v", first.GetId(), streamedEvent)
+		}
+	}
+	expectNoTimerEvent(t, firstTimers)
+	expectNoTimerEvent(t, strangerTimers)
+
+	cancelAll()
+	for received := range allTimers {
+		if received.err != nil && status.Code(received.err) != codes.Canceled {
+			t.Fatalf("❌ Cancelled stream ended with %v, want Canceled", received.err)
+		}
+	}
+}
+
+func TestGRPCWatchTimersRequiresAuthentication(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+	events := pb.NewEventServiceClient(dial(t, container))
+
+	tokens := map[string]context.Context{
+		"missing token": context.Background(),
+		"invalid token": metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid"),
+	}
+	for name, ctx := range tokens {
+		stream, err := events.WatchTimers(ctx, &pb.WatchTimersRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("❌ %s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+}
+
+// signUp creates a user and returns a context authenticated as them
+func signUp(t *testing.T, users pb.UserServiceClient) context.Context {
+	email := "user" + uuid.NewString() + "@example.com"
+	session, err := users.Signup(context.Background(), &pb.Credentials{Email: email, Password: "P@ssw0rd"})
+	if err != nil {
+		t.Fatalf("❌ Failed to sign up user. Email: %s, error: %v", email, err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+session.GetToken())
+	t.Cleanup(func() {
+		_, _ = users.DeleteCurrentUser(ctx, &emptypb.Empty{})
+	})
+	return ctx
+}
+
+type timerEventOrError struct {
+	event *pb.TimerEvent
+	err   error
+}
+
+// watch opens a WatchTimers stream and relays what it receives until the stream ends,
+// it returns once the server subscribed so no later event is missed
+func watch(t *testing.T, events pb.EventServiceClient, ctx context.Context, taskID uint64) <-chan timerEventOrError {
+	stream, err := events.WatchTimers(ctx, &pb.WatchTimersRequest{TaskId: taskID})
+	if err != nil {
+		t.Fatalf("❌ Failed to watch timers: %v", err)
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("❌ Timer stream was not opened: %v", err)
+	}
+
+	received := make(chan timerEventOrError, 16)
+	go relay(stream, received)
+	return received
+}
+
+func relay(stream grpc.ServerStreamingClient[pb.TimerEvent], received chan<- timerEventOrError) {
+	defer close(received)
+	for {
+		timerEvent, err := stream.Recv()
+		received <- timerEventOrError{event: timerEvent, err: err}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// timerEvent identifies a streamed event by its type and task, time records count as being about their task
+type timerEvent struct {
+	eventType string
+	taskID    uint64
+}
+
+func timerEventOf(eventType event.Type, taskID uint64) timerEvent {
+	return timerEvent{eventType: string(eventType), taskID: taskID}
+}
+
+// awaitTimerEvents receives until every wanted event arrived and returns all received events in order
+func awaitTimerEvents(t *testing.T, received <-chan timerEventOrError, wanted ...timerEvent) []timerEvent {
+	t.Helper()
+	missing := make(map[timerEvent]bool)
+	for _, wantedEvent := range wanted {
+		missing[wantedEvent] = true
+	}
+
+	var streamed []timerEvent
+	timeout := time.After(10 * time.Second)
+	for len(missing) > 0 {
+		select {
+		case next, ok := <-received:
+			if !ok || next.err != nil {
+				t.Fatalf("❌ Timer stream ended while waiting for %v: %v", missing, next.err)
+			}
+			streamedEvent := timerEvent{eventType: next.event.GetType(), taskID: next.event.GetTask().GetId()}
+			if next.event.GetTimeRecord() != nil {
+				streamedEvent.taskID = next.event.GetTimeRecord().GetTaskId()
+			}
+			if next.event.GetId() == "" || next.event.GetOccurredAt() == nil {
+				t.Fatalf("❌ Streamed event misses its id or time: %v", next.event)
+			}
+			streamed = append(streamed, streamedEvent)
+			delete(missing, streamedEvent)
+		case <-timeout:
+			t.Fatalf("❌ %v were not streamed, got %v", missing, streamed)
+		}
+	}
+	return streamed
+}
+
+func expectNoTimerEvent(t *testing.T, received <-chan timerEventOrError) {
+	t.Helper()
+	select {
+	case next := <-received:
+		t.Fatalf("❌ Unexpected timer event %v, error %v", next.event, next.err)
+	case <-time.After(quietPeriod):
+	}
+}
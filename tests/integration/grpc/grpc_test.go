@@ -0,0 +1,153 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/event"
+	"github.com/advanced-coder-com/go-timekeeper/internal/grpcapi"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	pb "github.com/advanced-coder-com/go-timekeeper/pkg/pb/timekeeper/v1"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGRPCTaskFlow(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	connection := dial(t, container)
+	users := pb.NewUserServiceClient(connection)
+	projects := pb.NewProjectServiceClient(connection)
+	tasks := pb.NewTaskServiceClient(connection)
+	timeRecords := pb.NewTimeRecordServiceClient(connection)
+	events := pb.NewEventServiceClient(connection)
+
+	ctx := context.Background()
+	if _, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("❌ Expected Unauthenticated without a token, got %v", err)
+	}
+
+	email := "user" + uuid.NewString() + "@example.com"
+	session, err := users.Signup(ctx, &pb.Credentials{Email: email, Password: "P@ssw0rd"})
+	if err != nil {
+		t.Fatalf("❌ Failed to sign up user. Email: %s, error: %v", email, err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+session.GetToken())
+
+	profile, err := users.GetProfile(ctx, &emptypb.Empty{})
+	if err != nil || profile.GetEmail() != email {
+		t.Fatalf("❌ Profile failed: %v, %v", profile, err)
+	}
+
+	project, err := projects.CreateProject(ctx, &pb.CreateProjectRequest{Name: "gRPC Project"})
+	if err != nil {
+		t.Fatalf("❌ Failed to create project: %v", err)
+	}
+	task, err := tasks.CreateTask(ctx, &pb.CreateTaskRequest{
+		Name:      "gRPC Task",
+		ProjectId: project.GetId(),
+		Tags:      []string{"grpc"},
+	})
+	if err != nil {
+		t.Fatalf("❌ Failed to create task: %v", err)
+	}
+	if task.GetStatus() != string(model.StatusOpened) {
+		t.Fatalf("❌ Expected an opened task, got %s", task.GetStatus())
+	}
+	if _, err := tasks.CreateTask(ctx, &pb.CreateTaskRequest{Name: "Bad", Status: "Unknown"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("❌ Expected InvalidArgument for an unknown status, got %v", err)
+	}
+
+	if _, err := tasks.StartTask(ctx, &pb.TaskIDRequest{Id: task.GetId()}); err != nil {
+		t.Fatalf("❌ Failed to start task: %v", err)
+	}
+	if _, err := tasks.StopTask(ctx, &pb.TaskIDRequest{Id: task.GetId()}); err != nil {
+		t.Fatalf("❌ Failed to stop task: %v", err)
+	}
+
+	taskID := task.GetId()
+	list, err := timeRecords.ListTimeRecords(ctx, &pb.ListTimeRecordsRequest{TaskId: &taskID})
+	if err != nil || len(list.GetTimeRecords()) != 1 || !list.GetTimeRecords()[0].GetIsClosed() {
+		t.Fatalf("❌ Expected one closed time record, got %v, %v", list, err)
+	}
+	history, err := timeRecords.GetTimeRecordHistory(ctx, &pb.TimeRecordIDRequest{Id: list.GetTimeRecords()[0].GetId()})
+	if err != nil || len(history.GetVersions()) != 2 {
+		t.Fatalf("❌ Expected create and stop versions, got %v, %v", history, err)
+	}
+
+	if _, err := tasks.GetTask(ctx, &pb.GetTaskRequest{Id: 0}); status.Code(err) != codes.NotFound {
+		t.Fatalf("❌ Expected NotFound for a missing task, got %v", err)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	stream, err := events.WatchTimers(watchCtx, &pb.WatchTimersRequest{TaskId: task.GetId()})
+	if err != nil {
+		t.Fatalf("❌ Failed to watch timers: %v", err)
+	}
+	// the subscription is made when the server receives the call, publish until the stream sees the event
+	published := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		started := model.Task{ID: task.GetId(), Name: task.GetName(), Status: model.StatusWorkingOn}
+		for {
+			container.Bus.Publish(container.Bus.New(event.TaskCreated, session.GetId(), started))
+			container.Bus.Publish(container.Bus.New(event.TaskStarted, session.GetId(), model.Task{ID: task.GetId() + 1}))
+			container.Bus.Publish(container.Bus.New(event.TaskStarted, session.GetId(), started))
+			select {
+			case <-published:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	received, err := stream.Recv()
+	close(published)
+	if err != nil {
+		t.Fatalf("❌ Failed to receive timer event: %v", err)
+	}
+	if received.GetType() != string(event.TaskStarted) || received.GetTask().GetId() != task.GetId() {
+		t.Fatalf("❌ Expected task.started of task %d, got %v", task.GetId(), received)
+	}
+
+	if _, err := users.DeleteCurrentUser(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("❌ Failed to delete user: %v", err)
+	}
+}
+
+// dial serves the gRPC API on an in-memory listener
+func dial(t *testing.T, container *app.Container) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpcapi.NewServer(container)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("❌ Failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+	return connection
+}
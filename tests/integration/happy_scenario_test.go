@@ -4,26 +4,24 @@ import (
 	"fmt"
 	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
 	"github.com/google/uuid"
-	"github.com/spf13/viper"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/db"
 	"github.com/advanced-coder-com/go-timekeeper/internal/router"
 	"github.com/gin-gonic/gin"
 )
 
 func TestHappyScenario(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -0,0 +1,63 @@
+package health_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/buildinfo"
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+)
+
+func TestProbes(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	var health map[string]string
+	getJSON(t, server.URL+"/healthz", http.StatusOK, &health)
+	if health["status"] != "ok" {
+		t.Fatalf("❌ Expected status ok, got %+v", health)
+	}
+
+	// the test database is migrated with make migrate-test-up
+	var readiness service.Readiness
+	getJSON(t, server.URL+"/readyz", http.StatusOK, &readiness)
+	if !readiness.Ready() || readiness.Checks["database"] != "ok" || readiness.Checks["migrations"] != "ok" {
+		t.Fatalf("❌ Expected a ready server, got %+v", readiness)
+	}
+
+	var version buildinfo.Info
+	getJSON(t, server.URL+"/version", http.StatusOK, &version)
+	if version.Version == "" || version.GoVersion == "" {
+		t.Fatalf("❌ Expected the version and Go version, got %+v", version)
+	}
+}
+
+func getJSON(t *testing.T, url string, expectedStatus int, body any) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("❌ Failed to get %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("❌ Expected status %d from %s, got %d", expectedStatus, url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(body); err != nil {
+		t.Fatalf("❌ Failed to decode the body of %s: %v", url, err)
+	}
+}
@@ -0,0 +1,36 @@
+package integration_test_helper
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type AuditLog struct {
+	ID         uint64                     `json:"id"`
+	ActorID    string                     `json:"actor_id"`
+	Action     string                     `json:"action"`
+	EntityType string                     `json:"entity_type"`
+	EntityID   string                     `json:"entity_id"`
+	Changes    map[string]json.RawMessage `json:"changes"`
+	RequestID  string                     `json:"request_id"`
+	IP         string                     `json:"ip"`
+}
+
+func GetAuditLogs(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	query url.Values,
+) []AuditLog {
+	resp := DoGetAuth(t, client, server.URL+"/api/audit/list?"+query.Encode(), testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get audit logs failed: status %d", resp.StatusCode)
+	}
+	var auditLogs []AuditLog
+	DecodeJSON(t, resp.Body, &auditLogs)
+	return auditLogs
+}
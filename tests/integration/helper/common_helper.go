@@ -3,10 +3,17 @@ package integration_test_helper
 import (
 	"bytes"
 	"encoding/json"
-	"github.com/spf13/viper"
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/clock"
+	"github.com/advanced-coder-com/go-timekeeper/internal/config"
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/logs"
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
+	"gorm.io/gorm"
 	"io"
 	"log"
 	"net/http"
+	"sync"
 	"testing"
 )
 
@@ -18,14 +25,39 @@ type TestingContext struct {
 	TaskID    []uint64
 }
 
-func InitConfig(env string) {
-	viper.SetConfigFile(env)
-	viper.SetConfigType("env")
-	viper.AutomaticEnv()
-
-	if err := viper.ReadInConfig(); err != nil {
-		log.Printf("No .env.test file found or error reading it: %v", err)
+// InitConfig loads the test configuration and sets up the logger main configures at startup
+func InitConfig(env string) *config.Config {
+	cfg, err := config.Load(env)
+	if err != nil {
+		log.Fatalf("Invalid test configuration in %s or the environment: %v", env, err)
 	}
+	logs.Init(cfg.Logging)
+	return cfg
+}
+
+var (
+	database     *gorm.DB
+	databaseOnce sync.Once
+)
+
+// Database migrates the test database with the embedded migrations and opens it, once per test binary
+func Database(cfg *config.Config) *gorm.DB {
+	databaseOnce.Do(func() {
+		if err := db.MigrateUp(cfg.Database, logs.Get()); err != nil {
+			log.Fatalf("Test database migration failed: %v", err)
+		}
+		var err error
+		database, err = db.Open(cfg.Database)
+		if err != nil {
+			log.Fatalf("Test database setup failed: %v", err)
+		}
+	})
+	return database
+}
+
+// NewContainer wires the application against the test database the way main does
+func NewContainer(cfg *config.Config) *app.Container {
+	return app.New(cfg, repository.NewRepositories(Database(cfg)), clock.Real(), logs.Get())
 }
 
 var ErrorMessage struct {
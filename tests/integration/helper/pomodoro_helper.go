@@ -0,0 +1,59 @@
+package integration_test_helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type PomodoroState struct {
+	Active           bool `json:"active"`
+	RemainingSeconds int  `json:"remaining_seconds"`
+	CompletedToday   int  `json:"completed_today"`
+	Session          *struct {
+		ID     uint64 `json:"id"`
+		TaskID uint64 `json:"task_id"`
+		Phase  string `json:"phase"`
+		Status string `json:"status"`
+	} `json:"session"`
+}
+
+func StartPomodoro(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	taskId uint64,
+	focusMinutes int,
+) *http.Response {
+	url := server.URL + "/api/pomodoro/start/" + strconv.FormatUint(taskId, 10)
+	body := map[string]int{
+		"focus_minutes": focusMinutes,
+	}
+	return DoPostAuth(t, client, url, body, testVars.AuthToken)
+}
+
+func StopPomodoro(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+) *http.Response {
+	return DoPostAuth(t, client, server.URL+"/api/pomodoro/stop", nil, testVars.AuthToken)
+}
+
+func GetPomodoroState(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+) PomodoroState {
+	resp := DoGetAuth(t, client, server.URL+"/api/pomodoro/state", testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get pomodoro state failed: status %d", resp.StatusCode)
+	}
+	var state PomodoroState
+	DecodeJSON(t, resp.Body, &state)
+	return state
+}
@@ -0,0 +1,73 @@
+package integration_test_helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type TimeRecord struct {
+	ID          uint64  `json:"id"`
+	TaskID      uint64  `json:"task_id"`
+	EndTime     *string `json:"end_time"`
+	IsClosed    bool    `json:"is_closed"`
+	Description string  `json:"description"`
+}
+
+type TimeRecordVersion struct {
+	Version     int    `json:"version"`
+	Operation   string `json:"operation"`
+	IsClosed    bool   `json:"is_closed"`
+	Description string `json:"description"`
+	Deleted     bool   `json:"deleted"`
+}
+
+func GetTimeRecords(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	taskID uint64,
+) []TimeRecord {
+	url := server.URL + "/api/time-records/list?task_id=" + strconv.FormatUint(taskID, 10)
+	resp := DoGetAuth(t, client, url, testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get time records failed: status %d", resp.StatusCode)
+	}
+	var timeRecords []TimeRecord
+	DecodeJSON(t, resp.Body, &timeRecords)
+	return timeRecords
+}
+
+func GetTimeRecordHistory(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	timeRecordID uint64,
+) []TimeRecordVersion {
+	url := server.URL + "/api/time-records/history/" + strconv.FormatUint(timeRecordID, 10)
+	resp := DoGetAuth(t, client, url, testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get time record history failed: status %d", resp.StatusCode)
+	}
+	var versions []TimeRecordVersion
+	DecodeJSON(t, resp.Body, &versions)
+	return versions
+}
+
+func UndoTimeRecordAction(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+) TimeRecord {
+	resp := DoPostAuth(t, client, server.URL+"/api/time-records/undo", nil, testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("undo time record action failed: status %d", resp.StatusCode)
+	}
+	var timeRecord TimeRecord
+	DecodeJSON(t, resp.Body, &timeRecord)
+	return timeRecord
+}
@@ -0,0 +1,81 @@
+package integration_test_helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type WebhookDelivery struct {
+	ID        uint64 `json:"id"`
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+}
+
+func CreateWebhook(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	url string,
+	secret string,
+	eventTypes []string,
+) uint64 {
+	webhookBody := map[string]interface{}{
+		"url":         url,
+		"secret":      secret,
+		"event_types": eventTypes,
+	}
+	resp := DoPostAuth(t, client, server.URL+"/api/webhooks/create", webhookBody, testVars.AuthToken)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("webhook creation failed: status %d", resp.StatusCode)
+	}
+	var webhookData struct {
+		ID     *uint64 `json:"id"`
+		Secret string  `json:"secret"`
+	}
+	DecodeJSON(t, resp.Body, &webhookData)
+
+	if webhookData.ID == nil || webhookData.Secret != secret {
+		t.Fatal("invalid webhook returned")
+	}
+	return *webhookData.ID
+}
+
+func GetWebhookDeliveries(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	webhookID uint64,
+) []WebhookDelivery {
+	url := server.URL + "/api/webhooks/deliveries/" + strconv.FormatUint(webhookID, 10)
+	resp := DoGetAuth(t, client, url, testVars.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get webhook deliveries failed: status %d", resp.StatusCode)
+	}
+	var deliveries []WebhookDelivery
+	DecodeJSON(t, resp.Body, &deliveries)
+	return deliveries
+}
+
+// RedeliverWebhook queues the payload of delivery again and returns the new delivery
+func RedeliverWebhook(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testVars *TestingContext,
+	deliveryID uint64,
+) WebhookDelivery {
+	url := server.URL + "/api/webhooks/redeliver/" + strconv.FormatUint(deliveryID, 10)
+	resp := DoPostAuth(t, client, url, nil, testVars.AuthToken)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("redeliver webhook failed: status %d", resp.StatusCode)
+	}
+	var delivery WebhookDelivery
+	DecodeJSON(t, resp.Body, &delivery)
+	return delivery
+}
@@ -0,0 +1,105 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/openapi"
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+)
+
+func setupEngine() *gin.Engine {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+	return engine
+}
+
+// TestEveryRouteIsDocumented fails when a route is added to the router package without an entry in the OpenAPI operations
+func TestEveryRouteIsDocumented(t *testing.T) {
+	engine := setupEngine()
+	document := openapi.Get()
+
+	routed := map[string]bool{}
+	for _, route := range engine.Routes() {
+		path := openapi.PathFromGin(route.Path)
+		method := strings.ToLower(route.Method)
+		routed[method+" "+path] = true
+
+		item, ok := document.Paths[path]
+		if !ok || item[method] == nil {
+			t.Errorf("❌ Route %s %s is not documented in internal/openapi", route.Method, route.Path)
+		}
+	}
+
+	for path, item := range document.Paths {
+		for method := range item {
+			if !routed[method+" "+path] {
+				t.Errorf("❌ Documented operation %s %s has no route", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestOpenAPIDocumentIsServed(t *testing.T) {
+	server := httptest.NewServer(setupEngine())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/openapi.json")
+	if err != nil {
+		t.Fatalf("❌ Failed to get the OpenAPI document: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var document struct {
+		OpenAPI    string                    `json:"openapi"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		t.Fatalf("❌ Failed to decode the OpenAPI document: %v", err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		t.Fatalf("❌ Expected an OpenAPI 3 document, got version %q", document.OpenAPI)
+	}
+	if document.Paths["/api/tasks/detail/{id}"]["get"] == nil {
+		t.Fatalf("❌ Expected GET /api/tasks/detail/{id} in the document")
+	}
+
+	var task struct {
+		Properties map[string]struct {
+			Type   string `json:"type"`
+			Format string `json:"format"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(document.Components.Schemas["Task"], &task); err != nil {
+		t.Fatalf("❌ Failed to decode the Task schema: %v", err)
+	}
+	if task.Properties["project_id"].Type != "integer" || task.Properties["tags"].Type != "array" {
+		t.Fatalf("❌ Unexpected Task schema: %+v", task.Properties)
+	}
+
+	uiResp, err := http.Get(server.URL + "/api/docs")
+	if err != nil {
+		t.Fatalf("❌ Failed to get the documentation page: %v", err)
+	}
+	defer uiResp.Body.Close()
+	if uiResp.StatusCode != http.StatusOK || !strings.HasPrefix(uiResp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("❌ Expected the documentation page, got status %d, content type %q", uiResp.StatusCode, uiResp.Header.Get("Content-Type"))
+	}
+}
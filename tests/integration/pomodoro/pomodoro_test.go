@@ -0,0 +1,70 @@
+package pomodoro_test
+
+import (
+	"fmt"
+	"testing"
+
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/gin-gonic/gin"
+)
+
+func TestPomodoroStartStateStop(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := http.Client{}
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+	helper.CreateProject(t, &client, server, testingVariables, "Pomodoro Project")
+	helper.CreateTask(t, &client, server, testingVariables, 0, "Pomodoro Task")
+	taskID := testingVariables.TaskID[0]
+
+	if resp := helper.StartPomodoro(t, &client, server, testingVariables, taskID, 25); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("❌ Failed to start pomodoro. Status: %d", resp.StatusCode)
+	}
+
+	if resp := helper.StartPomodoro(t, &client, server, testingVariables, taskID, 25); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("❌ Second pomodoro should be rejected. Status: %d", resp.StatusCode)
+	}
+
+	state := helper.GetPomodoroState(t, &client, server, testingVariables)
+	if !state.Active || state.Session == nil || state.Session.TaskID != taskID || state.Session.Phase != "Focus" {
+		t.Fatalf("❌ Unexpected pomodoro state after start: %+v", state)
+	}
+	if state.RemainingSeconds <= 0 || state.RemainingSeconds > 25*60 {
+		t.Fatalf("❌ Unexpected remaining seconds: %d", state.RemainingSeconds)
+	}
+
+	if resp := helper.StopPomodoro(t, &client, server, testingVariables); resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Failed to stop pomodoro. Status: %d", resp.StatusCode)
+	}
+
+	state = helper.GetPomodoroState(t, &client, server, testingVariables)
+	if state.Active {
+		t.Fatalf("❌ Pomodoro should not be active after stop: %+v", state)
+	}
+	t.Logf("✅ Successfully started and stopped pomodoro for task %d", taskID)
+}
@@ -0,0 +1,17 @@
+package repository_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/repository/repositorytest"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+)
+
+func TestPostgresRepositories(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	repositorytest.Run(t, container.Repositories)
+}
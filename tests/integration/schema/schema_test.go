@@ -0,0 +1,147 @@
+package schema_test
+
+import (
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/db"
+	"github.com/advanced-coder-com/go-timekeeper/internal/model"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// models are the types stored with GORM, each in the table of its name
+var models = []any{
+	&model.User{},
+	&model.Project{},
+	&model.Task{},
+	&model.TimeRecord{},
+	&model.TimeRecordVersion{},
+	&model.PomodoroSession{},
+	&model.WebhookSubscription{},
+	&model.WebhookDelivery{},
+	&model.OutboxMessage{},
+	&model.AuditLog{},
+	&model.APIKey{},
+}
+
+// TestModelsMatchSchema checks the models against the tables the embedded migrations create: every field has
+// a column of a matching type, nullability and uniqueness, every column has a field and every table a model.
+func TestModelsMatchSchema(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	database := helper.Database(helper.InitConfig("../../../.env.test"))
+
+	tables := []string{db.MigrationsTable}
+	for _, value := range models {
+		parsed, err := schema.Parse(value, &sync.Map{}, database.NamingStrategy)
+		if err != nil {
+			t.Fatalf("parse model %T failed: %v", value, err)
+		}
+		tables = append(tables, parsed.Table)
+
+		t.Run(parsed.Name, func(t *testing.T) {
+			columnTypes, err := database.Migrator().ColumnTypes(value)
+			if err != nil {
+				t.Fatalf("read the columns of %s failed: %v", parsed.Table, err)
+			}
+			columns := make(map[string]gorm.ColumnType, len(columnTypes))
+			for _, column := range columnTypes {
+				columns[column.Name()] = column
+			}
+
+			unique := make(map[string]bool)
+			for _, index := range parsed.ParseIndexes() {
+				if index.Class == "UNIQUE" && len(index.Fields) == 1 {
+					unique[index.Fields[0].DBName] = true
+				}
+			}
+
+			for _, field := range parsed.Fields {
+				if field.DBName == "" {
+					continue
+				}
+				column, ok := columns[field.DBName]
+				if !ok {
+					t.Errorf("%s.%s has no column %s in %s", parsed.Name, field.Name, field.DBName, parsed.Table)
+					continue
+				}
+				delete(columns, field.DBName)
+				checkColumn(t, field, column, field.Unique || unique[field.DBName])
+			}
+			for name := range columns {
+				t.Errorf("column %s.%s has no field in %s", parsed.Table, name, parsed.Name)
+			}
+		})
+	}
+
+	existing, err := database.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("list tables failed: %v", err)
+	}
+	for _, table := range existing {
+		if !slices.Contains(tables, table) {
+			t.Errorf("table %s has no model", table)
+		}
+	}
+}
+
+func checkColumn(t *testing.T, field *schema.Field, column gorm.ColumnType, unique bool) {
+	t.Helper()
+	name := field.Schema.Name + "." + field.Name
+
+	types, length := columnType(field)
+	if !slices.Contains(types, column.DatabaseTypeName()) {
+		t.Errorf("%s is %s, its column is %s, want %s", name, field.DataType, column.DatabaseTypeName(), strings.Join(types, " or "))
+	}
+	if columnLength, ok := column.Length(); length > 0 && (!ok || columnLength != length) {
+		t.Errorf("%s has length %d, its column %d", name, length, columnLength)
+	}
+
+	nullable, _ := column.Nullable()
+	if (field.NotNull || field.PrimaryKey) && nullable {
+		t.Errorf("%s is not null, its column is nullable", name)
+	}
+	if field.FieldType.Kind() == reflect.Pointer && !nullable {
+		t.Errorf("%s is a pointer, its column is NOT NULL and cannot store nil", name)
+	}
+	if primaryKey, _ := column.PrimaryKey(); primaryKey != field.PrimaryKey {
+		t.Errorf("%s is primary key %t, its column %t", name, field.PrimaryKey, primaryKey)
+	}
+	if columnUnique, _ := column.Unique(); unique && !columnUnique {
+		t.Errorf("%s is unique, its column is not", name)
+	}
+}
+
+// columnType returns the Postgres types a column of field may have and the length a varchar(n) type tag sets.
+// A type tag names the type exactly, otherwise any type of the kind of the Go type matches.
+func columnType(field *schema.Field) ([]string, int64) {
+	if tag, ok := field.TagSettings["TYPE"]; ok {
+		name, size, _ := strings.Cut(strings.ToLower(tag), "(")
+		length, _ := strconv.ParseInt(strings.TrimSuffix(size, ")"), 10, 64)
+		if element, isArray := strings.CutSuffix(name, "[]"); isArray {
+			name = "_" + element
+		}
+		return []string{name}, length
+	}
+	switch field.DataType {
+	case schema.Bool:
+		return []string{"bool"}, 0
+	case schema.Int, schema.Uint:
+		return []string{"int2", "int4", "int8"}, 0
+	case schema.Float:
+		return []string{"float4", "float8", "numeric"}, 0
+	case schema.String:
+		return []string{"text", "varchar", "bpchar"}, 0
+	case schema.Time:
+		return []string{"timestamptz"}, 0
+	case schema.Bytes:
+		return []string{"bytea"}, 0
+	}
+	return nil, 0
+}
@@ -0,0 +1,199 @@
+package timerecord_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestTimeRecordHistoryRestoreAndUndo(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := http.Client{}
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+	helper.CreateProject(t, &client, server, testingVariables, "History Project")
+	helper.CreateTask(t, &client, server, testingVariables, 0, "History Task")
+	taskID := testingVariables.TaskID[0]
+
+	helper.StartTask(t, &client, server, testingVariables, taskID)
+	helper.StopTask(t, &client, server, testingVariables, taskID)
+
+	timeRecords := helper.GetTimeRecords(t, &client, server, testingVariables, taskID)
+	if len(timeRecords) != 1 || !timeRecords[0].IsClosed {
+		t.Fatalf("❌ Expected one closed time record, got %+v", timeRecords)
+	}
+	timeRecordID := strconv.FormatUint(timeRecords[0].ID, 10)
+
+	// undo the stop
+	reopened := helper.UndoTimeRecordAction(t, &client, server, testingVariables)
+	if reopened.IsClosed || reopened.EndTime != nil {
+		t.Fatalf("❌ Undo did not reopen the time record: %+v", reopened)
+	}
+	helper.StopTask(t, &client, server, testingVariables, taskID)
+
+	editResp := helper.DoPutchAuth(
+		t,
+		&client,
+		server.URL+"/api/time-records/update/"+timeRecordID,
+		map[string]string{"description": "Mistaken edit"},
+		testingVariables.AuthToken,
+	)
+	if editResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Time record edit failed: status %d", editResp.StatusCode)
+	}
+
+	history := helper.GetTimeRecordHistory(t, &client, server, testingVariables, timeRecords[0].ID)
+	expected := []string{"create", "stop", "undo", "stop", "edit"}
+	if len(history) != len(expected) {
+		t.Fatalf("❌ Expected %d versions, got %+v", len(expected), history)
+	}
+	for i, operation := range expected {
+		if history[i].Operation != operation || history[i].Version != i+1 {
+			t.Fatalf("❌ Unexpected version %d: %+v", i+1, history[i])
+		}
+	}
+
+	restoreResp := helper.DoPostAuth(
+		t,
+		&client,
+		server.URL+"/api/time-records/restore/"+timeRecordID,
+		map[string]int{"version": 4},
+		testingVariables.AuthToken,
+	)
+	if restoreResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Time record restore failed: status %d", restoreResp.StatusCode)
+	}
+	var restored helper.TimeRecord
+	helper.DecodeJSON(t, restoreResp.Body, &restored)
+	if restored.Description != "" || !restored.IsClosed {
+		t.Fatalf("❌ Time record was not restored to version 4: %+v", restored)
+	}
+
+	deleteResp := helper.DoDeleteAuth(
+		t,
+		&client,
+		server.URL+"/api/time-records/delete/"+timeRecordID,
+		nil,
+		testingVariables.AuthToken,
+	)
+	if deleteResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Time record delete failed: status %d", deleteResp.StatusCode)
+	}
+	undeleted := helper.UndoTimeRecordAction(t, &client, server, testingVariables)
+	if undeleted.ID != timeRecords[0].ID {
+		t.Fatalf("❌ Undo did not bring the deleted time record back: %+v", undeleted)
+	}
+	t.Logf("✅ Successfully restored time record %s", timeRecordID)
+}
+
+func TestTimeRecordListByDatesOfUserTimeZone(t *testing.T) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := http.Client{}
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, &client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+	settingsResp := helper.DoPutchAuth(
+		t,
+		&client,
+		server.URL+"/api/user/settings",
+		map[string]string{"time_zone": "Europe/Berlin"},
+		testingVariables.AuthToken,
+	)
+	if settingsResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Time zone update failed: status %d", settingsResp.StatusCode)
+	}
+	helper.CreateProject(t, &client, server, testingVariables, "Dates Project")
+	helper.CreateTask(t, &client, server, testingVariables, 0, "Dates Task")
+	taskID := testingVariables.TaskID[0]
+
+	// Berlin is one hour ahead of UTC in March, only the middle records start on March 10 there
+	for _, start := range []string{
+		"2025-03-09T22:30:00Z",
+		"2025-03-09T23:30:00Z",
+		"2025-03-10T22:30:00Z",
+		"2025-03-10T23:30:00Z",
+	} {
+		startTime, _ := time.Parse(time.RFC3339, start)
+		createResp := helper.DoPostAuth(t, &client, server.URL+"/api/time-records/create", map[string]any{
+			"task_id":    taskID,
+			"start_time": startTime,
+			"end_time":   startTime.Add(10 * time.Minute),
+		}, testingVariables.AuthToken)
+		if createResp.StatusCode != http.StatusCreated {
+			t.Fatalf("❌ Time record create failed: status %d", createResp.StatusCode)
+		}
+	}
+
+	listResp := helper.DoGetAuth(
+		t,
+		&client,
+		server.URL+"/api/time-records/list?from_date=2025-03-10&to_date=2025-03-10",
+		testingVariables.AuthToken,
+	)
+	if listResp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Time record list failed: status %d", listResp.StatusCode)
+	}
+	var timeRecords []struct {
+		StartTime time.Time `json:"start_time"`
+	}
+	helper.DecodeJSON(t, listResp.Body, &timeRecords)
+	if len(timeRecords) != 2 ||
+		!timeRecords[0].StartTime.Equal(time.Date(2025, time.March, 10, 22, 30, 0, 0, time.UTC)) ||
+		!timeRecords[1].StartTime.Equal(time.Date(2025, time.March, 9, 23, 30, 0, 0, time.UTC)) {
+		t.Fatalf("❌ Expected the records of March 10 in Berlin, got %+v", timeRecords)
+	}
+
+	conflictResp := helper.DoGetAuth(
+		t,
+		&client,
+		server.URL+"/api/time-records/list?from_date=2025-03-10&from=2025-03-10T00:00:00Z",
+		testingVariables.AuthToken,
+	)
+	if conflictResp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("❌ Expected a date and a time for the start to be rejected, got status %d", conflictResp.StatusCode)
+	}
+	t.Logf("✅ Successfully listed time records by the days of the user's time zone")
+}
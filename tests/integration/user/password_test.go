@@ -4,28 +4,26 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	"github.com/advanced-coder-com/go-timekeeper/internal/apperror"
 	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
 	"github.com/google/uuid"
-	"github.com/spf13/viper"
 	"net/http"
 	"net/http/httptest"
 	"os"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/db"
 	"github.com/advanced-coder-com/go-timekeeper/internal/router"
 	"github.com/gin-gonic/gin"
 )
 
 func TestChangePasswordSuccess(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -59,13 +57,13 @@ func TestChangePasswordSuccess(t *testing.T) {
 
 func TestChangePasswordFailsWithInvalidOldPassword(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -88,24 +86,18 @@ func TestChangePasswordFailsWithInvalidOldPassword(t *testing.T) {
 		t.Fatalf("❌ Password change should have failed with invalid old password. Email: %s", testingVariables.Email)
 	}
 
-	var errorMessage = helper.ErrorMessage
-	helper.DecodeJSON(t, resp.Body, &errorMessage)
-	if errorMessage.ErrorMessage == service.ErrUserChangePasswordFailed.Error() {
-		t.Logf("✅ Password change failed as expected with invalid old password. Error: %s", errorMessage.ErrorMessage)
-	} else {
-		t.Fatalf("❌ Unexpected error message. Email: %s, got: %s", testingVariables.Email, errorMessage.ErrorMessage)
-	}
+	expectFieldError(t, resp, "old_password")
 }
 
 func TestChangePasswordFailsWithSameOldAndNewPassword(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -127,11 +119,18 @@ func TestChangePasswordFailsWithSameOldAndNewPassword(t *testing.T) {
 		t.Fatalf("❌ Password change should have failed with same old and new password. Email: %s", testingVariables.Email)
 	}
 
-	var errorMessage = helper.ErrorMessage
-	helper.DecodeJSON(t, resp.Body, &errorMessage)
-	if errorMessage.ErrorMessage == "Old password must not be same as a new one" {
-		t.Logf("✅ Password change failed as expected with same old and new password. Error: %s", errorMessage.ErrorMessage)
-	} else {
-		t.Fatalf("❌ Unexpected error message. Email: %s, got: %s", testingVariables.Email, errorMessage.ErrorMessage)
+	expectFieldError(t, resp, "new_password")
+}
+
+// expectFieldError checks that resp is a validation failure of field alone
+func expectFieldError(t *testing.T, resp *http.Response, field string) {
+	t.Helper()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("❌ Expected status 422, got %d", resp.StatusCode)
+	}
+	var apiError apperror.Response
+	helper.DecodeJSON(t, resp.Body, &apiError)
+	if apiError.Code != apperror.CodeValidationFailed || len(apiError.Fields) != 1 || apiError.Fields[0].Field != field {
+		t.Fatalf("❌ Expected a validation error of %s, got %+v", field, apiError)
 	}
 }
@@ -7,25 +7,23 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
-	"github.com/spf13/viper"
 	"net/http"
 	"net/http/httptest"
 	"os"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/db"
 	"github.com/advanced-coder-com/go-timekeeper/internal/router"
 	"github.com/gin-gonic/gin"
 )
 
 func TestSignInSuccess(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -47,13 +45,13 @@ func TestSignInSuccess(t *testing.T) {
 
 func TestSignInFailsWithIncorrectPassword(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
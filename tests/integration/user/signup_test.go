@@ -6,27 +6,24 @@ import (
 	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
 	"testing"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/repository"
 	"github.com/google/uuid"
-	"github.com/spf13/viper"
 	"net/http"
 	"net/http/httptest"
 	"os"
 
-	"github.com/advanced-coder-com/go-timekeeper/internal/db"
 	"github.com/advanced-coder-com/go-timekeeper/internal/router"
 	"github.com/gin-gonic/gin"
 )
 
 func TestSignUpSuccess(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -40,7 +37,7 @@ func TestSignUpSuccess(t *testing.T) {
 		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
 	}
 
-	userRepo := repository.NewUserRepository()
+	userRepo := container.Repositories.Users
 	user, err := userRepo.GetByEmail(context.Background(), testingVariables.Email)
 	if err != nil {
 		t.Fatalf("❌ Could not find created user by email: %s. Error: %v", testingVariables.Email, err)
@@ -50,13 +47,13 @@ func TestSignUpSuccess(t *testing.T) {
 
 func TestSignUpFailsWithDuplicateEmail(t *testing.T) {
 	_ = os.Setenv("APP_ENV_FILE", ".env.test")
-	helper.InitConfig("../../../.env.test")
-	fmt.Println(viper.GetString("DB_HOST"))
-	db.Init()
+	cfg := helper.InitConfig("../../../.env.test")
+	fmt.Println(cfg.Database.Host)
+	container := helper.NewContainer(cfg)
 
 	engine := gin.Default()
 	gin.SetMode(gin.TestMode)
-	router.SetupRoutes(engine)
+	router.SetupRoutes(engine, container)
 
 	server := httptest.NewServer(engine)
 	defer server.Close()
@@ -0,0 +1,325 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/advanced-coder-com/go-timekeeper/internal/app"
+	"github.com/advanced-coder-com/go-timekeeper/internal/router"
+	"github.com/advanced-coder-com/go-timekeeper/internal/service"
+	helper "github.com/advanced-coder-com/go-timekeeper/tests/integration/helper"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+	at     time.Time
+}
+
+// receiver records the webhooks it gets and answers them with the next status of its script,
+// the last status is repeated once the script is used up
+type receiver struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	statuses []int
+	received chan receivedWebhook
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	webhookReceiver := &receiver{statuses: statuses, received: make(chan receivedWebhook, 10)}
+	webhookReceiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		webhookReceiver.received <- receivedWebhook{header: r.Header.Clone(), body: body, at: time.Now()}
+
+		webhookReceiver.mutex.Lock()
+		status := webhookReceiver.statuses[0]
+		if len(webhookReceiver.statuses) > 1 {
+			webhookReceiver.statuses = webhookReceiver.statuses[1:]
+		}
+		webhookReceiver.mutex.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(webhookReceiver.Close)
+	return webhookReceiver
+}
+
+func (webhookReceiver *receiver) await(t *testing.T) receivedWebhook {
+	t.Helper()
+	select {
+	case webhook := <-webhookReceiver.received:
+		return webhook
+	case <-time.After(10 * time.Second):
+		t.Fatal("❌ Webhook was not delivered")
+	}
+	return receivedWebhook{}
+}
+
+// newServer serves the API with both dispatchers running, webhooks may reach the local receiver
+func newServer(t *testing.T) (*httptest.Server, *app.Container) {
+	_ = os.Setenv("APP_ENV_FILE", ".env.test")
+	cfg := helper.InitConfig("../../../.env.test")
+	cfg.Webhooks.AllowPrivateNetworks = true
+	container := helper.NewContainer(cfg)
+
+	engine := gin.Default()
+	gin.SetMode(gin.TestMode)
+	router.SetupRoutes(engine, container)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	container.WebhookDispatcher.PollInterval = 100 * time.Millisecond
+	container.OutboxDispatcher.PollInterval = 100 * time.Millisecond
+	return server, container
+}
+
+func runDispatchers(t *testing.T, container *app.Container) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go container.WebhookDispatcher.Run(ctx)
+	go container.OutboxDispatcher.Run(ctx)
+}
+
+func signUp(t *testing.T, client *http.Client, server *httptest.Server) *helper.TestingContext {
+	testingVariables := &helper.TestingContext{}
+	testingVariables.Email = "user" + uuid.NewString() + "@example.com"
+	testingVariables.Password = "P@ssw0rd"
+
+	if ok, _ := helper.SignUp(t, client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign up user. Email: %s", testingVariables.Email)
+	}
+	if ok, _ := helper.SignIn(t, client, server, testingVariables); !ok {
+		t.Fatalf("❌ Failed to sign in user. Email: %s", testingVariables.Email)
+	}
+	return testingVariables
+}
+
+// awaitDelivery polls the delivery log of webhookID until its delivery with id deliveryID, or the only one
+// when deliveryID is 0, reaches status
+func awaitDelivery(
+	t *testing.T,
+	client *http.Client,
+	server *httptest.Server,
+	testingVariables *helper.TestingContext,
+	webhookID uint64,
+	deliveryID uint64,
+	status string,
+) helper.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		deliveries := helper.GetWebhookDeliveries(t, client, server, testingVariables, webhookID)
+		for _, delivery := range deliveries {
+			matches := delivery.ID == deliveryID || (deliveryID == 0 && len(deliveries) == 1)
+			if matches && delivery.Status == status {
+				return delivery
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("❌ Delivery was not logged as %s: %+v", status, deliveries)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestWebhookDeliveredWithSignature(t *testing.T) {
+	server, container := newServer(t)
+	runDispatchers(t, container)
+	webhookReceiver := newReceiver(t, http.StatusNoContent)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+
+	secret := "webhook-secret"
+	webhookID := helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, secret, []string{"task.created"})
+
+	helper.CreateProject(t, &client, server, testingVariables, "Webhook Project")
+	helper.CreateTask(t, &client, server, testingVariables, 0, "Webhook Task")
+
+	webhook := webhookReceiver.await(t)
+	if eventType := webhook.header.Get(service.WebhookEventHeader); eventType != "task.created" {
+		t.Fatalf("❌ Unexpected event type header: %s", eventType)
+	}
+	timestamp := webhook.header.Get(service.WebhookTimestampHeader)
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		t.Fatalf("❌ Invalid timestamp header: %v", err)
+	}
+	// recompute the signature the way the Readme tells receivers to
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(webhook.body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if signature := webhook.header.Get(service.WebhookSignatureHeader); !hmac.Equal([]byte(signature), []byte(expected)) {
+		t.Fatalf("❌ Invalid signature. Expected %s, got %s", expected, signature)
+	}
+
+	awaitDelivery(t, &client, server, testingVariables, webhookID, 0, "Succeeded")
+	t.Logf("✅ Successfully delivered signed webhook %d", webhookID)
+}
+
+func TestWebhookRetriedWithBackoff(t *testing.T) {
+	server, container := newServer(t)
+	baseBackoff := 300 * time.Millisecond
+	container.WebhookDispatcher.BaseBackoff = baseBackoff
+	container.WebhookDispatcher.MaxAttempts = 5
+	runDispatchers(t, container)
+	webhookReceiver := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+
+	webhookID := helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, "retry-secret", []string{"project.created"})
+	helper.CreateProject(t, &client, server, testingVariables, "Retried Project")
+
+	attempts := []receivedWebhook{webhookReceiver.await(t), webhookReceiver.await(t), webhookReceiver.await(t)}
+	for i := 1; i < len(attempts); i++ {
+		if attempts[i].header.Get(service.WebhookDeliveryHeader) != attempts[0].header.Get(service.WebhookDeliveryHeader) {
+			t.Fatalf("❌ Attempt %d was sent as another delivery", i+1)
+		}
+		// the delay doubles after every failed attempt
+		backoff := baseBackoff << (i - 1)
+		if gap := attempts[i].at.Sub(attempts[i-1].at); gap < backoff {
+			t.Fatalf("❌ Attempt %d came %s after the previous one, want at least %s", i+1, gap, backoff)
+		}
+	}
+
+	delivery := awaitDelivery(t, &client, server, testingVariables, webhookID, 0, "Succeeded")
+	if delivery.Attempts != 3 {
+		t.Fatalf("❌ Expected 3 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestWebhookGivesUpAfterMaxAttempts(t *testing.T) {
+	server, container := newServer(t)
+	container.WebhookDispatcher.BaseBackoff = 100 * time.Millisecond
+	container.WebhookDispatcher.MaxAttempts = 2
+	runDispatchers(t, container)
+	webhookReceiver := newReceiver(t, http.StatusServiceUnavailable)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+
+	webhookID := helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, "failing-secret", []string{"project.created"})
+	helper.CreateProject(t, &client, server, testingVariables, "Failing Project")
+
+	delivery := awaitDelivery(t, &client, server, testingVariables, webhookID, 0, "Failed")
+	if delivery.Attempts != 2 {
+		t.Fatalf("❌ Expected 2 attempts, got %d", delivery.Attempts)
+	}
+	webhookReceiver.await(t)
+	webhookReceiver.await(t)
+	select {
+	case <-webhookReceiver.received:
+		t.Fatal("❌ Webhook was sent again after the last attempt")
+	case <-time.After(time.Second):
+	}
+}
+
+func TestWebhookRedeliver(t *testing.T) {
+	server, container := newServer(t)
+	runDispatchers(t, container)
+	webhookReceiver := newReceiver(t, http.StatusNoContent)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+	stranger := signUp(t, &client, server)
+
+	webhookID := helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, "redeliver-secret", []string{"project.created"})
+	helper.CreateProject(t, &client, server, testingVariables, "Redelivered Project")
+	original := webhookReceiver.await(t)
+	delivered := awaitDelivery(t, &client, server, testingVariables, webhookID, 0, "Succeeded")
+
+	resp := helper.DoPostAuth(t, &client, server.URL+"/api/webhooks/redeliver/"+strconv.FormatUint(delivered.ID, 10), nil, stranger.AuthToken)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("❌ Redelivering a delivery of another user: expected status 404, got %d", resp.StatusCode)
+	}
+
+	redelivery := helper.RedeliverWebhook(t, &client, server, testingVariables, delivered.ID)
+	if redelivery.ID == delivered.ID || redelivery.EventID != delivered.EventID || redelivery.Status != "Pending" {
+		t.Fatalf("❌ Unexpected redelivery %+v of %+v", redelivery, delivered)
+	}
+
+	resent := webhookReceiver.await(t)
+	if string(resent.body) != string(original.body) {
+		t.Fatalf("❌ Redelivered payload differs.\nOriginal: %s\nRedelivered: %s", original.body, resent.body)
+	}
+	if header := resent.header.Get(service.WebhookDeliveryHeader); header != strconv.FormatUint(redelivery.ID, 10) {
+		t.Fatalf("❌ Redelivery was sent as delivery %s, want %d", header, redelivery.ID)
+	}
+	awaitDelivery(t, &client, server, testingVariables, webhookID, redelivery.ID, "Succeeded")
+	if original := awaitDelivery(t, &client, server, testingVariables, webhookID, delivered.ID, "Succeeded"); original.Attempts != 1 {
+		t.Fatalf("❌ Redelivery changed the original delivery: %+v", original)
+	}
+}
+
+func TestWebhookDeliveredOnceByConcurrentDispatchers(t *testing.T) {
+	server, container := newServer(t)
+	runDispatchers(t, container)
+	// a second instance on the same database competes for the deliveries
+	cfg := helper.InitConfig("../../../.env.test")
+	cfg.Webhooks.AllowPrivateNetworks = true
+	other := helper.NewContainer(cfg)
+	other.WebhookDispatcher.PollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go other.WebhookDispatcher.Run(ctx)
+
+	webhookReceiver := newReceiver(t, http.StatusNoContent)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+	helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, "concurrent-secret", []string{"project.created"})
+
+	const projects = 5
+	for i := range projects {
+		helper.CreateProject(t, &client, server, testingVariables, "Concurrent Project "+strconv.Itoa(i))
+	}
+	sent := make(map[string]bool)
+	for range projects {
+		webhook := webhookReceiver.await(t)
+		deliveryID := webhook.header.Get(service.WebhookDeliveryHeader)
+		if sent[deliveryID] {
+			t.Fatalf("❌ Delivery %s was sent twice", deliveryID)
+		}
+		sent[deliveryID] = true
+	}
+	select {
+	case webhook := <-webhookReceiver.received:
+		t.Fatalf("❌ Delivery %s was sent again", webhook.header.Get(service.WebhookDeliveryHeader))
+	case <-time.After(time.Second):
+	}
+}
+
+func TestWebhookOfDeactivatedSubscriptionIsNotSent(t *testing.T) {
+	server, container := newServer(t)
+	runDispatchers(t, container)
+	webhookReceiver := newReceiver(t, http.StatusNoContent)
+	client := http.Client{}
+	testingVariables := signUp(t, &client, server)
+
+	webhookID := helper.CreateWebhook(t, &client, server, testingVariables, webhookReceiver.URL, "inactive-secret", []string{"project.created"})
+	helper.CreateProject(t, &client, server, testingVariables, "Deactivated Project")
+	webhookReceiver.await(t)
+	delivered := awaitDelivery(t, &client, server, testingVariables, webhookID, 0, "Succeeded")
+
+	url := server.URL + "/api/webhooks/update/" + strconv.FormatUint(webhookID, 10)
+	resp := helper.DoPutchAuth(t, &client, url, map[string]any{"is_active": false}, testingVariables.AuthToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("❌ Failed to deactivate webhook: status %d", resp.StatusCode)
+	}
+
+	redelivery := helper.RedeliverWebhook(t, &client, server, testingVariables, delivered.ID)
+	if given := awaitDelivery(t, &client, server, testingVariables, webhookID, redelivery.ID, "Failed"); given.Attempts != 0 {
+		t.Fatalf("❌ Delivery of the deactivated webhook was attempted: %+v", given)
+	}
+	select {
+	case <-webhookReceiver.received:
+		t.Fatal("❌ Webhook was sent to a deactivated subscription")
+	case <-time.After(time.Second):
+	}
+}